@@ -0,0 +1,21 @@
+# Look up an existing object on every plan without managing it
+data "http_request" "current_user" {
+  path = "/users/me"
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.id"
+}
+
+# Tolerate a 404 to check whether something exists
+data "http_request" "optional_widget" {
+  path                   = "/widgets/legacy"
+  tolerated_status_codes = [404]
+}
+
+output "current_user_id" {
+  value = data.http_request.current_user.response_body_id
+}
+
+output "legacy_widget_exists" {
+  value = data.http_request.optional_widget.response_code == 200
+}
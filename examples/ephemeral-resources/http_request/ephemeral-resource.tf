@@ -0,0 +1,21 @@
+# Obtain a short-lived token from a login endpoint without writing it to state
+ephemeral "http_request" "login" {
+  method = "POST"
+  path   = "/oauth/token"
+  request_body = jsonencode({
+    client_id     = var.client_id
+    client_secret = var.client_secret
+  })
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.access_token"
+}
+
+# Feed it to a provider configuration
+provider "http" {
+  alias = "authenticated"
+  url   = "https://api.example.com"
+  headers = {
+    "Authorization" = "Bearer ${ephemeral.http_request.login.response_body_id}"
+  }
+}
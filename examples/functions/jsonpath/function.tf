@@ -0,0 +1,9 @@
+# Extract a single value from a response body
+output "owner_name" {
+  value = provider::http::jsonpath(http_request.widget.response_body, "$.owner.name")
+}
+
+# Fall back to a default when the value may be absent
+output "etag" {
+  value = try(provider::http::jsonpath(http_request.widget.response_body, "$.meta.etag"), null)
+}
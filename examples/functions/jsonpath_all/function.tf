@@ -0,0 +1,4 @@
+# Collect every id of a paginated listing
+output "widget_ids" {
+  value = provider::http::jsonpath_all(data.http_request.widgets.response_body, "$.items[*].id")
+}
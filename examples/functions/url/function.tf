@@ -0,0 +1,7 @@
+# Build a URL with the provider's joining and query-encoding rules
+output "search_url" {
+  value = provider::http::url("https://api.example.com/v1", "/widgets", {
+    name = "blue widget"
+    page = "2"
+  })
+}
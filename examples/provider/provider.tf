@@ -26,7 +26,167 @@ provider "http" {
   }
 }
 
+# Bearer tokens and API keys have first-class arguments, with environment-variable fallbacks
+# (PROVIDER_HTTP_BEARER_TOKEN, PROVIDER_HTTP_API_KEY_NAME, PROVIDER_HTTP_API_KEY and
+# PROVIDER_HTTP_API_KEY_IN). A resource's own `bearer_auth` or `api_key` overrides these.
+provider "http" {
+  alias = "token"
+  url   = "https://jsonplaceholder.typicode.com"
+  bearer_auth = {
+    token = var.api_token
+  }
+}
+
+provider "http" {
+  alias = "api_key"
+  url   = "https://jsonplaceholder.typicode.com"
+  api_key = {
+    name  = "api_key"
+    value = var.api_token
+    in    = "query"
+  }
+}
+
+# OAuth 2.0 client credentials: the provider fetches a token, caches it until shortly before it
+# expires, and retries a request rejected with 401 once with a fresh one.
+provider "http" {
+  alias = "oauth2"
+  url   = "https://api.example.com"
+  oauth2 = {
+    token_url     = "https://login.example.com/oauth2/token"
+    client_id     = var.client_id
+    client_secret = var.client_secret
+    scopes        = ["widgets.read", "widgets.write"]
+    audience      = "https://api.example.com"
+  }
+}
+
+# An API Gateway endpoint protected by IAM: every request is signed with AWS Signature Version 4.
+# The credentials come from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+provider "http" {
+  alias = "aws"
+  url   = "https://abc123.execute-api.eu-west-1.amazonaws.com/prod"
+  aws_sigv4 = {
+    region  = "eu-west-1"
+    service = "execute-api"
+  }
+}
+
+# A switch management API that only accepts HTTP Digest authentication.
+provider "http" {
+  alias = "digest"
+  url   = "https://switch-01.example.com"
+  digest_auth = {
+    username = "admin"
+    password = var.switch_password
+  }
+}
+
+# An API served with a certificate from a private CA, requiring a client certificate.
+provider "http" {
+  alias            = "mtls"
+  url              = "https://api.internal.example.com"
+  ca_cert_file     = "/etc/ssl/internal-ca.pem"
+  client_cert_file = "/etc/ssl/client.pem"
+  client_key_file  = "/etc/ssl/client-key.pem"
+}
+
+# Every request goes through the corporate proxy except those to internal hosts.
+provider "http" {
+  alias     = "proxy"
+  url       = "https://api.example.com"
+  proxy_url = "http://proxy.corp.example.com:3128"
+  no_proxy  = [".internal.example.com", "10.0.0.0/8"]
+}
+
+# A large configuration against a single API: keep more connections open for reuse.
+provider "http" {
+  alias                = "pooled"
+  url                  = "https://api.example.com"
+  max_idle_conns       = 200
+  idle_conn_timeout_ms = 120000
+}
+
+# A vendor API that throttles at 10 requests per second.
+provider "http" {
+  alias = "throttled"
+  url   = "https://api.example.com"
+
+  rate_limit {
+    requests_per_second = 10
+  }
+  max_concurrent_requests = 4
+}
+
+# An admin console without API tokens: log in with its form once and reuse the session cookie.
+provider "http" {
+  alias = "console"
+  url   = "https://console.example.com"
+
+  session {
+    login_path = "/j_security_check"
+    headers = {
+      "Content-Type" = "application/x-www-form-urlencoded"
+    }
+    request_body   = "j_username=admin&j_password=${var.console_password}"
+    success_cookie = "JSESSIONID"
+    logout_path    = "/logout"
+  }
+}
+
+# One provider for several services: each resource selects its service with `endpoint`.
+provider "http" {
+  alias = "services"
+
+  endpoint {
+    name = "billing"
+    url  = "https://billing.internal.example.com/api"
+    basic_auth = {
+      username = "terraform"
+      password = var.billing_password
+    }
+    headers = {
+      "X-Tenant" = "acme"
+    }
+  }
+
+  endpoint {
+    name               = "inventory"
+    url                = "https://inventory.lab.example.com"
+    ignore_tls         = true
+    request_timeout_ms = 5000
+
+    retry {
+      attempts = 3
+    }
+  }
+}
+
+variable "client_id" {
+  type = string
+}
+
+variable "client_secret" {
+  type      = string
+  sensitive = true
+}
+
 variable "api_token" {
   type      = string
   sensitive = true
 }
+
+variable "switch_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "console_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "billing_password" {
+  type      = string
+  sensitive = true
+}
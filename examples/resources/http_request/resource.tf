@@ -237,3 +237,338 @@ output "watched_import_id" {
   description = "Run: terraform import http_request.watched \"$(terraform output -raw watched_import_id)\""
   value       = http_request.watched.import_id
 }
+
+# 13) Update the created object in place instead of creating another one
+# Without `update_method`, changing a request argument replaces the resource, and for a POST that
+# creates a second remote object. With it, a change to `method`, `path`, `headers`, `request_body`
+# or `query_parameters` sends the update request instead. `update_path` supports the same inline
+# JSONPath tokens as `delete_path`, evaluated against the response captured before the update;
+# `update_headers` and `update_request_body` default to `headers` and `request_body`.
+resource "http_request" "widget" {
+  method = "POST"
+  path   = "/posts"
+
+  request_body = jsonencode({
+    title = "renamed"
+  })
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.id"
+
+  update_method = "PATCH"
+  update_path   = "/posts/$.id"
+
+  is_delete_enabled = true
+  delete_path       = "/posts/$.id"
+}
+
+# 14) Wait for an asynchronous operation to finish
+# Some APIs answer `202 Accepted` with an operation to follow instead of doing the work inline.
+# `wait_for` polls that operation after create, update and destroy, so the apply only finishes once
+# the job has actually completed. The poll `path` supports inline JSONPath tokens evaluated against
+# the response that started the operation, and `capture_response` records the final poll response
+# instead of the `202`.
+resource "http_request" "provisioned" {
+  method = "POST"
+  path   = "/posts"
+
+  request_body = jsonencode({
+    title = "provisioned"
+  })
+
+  wait_for {
+    path              = "/operations/$.operation_id"
+    success_json_path = "$.status"
+    success_value     = "succeeded"
+    failure_json_path = "$.status"
+    failure_value     = "failed"
+    interval_ms       = 5000
+    timeout_ms        = 900000
+  }
+}
+
+# 15) Follow the Location header of a `201 Created`
+# `capture_response_headers` records the listed response headers in `response_headers`; nothing is
+# recorded unless it is listed, so a `Set-Cookie` never reaches state by accident. `delete_path`,
+# `refresh_path`, `update_path` and the `wait_for` path accept `${header.Name}` tokens next to `$.`
+# JSONPath ones -- written `$${header.Name}` in HCL so Terraform does not treat it as an
+# interpolation. A header holding an absolute URL contributes only its path.
+resource "http_request" "located" {
+  method = "POST"
+  path   = "/posts"
+
+  request_body = jsonencode({
+    title = "located"
+  })
+
+  capture_response_headers = ["Location", "ETag"]
+
+  is_refresh_enabled = true
+  refresh_path       = "$${header.Location}"
+
+  is_delete_enabled = true
+  delete_path       = "$${header.Location}"
+}
+
+# 16) Refresh through a search endpoint
+# Some APIs only read an object through a `POST` search. `refresh_method`, `refresh_headers` and
+# `refresh_request_body` describe that request. Only the statuses in `refresh_gone_status_codes`
+# (by default 404 and 410) mean the object was deleted; any other failure fails the refresh instead
+# of silently dropping the resource from state.
+resource "http_request" "searched" {
+  method = "POST"
+  path   = "/posts"
+
+  request_body = jsonencode({
+    title = "searched"
+  })
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.id"
+
+  is_refresh_enabled   = true
+  refresh_method       = "POST"
+  refresh_path         = "/posts/search"
+  refresh_request_body = jsonencode({ title = "searched" })
+  refresh_headers = {
+    "Accept" = "application/json"
+  }
+  refresh_gone_status_codes = [404, 410]
+}
+
+# 17) Reconcile drift of selected fields (PUT-style APIs)
+# `drift_detection` compares the listed fields of `request_body` with the refresh response. A field
+# changed outside Terraform is written back into `request_body` with its remote value, so the next
+# plan shows `request_body` changing back to the configured value -- sent in place by `update_method`.
+# `response_json_paths` covers a field the API returns under a different path.
+resource "http_request" "reconciled" {
+  method = "PUT"
+  path   = "/settings/retention"
+
+  request_body = jsonencode({
+    days    = 30
+    enabled = true
+  })
+
+  is_refresh_enabled = true
+  update_method      = "PUT"
+
+  drift_detection {
+    json_paths = ["$.days", "$.enabled"]
+    response_json_paths = {
+      "$.days" = "$.retention.days"
+    }
+  }
+}
+
+# 18) Wait until an asynchronously deleted object is really gone
+# Some APIs accept a DELETE and remove the object later. `delete_wait` keeps destroy going until a
+# GET against the deleted path (or `delete_wait.path`) answers 404, so recreating an object with
+# the same name right after does not conflict with the one still being deleted.
+resource "http_request" "deleted_in_background" {
+  method       = "POST"
+  path         = "/buckets"
+  request_body = jsonencode({ name = "logs" })
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.id"
+
+  is_delete_enabled = true
+  delete_path       = "/buckets/$.id"
+
+  delete_wait {
+    gone_status_codes = [404]
+    interval_ms       = 5000
+    timeout_ms        = 300000
+  }
+}
+
+# 19) Token authentication per resource
+# `bearer_auth` and `api_key` override the provider-level credentials for this resource only. Like
+# `basic_auth`, a changed credential re-sends the request in place unless listed in `ignore_changes`.
+resource "http_request" "with_api_key" {
+  method = "GET"
+  path   = "/reports/daily"
+
+  bearer_auth = {
+    token = var.reports_token
+  }
+  api_key = {
+    name  = "X-Tenant-Key"
+    value = var.tenant_key
+  }
+
+  ignore_changes = ["bearer_auth", "api_key"]
+}
+
+# 20) Mutual TLS against a partner API
+# The client certificate and key are read from disk on every request; `ca_cert_pem` trusts a
+# private CA in addition to the system pool.
+resource "http_request" "mutual_tls" {
+  method   = "POST"
+  base_url = "https://partner.example.com"
+  path     = "/orders"
+
+  request_body = jsonencode({
+    sku      = "A-100"
+    quantity = 2
+  })
+
+  ca_cert_pem      = file("${path.module}/partner-ca.pem")
+  client_cert_file = "/etc/ssl/partner/client.pem"
+  client_key_file  = "/etc/ssl/partner/client-key.pem"
+  tls_server_name  = "orders.partner.example.com"
+  min_tls_version  = "1.3"
+}
+
+# 21) Through a partner's egress proxy
+# `proxy_url` overrides the provider-level proxy for this resource only; `no_proxy = ["*"]` would
+# instead reach the API directly.
+resource "http_request" "through_proxy" {
+  method   = "GET"
+  base_url = "https://partner.example.com"
+  path     = "/catalog"
+
+  proxy_url = "socks5h://egress.partner.example.com:1080"
+  proxy_basic_auth = {
+    username = "terraform"
+    password = var.proxy_password
+  }
+}
+
+# 22) Redirect policy
+# A POST that gets redirected keeps its method and body, and a redirect to a login page on another
+# host fails the apply instead of recording the page. `final_url` reports where the response came from.
+resource "http_request" "redirect_policy" {
+  method = "POST"
+  path   = "/legacy/orders"
+
+  request_body = jsonencode({
+    sku = "A-100"
+  })
+
+  max_redirects               = 2
+  preserve_method_on_redirect = true
+  forward_auth_on_redirect    = false
+}
+
+output "orders_final_url" {
+  value = http_request.redirect_policy.final_url
+}
+
+# 23) Retrying an order submission safely
+# The API answers `202` with a `status` of `busy` while it is saturated and `409` while a previous
+# submission is still being processed. Neither was processed, so the POST may be retried on them.
+resource "http_request" "order_submission" {
+  method = "POST"
+  path   = "/orders"
+
+  request_body = jsonencode({
+    sku = "B-200"
+  })
+
+  retry {
+    attempts              = 4
+    retry_on_status_codes = [409, 429, 503]
+    retry_on_body_match   = "$[?(@.status == 'busy')]"
+    retry_non_idempotent  = true
+    jitter                = true
+  }
+}
+
+# 24) Signing requests for a payment gateway
+# The gateway expects an HMAC-SHA256 of the method, path, timestamp and body hash in
+# `X-Signature`, and the timestamp in `X-Timestamp` so it can reject replays. Template tokens are
+# written `$${name}` so Terraform does not interpolate them.
+resource "http_request" "signed_charge" {
+  method = "POST"
+  path   = "/v1/charges"
+
+  request_body = jsonencode({
+    amount   = 1000
+    currency = "eur"
+  })
+
+  hmac_signature = {
+    secret           = var.gateway_secret
+    template         = "$${method}\n$${path}\n$${timestamp}\n$${body_hash}"
+    header           = "X-Signature"
+    prefix           = "sha256="
+    timestamp_header = "X-Timestamp"
+  }
+}
+
+# 25) Managing a container through the Docker Engine socket
+# The whole path of a `unix://` URL names the socket; `path` is the HTTP path sent over it.
+resource "http_request" "container" {
+  base_url = "unix:///var/run/docker.sock"
+  method   = "POST"
+  path     = "/v1.45/containers/create"
+
+  request_body = jsonencode({
+    Image = "nginx:1.27"
+  })
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.Id"
+
+  is_delete_enabled = true
+  delete_method     = "DELETE"
+  delete_path       = "/v1.45/containers/$.Id"
+}
+
+# 26) Uploading a release artifact
+# The archive is read when the request is sent; state records its path and `request_body_hash`,
+# and a rebuilt archive with new content replaces the upload on the next apply.
+resource "http_request" "release_asset" {
+  method            = "POST"
+  path              = "/releases/42/assets"
+  request_body_file = "${path.module}/dist/app.zip"
+  content_type      = "application/zip"
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.id"
+}
+
+# 27) Uploading a build report as a form
+# The report is streamed from disk in its own part, next to a plain form field; the boundary and
+# the `Content-Type` announcing it are set automatically.
+resource "http_request" "build_report" {
+  method = "POST"
+  path   = "/api/files"
+
+  multipart {
+    part {
+      name  = "channel"
+      value = "releases"
+    }
+    part {
+      name         = "report"
+      file         = "${path.module}/dist/report.csv"
+      content_type = "text/csv"
+    }
+  }
+
+  is_response_body_json   = true
+  response_body_id_filter = "$.file.id"
+}
+
+variable "reports_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tenant_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "proxy_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "gateway_secret" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// MaxCachedClients bounds the clients CachedClient keeps. Settings vary with every rotated
+// certificate file and every resource-level timeout, retry or proxy, so an unbounded cache would
+// hold the idle connections of each variant for the life of the provider.
+const MaxCachedClients = 32
+
+// cachedClient is an entry of the client cache, most recently used first.
+type cachedClient struct {
+	key    string
+	client *http.Client
+}
+
+// ClientSettings are the effective settings an HTTP client is built from. Requests whose settings
+// are equal share one client, and with it one connection pool.
+type ClientSettings struct {
+	TLS       *TLSConfig
+	IgnoreTLS bool
+	Proxy     *ProxyConfig
+	Redirect  RedirectPolicy
+	Timeout   time.Duration
+	Retry     *RetryConfig
+	// Digest is set for the requests answering Digest challenges, whose client keeps the nonces.
+	Digest bool
+	// Socket is the Unix socket the client dials instead of the host of a URL, nil over TCP.
+	Socket *UnixSocket
+}
+
+// key identifies the settings in the client cache. It is a digest so the cache never holds the
+// private keys and passwords the settings may carry in the clear.
+func (it ClientSettings) key() (string, error) {
+	encoded, err := json.Marshal(it)
+	if err != nil {
+		return "", fmt.Errorf("encoding the client settings: %w", err)
+	}
+	digest := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// CachedClient returns the client built for equal settings before, calling build to create it the
+// first time. It is safe for the concurrent CRUD calls Terraform makes against one provider.
+//
+// Past MaxCachedClients, the least recently used client is dropped and its idle connections are
+// closed. A request still holding it completes, and its connections close as they become idle.
+func (it *InternalContext) CachedClient(
+	settings ClientSettings, build func() (*http.Client, error),
+) (*http.Client, error) {
+	key, err := settings.key()
+	if err != nil {
+		return nil, err
+	}
+
+	it.clientsMutex.Lock()
+	defer it.clientsMutex.Unlock()
+
+	if element, ok := it.clients[key]; ok {
+		it.clientsByUse.MoveToFront(element)
+
+		return element.Value.(*cachedClient).client, nil //nolint:forcetypeassert // the list only holds entries
+	}
+
+	client, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if it.clients == nil {
+		it.clients = make(map[string]*list.Element)
+		it.clientsByUse = list.New()
+	}
+	it.clients[key] = it.clientsByUse.PushFront(&cachedClient{key: key, client: client})
+
+	for it.clientsByUse.Len() > MaxCachedClients {
+		evicted := it.clientsByUse.Remove(it.clientsByUse.Back()).(*cachedClient) //nolint:forcetypeassert // as above
+		delete(it.clients, evicted.key)
+		evicted.client.CloseIdleConnections()
+	}
+
+	return client, nil
+}
@@ -3,6 +3,19 @@ package entities
 type Configuration struct {
 	URL       string
 	BasicAuth *BasicAuth
+	// BearerAuth and APIKey are the token-based alternatives to BasicAuth. A nil value means the
+	// scheme is not configured at provider level.
+	BearerAuth *BearerAuth
+	APIKey     *APIKey
+	// DigestAuth answers HTTP Digest challenges instead of sending BasicAuth. A nil value means it
+	// is not configured.
+	DigestAuth *DigestAuth
+	// OAuth2 obtains the bearer token through the client credentials grant instead. A nil value
+	// means it is not configured.
+	OAuth2 *OAuth2Config
+	// AWSSigV4 signs every request with AWS Signature Version 4 instead. A nil value means it is not
+	// configured.
+	AWSSigV4 *AWSSigV4Config
 	// Headers are sent on every request this provider makes, before each resource's
 	// own headers, so a resource naming the same header still wins. They exist for
 	// credentials an API expects in a header rather than in basic auth: a bearer
@@ -16,6 +29,39 @@ type Configuration struct {
 	RequestTimeoutMs int64
 	// Retry holds the retry configuration. A nil value means no retries.
 	Retry *RetryConfig
+	// TLS holds the provider-level TLS settings, which the client transport already uses. A nil
+	// value means none is set.
+	TLS *TLSConfig
+	// Proxy holds the provider-level proxy, which the client transport already uses. A nil value
+	// means the environment decides.
+	Proxy *ProxyConfig
+	// Redirect is the provider-level redirect policy. A nil value means the Go client defaults.
+	Redirect *RedirectPolicy
+	// ConnectionPool tunes the connections kept for reuse. A nil value keeps the defaults.
+	ConnectionPool *ConnectionPool
+	// Throttle caps the rate and the concurrency of the requests, which InternalContext.Throttle
+	// enforces. A nil value means no limit.
+	Throttle *ThrottleConfig
+	// Session is the login whose cookies authenticate every request, which InternalContext.Session
+	// performs. A nil value means there is none.
+	Session *SessionConfig
+	// Endpoints are the named profiles a resource selects with `endpoint`, by name. A nil or empty
+	// map means there are none.
+	Endpoints map[string]*Endpoint
+}
+
+// Endpoint is a named profile of the provider: the base URL, credentials, headers and client
+// settings of one service, which a resource that selects it uses instead of the provider-level
+// ones. Each nil or zero field falls back to the provider-level value.
+type Endpoint struct {
+	URL       string
+	BasicAuth *BasicAuth
+	// Headers are applied after the provider-level headers, so an endpoint naming the same header
+	// wins over the provider and loses to the resource.
+	Headers          map[string]string
+	IgnoreTLS        *bool
+	RequestTimeoutMs *int64
+	Retry            *RetryConfig
 }
 
 type BasicAuth struct {
@@ -23,6 +69,23 @@ type BasicAuth struct {
 	Password string
 }
 
+type BearerAuth struct {
+	Token string
+}
+
+// APIKey locations: the key is either sent as a request header or appended to the query string.
+const (
+	APIKeyInHeader = "header"
+	APIKeyInQuery  = "query"
+)
+
+type APIKey struct {
+	Name  string
+	Value string
+	// In is where the key is sent, either APIKeyInHeader or APIKeyInQuery.
+	In string
+}
+
 // RetryConfig describes how a failed HTTP request should be retried. It mirrors
 // the semantics of the upstream hashicorp/http provider's `retry` block.
 type RetryConfig struct {
@@ -33,6 +96,22 @@ type RetryConfig struct {
 	MinDelayMs int64
 	// MaxDelayMs is the maximum delay between retries, in milliseconds.
 	MaxDelayMs int64
+	// RetryOnStatusCodes replaces the statuses that are retried, which by default are 429 and the
+	// 5xx range except 501. Connection errors are retried either way.
+	RetryOnStatusCodes []int
+	// RespectRetryAfter waits for the delay a Retry-After header asks for, up to MaxDelayMs,
+	// instead of the exponential backoff.
+	RespectRetryAfter bool
+	// Jitter spreads each backoff randomly between MinDelayMs and its exponential value, so
+	// concurrent requests do not retry in lockstep.
+	Jitter bool
+	// RetryNonIdempotent retries requests whose method is not idempotent, such as POST, on any
+	// retryable outcome. Otherwise they are only retried when the connection could not be
+	// established or the server answered 429, both meaning the request was not processed.
+	RetryNonIdempotent bool
+	// RetryOnBodyMatch is a JSONPath expression; a JSON response body in which it selects at least
+	// one value is retried. An empty value disables it.
+	RetryOnBodyMatch string
 }
 
 func NewConfiguration(url string) *Configuration {
@@ -44,3 +123,27 @@ func NewConfiguration(url string) *Configuration {
 func (it *Configuration) HasAuthentication() bool {
 	return it != nil && it.BasicAuth != nil && it.BasicAuth.Username != "" && it.BasicAuth.Password != ""
 }
+
+// Endpoint returns the named profile, or nil when there is none by that name.
+func (it *Configuration) Endpoint(name string) *Endpoint {
+	if it == nil {
+		return nil
+	}
+
+	return it.Endpoints[name]
+}
+
+// HasBearerAuth reports whether a provider-level bearer token is usable.
+func (it *Configuration) HasBearerAuth() bool {
+	return it != nil && it.BearerAuth != nil && it.BearerAuth.Token != ""
+}
+
+// HasDigestAuth reports whether provider-level Digest credentials are usable.
+func (it *Configuration) HasDigestAuth() bool {
+	return it != nil && it.DigestAuth != nil && it.DigestAuth.Username != ""
+}
+
+// HasAPIKey reports whether a provider-level API key is usable.
+func (it *Configuration) HasAPIKey() bool {
+	return it != nil && it.APIKey != nil && it.APIKey.Name != "" && it.APIKey.Value != ""
+}
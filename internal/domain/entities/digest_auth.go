@@ -0,0 +1,315 @@
+package entities
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // RFC 7616 still defines MD5, which many appliances only support
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Digest algorithms of RFC 7616 this client answers, in order of preference.
+const (
+	DigestAlgorithmSHA256     = "SHA-256"
+	DigestAlgorithmSHA256Sess = "SHA-256-sess"
+	DigestAlgorithmMD5        = "MD5"
+	DigestAlgorithmMD5Sess    = "MD5-sess"
+)
+
+var digestAlgorithmPreference = []string{
+	DigestAlgorithmSHA256, DigestAlgorithmSHA256Sess, DigestAlgorithmMD5, DigestAlgorithmMD5Sess,
+}
+
+// DigestAuth holds the credentials of HTTP Digest authentication (RFC 7616).
+type DigestAuth struct {
+	Username string
+	Password string
+}
+
+type digestAuthKey struct{}
+
+// WithDigestAuth returns a context whose requests a DigestChallenges transport authenticates with
+// the given credentials.
+func WithDigestAuth(ctx context.Context, credentials *DigestAuth) context.Context {
+	return context.WithValue(ctx, digestAuthKey{}, credentials)
+}
+
+func digestAuthFrom(ctx context.Context) *DigestAuth {
+	credentials, _ := ctx.Value(digestAuthKey{}).(*DigestAuth)
+
+	return credentials
+}
+
+// DigestChallenges answers Digest challenges inside the client and keeps the last one of every
+// origin and user, so later requests to the same realm reuse its nonce instead of being challenged
+// again. A nil *DigestChallenges answers challenges without keeping them.
+type DigestChallenges struct {
+	mutex    sync.Mutex
+	sessions map[string]*digestSession
+}
+
+// digestSession is a challenge being reused, with the count of requests that used its nonce.
+type digestSession struct {
+	challenge  *digestChallenge
+	nonceCount uint32
+}
+
+// NewDigestChallenges returns an empty challenge cache.
+func NewDigestChallenges() *DigestChallenges {
+	return &DigestChallenges{sessions: make(map[string]*digestSession)}
+}
+
+// Wrap returns a round tripper that authenticates the requests whose context carries Digest
+// credentials before next, a nil next meaning http.DefaultTransport. The other requests pass
+// through unchanged.
+func (it *DigestChallenges) Wrap(next http.RoundTripper) http.RoundTripper {
+	if it == nil {
+		it = NewDigestChallenges()
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &digestTransport{next: next, challenges: it}
+}
+
+// authorization returns the Authorization header answering the cached challenge of key, counting
+// the use of its nonce, or "" when there is none.
+func (it *DigestChallenges) authorization(key string, request *http.Request, credentials *DigestAuth) string {
+	it.mutex.Lock()
+	session, ok := it.sessions[key]
+	var challenge *digestChallenge
+	var nonceCount uint32
+	if ok {
+		// read under the lock, as concurrent requests would otherwise send the same count
+		session.nonceCount++
+		challenge, nonceCount = session.challenge, session.nonceCount
+	}
+	it.mutex.Unlock()
+	if !ok {
+		return ""
+	}
+
+	return challenge.authorization(request, credentials, nonceCount)
+}
+
+// store replaces the challenge of key and returns its first authorization.
+func (it *DigestChallenges) store(
+	key string, challenge *digestChallenge, request *http.Request, credentials *DigestAuth,
+) string {
+	it.mutex.Lock()
+	it.sessions[key] = &digestSession{challenge: challenge, nonceCount: 1}
+	it.mutex.Unlock()
+
+	return challenge.authorization(request, credentials, 1)
+}
+
+// digestTransport is the round tripper DigestChallenges.Wrap returns.
+type digestTransport struct {
+	next       http.RoundTripper
+	challenges *DigestChallenges
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport.
+func (it *digestTransport) CloseIdleConnections() {
+	CloseIdleConnections(it.next)
+}
+
+// RoundTrip sends the request with the cached challenge answered, if there is one, and answers the
+// challenge of a `401 Unauthorized` once, which requires a body that can be read again.
+func (it *digestTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	credentials := digestAuthFrom(request.Context())
+	if credentials == nil {
+		return it.next.RoundTrip(request)
+	}
+	key := request.URL.Scheme + "://" + request.URL.Host + "\x00" + credentials.Username
+
+	first := request
+	if authorization := it.challenges.authorization(key, request, credentials); authorization != "" {
+		first = request.Clone(request.Context())
+		first.Header.Set("Authorization", authorization)
+	}
+	response, err := it.next.RoundTrip(first)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	challenge := parseDigestChallenges(response.Header.Values("WWW-Authenticate"))
+	if challenge == nil || (request.Body != nil && request.Body != http.NoBody && request.GetBody == nil) {
+		return response, nil
+	}
+	retry := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, bodyErr := request.GetBody()
+		if bodyErr != nil {
+			return response, nil //nolint:nilerr // the challenge cannot be answered, so its response stands
+		}
+		retry.Body = body
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	retry.Header.Set("Authorization", it.challenges.store(key, challenge, request, credentials))
+
+	return it.next.RoundTrip(retry)
+}
+
+// digestChallenge is a `WWW-Authenticate: Digest` challenge this client can answer.
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	// qopAuth is set when the server offers `qop=auth`; without qop the RFC 2069 form is used.
+	qopAuth bool
+}
+
+// parseDigestChallenges returns the challenge to answer among the values of WWW-Authenticate: the
+// Digest one with the preferred algorithm, or nil when none can be answered.
+func parseDigestChallenges(values []string) *digestChallenge {
+	var best *digestChallenge
+	for _, value := range values {
+		scheme, params, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+
+		fields := parseAuthParams(params)
+		challenge := &digestChallenge{
+			realm:     fields["realm"],
+			nonce:     fields["nonce"],
+			opaque:    fields["opaque"],
+			algorithm: DigestAlgorithmMD5,
+		}
+		if algorithm, ok := fields["algorithm"]; ok {
+			index := slices.IndexFunc(digestAlgorithmPreference, func(known string) bool {
+				return strings.EqualFold(known, algorithm)
+			})
+			if index < 0 {
+				continue
+			}
+			challenge.algorithm = digestAlgorithmPreference[index]
+		}
+		if qop, ok := fields["qop"]; ok {
+			for option := range strings.SplitSeq(qop, ",") {
+				challenge.qopAuth = challenge.qopAuth || strings.TrimSpace(option) == "auth"
+			}
+			if !challenge.qopAuth {
+				// only `auth-int` is offered, which this client does not implement
+				continue
+			}
+		}
+		if challenge.nonce == "" {
+			continue
+		}
+
+		if best == nil || slices.Index(digestAlgorithmPreference, challenge.algorithm) <
+			slices.Index(digestAlgorithmPreference, best.algorithm) {
+			best = challenge
+		}
+	}
+
+	return best
+}
+
+// parseAuthParams parses the comma-separated `name=value` parameters of a challenge, the values
+// being tokens or quoted strings.
+func parseAuthParams(params string) map[string]string {
+	fields := make(map[string]string)
+	for rest := strings.TrimSpace(params); rest != ""; {
+		name, after, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		after = strings.TrimSpace(after)
+
+		var value strings.Builder
+		if strings.HasPrefix(after, `"`) {
+			index := 1
+			for ; index < len(after) && after[index] != '"'; index++ {
+				if after[index] == '\\' && index+1 < len(after) {
+					index++
+				}
+				value.WriteByte(after[index])
+			}
+			after = after[min(index+1, len(after)):]
+		} else {
+			token, _, _ := strings.Cut(after, ",")
+			value.WriteString(strings.TrimSpace(token))
+			after = after[len(token):]
+		}
+		fields[name] = value.String()
+
+		_, rest, _ = strings.Cut(after, ",")
+		rest = strings.TrimSpace(rest)
+	}
+
+	return fields
+}
+
+// authorization computes the Authorization header answering the challenge for a request, the nonce
+// being used for the nonceCount-th time.
+func (it *digestChallenge) authorization(request *http.Request, credentials *DigestAuth, nonceCount uint32) string {
+	digest := func(parts ...string) string {
+		var newHash func() hash.Hash = md5.New
+		if strings.HasPrefix(it.algorithm, DigestAlgorithmSHA256) {
+			newHash = sha256.New
+		}
+		sum := newHash()
+		sum.Write([]byte(strings.Join(parts, ":")))
+
+		return hex.EncodeToString(sum.Sum(nil))
+	}
+
+	uri := request.URL.RequestURI()
+	nc := fmt.Sprintf("%08x", nonceCount)
+	cnonce := newDigestCnonce()
+	ha1 := digest(credentials.Username, it.realm, credentials.Password)
+	if strings.HasSuffix(it.algorithm, "-sess") {
+		ha1 = digest(ha1, it.nonce, cnonce)
+	}
+	ha2 := digest(request.Method, uri)
+
+	fields := []string{
+		fmt.Sprintf("username=%s", quoteAuthParam(credentials.Username)),
+		fmt.Sprintf("realm=%s", quoteAuthParam(it.realm)),
+		fmt.Sprintf("nonce=%s", quoteAuthParam(it.nonce)),
+		fmt.Sprintf("uri=%s", quoteAuthParam(uri)),
+		"algorithm=" + it.algorithm,
+	}
+	if it.qopAuth {
+		fields = append(fields,
+			fmt.Sprintf("response=%q", digest(ha1, it.nonce, nc, cnonce, "auth", ha2)),
+			"qop=auth",
+			"nc="+nc,
+			fmt.Sprintf("cnonce=%s", quoteAuthParam(cnonce)),
+		)
+	} else {
+		fields = append(fields, fmt.Sprintf("response=%q", digest(ha1, it.nonce, ha2)))
+	}
+	if it.opaque != "" {
+		fields = append(fields, fmt.Sprintf("opaque=%s", quoteAuthParam(it.opaque)))
+	}
+
+	return "Digest " + strings.Join(fields, ", ")
+}
+
+// quoteAuthParam writes a quoted-string, escaping its quotes and backslashes.
+func quoteAuthParam(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func newDigestCnonce() string {
+	cnonce := make([]byte, 16)
+	_, _ = rand.Read(cnonce)
+
+	return hex.EncodeToString(cnonce)
+}
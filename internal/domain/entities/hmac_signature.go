@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HMAC signature algorithms.
+const (
+	HMACAlgorithmSHA256 = "sha256"
+	HMACAlgorithmSHA512 = "sha512"
+)
+
+// HMAC signature encodings.
+const (
+	HMACEncodingHex    = "hex"
+	HMACEncodingBase64 = "base64"
+)
+
+// HMAC signature timestamp formats.
+const (
+	HMACTimestampUnix      = "unix"
+	HMACTimestampUnixMilli = "unix_ms"
+	HMACTimestampRFC3339   = "rfc3339"
+)
+
+// DefaultHMACTemplate is the canonical string signed when no template is configured.
+const DefaultHMACTemplate = "${method}\n${path}\n${timestamp}\n${body_hash}"
+
+// HMACTemplateTokens are the tokens a canonical string template may use.
+var HMACTemplateTokens = []string{"method", "host", "path", "query", "timestamp", "nonce", "body_hash"}
+
+// HMACSignatureConfig signs a request with an HMAC over a canonical string built from a template, as
+// payment gateways and webhook receivers commonly require.
+type HMACSignatureConfig struct {
+	Secret string
+	// Algorithm is HMACAlgorithmSHA256 or HMACAlgorithmSHA512; it hashes the body too.
+	Algorithm string
+	// Template is the canonical string, its `${name}` tokens replaced by the values of the request.
+	Template string
+	// Header receives the signature, preceded by Prefix.
+	Header string
+	Prefix string
+	// Encoding is HMACEncodingHex or HMACEncodingBase64.
+	Encoding string
+	// TimestampFormat is one of the HMACTimestamp formats.
+	TimestampFormat string
+	// TimestampHeader and NonceHeader, when set, send the timestamp and the nonce the signature
+	// covers, so the receiver can rebuild the canonical string.
+	TimestampHeader string
+	NonceHeader     string
+}
+
+// Sign writes the signature of a complete request made at the given time with the given nonce into
+// the configured header. It MUST be called once the request is complete, as the signature covers
+// its method, URL and body.
+func (it *HMACSignatureConfig) Sign(request *http.Request, now time.Time, nonce string) error {
+	newHash, err := it.hashFunction()
+	if err != nil {
+		return err
+	}
+	bodyHash, err := requestPayloadHash(request, newHash)
+	if err != nil {
+		return fmt.Errorf("reading the body to sign: %w", err)
+	}
+
+	timestamp := it.formatTimestamp(now)
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+
+	canonical := it.Template
+	if canonical == "" {
+		canonical = DefaultHMACTemplate
+	}
+	canonical = strings.NewReplacer(
+		"${method}", request.Method,
+		"${host}", host,
+		"${path}", request.URL.EscapedPath(),
+		"${query}", request.URL.RawQuery,
+		"${timestamp}", timestamp,
+		"${nonce}", nonce,
+		"${body_hash}", hex.EncodeToString(bodyHash),
+	).Replace(canonical)
+
+	mac := hmac.New(newHash, []byte(it.Secret))
+	mac.Write([]byte(canonical))
+	signature := hex.EncodeToString(mac.Sum(nil))
+	if it.Encoding == HMACEncodingBase64 {
+		signature = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	request.Header.Set(it.Header, it.Prefix+signature)
+	if it.TimestampHeader != "" {
+		request.Header.Set(it.TimestampHeader, timestamp)
+	}
+	if it.NonceHeader != "" {
+		request.Header.Set(it.NonceHeader, nonce)
+	}
+
+	return nil
+}
+
+func (it *HMACSignatureConfig) hashFunction() (func() hash.Hash, error) {
+	switch it.Algorithm {
+	case HMACAlgorithmSHA256, "":
+		return sha256.New, nil
+	case HMACAlgorithmSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported HMAC algorithm %q", it.Algorithm)
+	}
+}
+
+func (it *HMACSignatureConfig) formatTimestamp(now time.Time) string {
+	switch it.TimestampFormat {
+	case HMACTimestampUnixMilli:
+		return strconv.FormatInt(now.UnixMilli(), 10)
+	case HMACTimestampRFC3339:
+		return now.UTC().Format(time.RFC3339)
+	default:
+		return strconv.FormatInt(now.Unix(), 10)
+	}
+}
@@ -1,30 +1,45 @@
 package entities
 
 import (
-	"crypto/tls"
+	"container/list"
 	"net/http"
+	"sync"
 )
 
 type InternalContext struct {
 	Client *http.Client
 	Config *Configuration
+	// Throttle enforces Config.Throttle on every client of this provider instance. A nil value
+	// enforces nothing.
+	Throttle *Throttle
+	// Digest keeps the Digest challenges answered by every client of this provider instance, so
+	// their nonces are reused across requests.
+	Digest *DigestChallenges
+	// Session logs in with Config.Session and keeps the cookie jar every client of this provider
+	// instance shares. A nil value means no session is configured.
+	Session *Session
+
+	// oauth2Token caches the token of the client credentials grant across every request of this
+	// provider instance; see OAuth2Token.
+	oauth2Mutex sync.Mutex
+	oauth2Token *oauth2Token
+
+	// clients caches the HTTP clients of the requests, keyed by their settings, and clientsByUse
+	// orders them for eviction; see CachedClient.
+	clientsMutex sync.Mutex
+	clients      map[string]*list.Element
+	clientsByUse *list.List
 }
 
 func NewInternalContext(ignoreTLS bool, config *Configuration) *InternalContext {
 	client := &http.Client{}
 	if ignoreTLS {
-		transport := &http.Transport{
-			TLSClientConfig: &tls.Config{
-				MinVersion: tls.VersionTLS13,
-				//nolint:gosec // purposefully ignore TLS verification according the flag
-				InsecureSkipVerify: true,
-			},
-		}
-		client.Transport = transport
+		client.Transport = &http.Transport{TLSClientConfig: InsecureTLSConfig()}
 	}
 
 	return &InternalContext{
 		Client: client,
 		Config: config,
+		Digest: NewDigestChallenges(),
 	}
 }
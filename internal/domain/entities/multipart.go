@@ -0,0 +1,223 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// MultipartPart is one part of a `multipart/form-data` body: a form field holding Value, or a file
+// whose content is read from File when the body is sent.
+type MultipartPart struct {
+	Name  string
+	Value string
+	File  string
+	// Filename is the file name the part announces. It defaults to the base name of File, and a
+	// part with neither is a plain form field.
+	Filename string
+	// ContentType defaults to application/octet-stream for a part with a file name.
+	ContentType string
+	Headers     map[string]string
+}
+
+// MultipartBody is a `multipart/form-data` request body that streams its file parts from disk as it
+// is read instead of holding them in memory. Seeking back to the start rewrites it from the first
+// part, which is how a retried request sends it again, and its length is known upfront so the
+// request is not sent chunked.
+type MultipartBody struct {
+	ctx      context.Context
+	parts    []MultipartPart
+	boundary string
+	length   int64
+
+	mutex  sync.Mutex
+	reader *io.PipeReader
+}
+
+// NewMultipartBody returns the body of the parts given, with a random boundary. The files are only
+// opened when the body is read, but must already exist, as their sizes make up its length. Reading
+// stops when ctx is done.
+func NewMultipartBody(ctx context.Context, parts []MultipartPart) (*MultipartBody, error) {
+	body := &MultipartBody{ctx: ctx, parts: parts, boundary: multipart.NewWriter(io.Discard).Boundary()}
+
+	counter := &countingWriter{}
+	err := body.write(counter, func(_ io.Writer, file string) error {
+		info, statErr := os.Stat(file)
+		if statErr != nil {
+			return statErr
+		}
+		counter.count += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sizing the multipart body: %w", err)
+	}
+	body.length = counter.count
+
+	return body, nil
+}
+
+// ContentType returns the `Content-Type` of the body, which carries its boundary.
+func (it *MultipartBody) ContentType() string {
+	return "multipart/form-data; boundary=" + it.boundary
+}
+
+// Len returns the length of the body in bytes.
+func (it *MultipartBody) Len() int64 {
+	return it.length
+}
+
+// Attach makes the body the one of the request, so the request can also be redirected and signed
+// with a copy of it.
+func (it *MultipartBody) Attach(request *http.Request) {
+	request.Body = it
+	request.ContentLength = it.length
+	request.GetBody = func() (io.ReadCloser, error) {
+		return &MultipartBody{ctx: it.ctx, parts: it.parts, boundary: it.boundary, length: it.length}, nil
+	}
+	request.Header.Set("Content-Type", it.ContentType())
+}
+
+func (it *MultipartBody) Read(p []byte) (int, error) {
+	it.mutex.Lock()
+	if it.reader == nil {
+		it.reader = it.start()
+	}
+	reader := it.reader
+	it.mutex.Unlock()
+
+	return reader.Read(p)
+}
+
+// Seek rewinds the body to its start, the only position it can seek to.
+func (it *MultipartBody) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, errors.New("a multipart body can only be rewound to its start")
+	}
+
+	return 0, it.Close()
+}
+
+// Close stops the part being written, closing its file.
+func (it *MultipartBody) Close() error {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if it.reader != nil {
+		_ = it.reader.Close()
+		it.reader = nil
+	}
+
+	return nil
+}
+
+// start writes the body into a pipe from a goroutine, which ends with the body or as soon as the
+// reader is closed or the context is done.
+func (it *MultipartBody) start() *io.PipeReader {
+	reader, writer := io.Pipe()
+	stop := context.AfterFunc(it.ctx, func() { _ = reader.CloseWithError(it.ctx.Err()) })
+	go func() {
+		defer stop()
+		_ = writer.CloseWithError(it.write(writer, copyFile))
+	}()
+
+	return reader
+}
+
+// write writes the parts to w, handing the content of each file part to writeFile.
+func (it *MultipartBody) write(w io.Writer, writeFile func(io.Writer, string) error) error {
+	form := multipart.NewWriter(w)
+	if err := form.SetBoundary(it.boundary); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	for _, part := range it.parts {
+		content, err := form.CreatePart(part.header())
+		if err != nil {
+			return fmt.Errorf("writing the %q part: %w", part.Name, err)
+		}
+		if part.File == "" {
+			_, err = io.WriteString(content, part.Value)
+		} else {
+			err = writeFile(content, part.File)
+		}
+		if err != nil {
+			return fmt.Errorf("writing the %q part: %w", part.Name, err)
+		}
+	}
+
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// header returns the MIME header of the part. Its own headers go on first, so the
+// `Content-Disposition` that names the part cannot be overridden by them.
+func (it MultipartPart) header() textproto.MIMEHeader {
+	header := make(textproto.MIMEHeader, len(it.Headers)+2)
+	for name, value := range it.Headers {
+		header.Set(name, value)
+	}
+
+	filename := it.Filename
+	if filename == "" && it.File != "" {
+		filename = filepath.Base(it.File)
+	}
+
+	disposition := fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(it.Name))
+	if filename != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, quoteEscaper.Replace(filename))
+	}
+	header.Set("Content-Disposition", disposition)
+
+	switch {
+	case it.ContentType != "":
+		header.Set("Content-Type", it.ContentType)
+	case filename != "" && header.Get("Content-Type") == "":
+		header.Set("Content-Type", "application/octet-stream")
+	}
+
+	return header
+}
+
+// quoteEscaper escapes a quoted parameter of `Content-Disposition` as mime/multipart does.
+//
+//nolint:gochecknoglobals // a stateless replacer, as in the standard library
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// copyFile streams a file into w.
+func copyFile(w io.Writer, file string) error {
+	content, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	defer func() { _ = content.Close() }()
+
+	if _, err = io.Copy(w, content); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	count int64
+}
+
+func (it *countingWriter) Write(p []byte) (int, error) {
+	it.count += int64(len(p))
+
+	return len(p), nil
+}
@@ -0,0 +1,173 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuth2Config configures the OAuth 2.0 client credentials grant (RFC 6749, section 4.4) the
+// provider uses to obtain the bearer token it sends on every request.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Audience is sent as the `audience` parameter some authorization servers require. Empty
+	// sends nothing.
+	Audience string
+	// ExtraParams are added to the token request form as they are.
+	ExtraParams map[string]string
+}
+
+// oauth2ExpiryDelta is how long before its reported expiry a cached token is treated as expired,
+// so a request never leaves with a token that lapses while it is in flight.
+const oauth2ExpiryDelta = 30 * time.Second
+
+// oauth2Token is a cached access token. A zero expiry means the server did not say, and the token
+// is kept until a request is rejected with it.
+type oauth2Token struct {
+	accessToken string
+	expiry      time.Time
+}
+
+func (it *oauth2Token) valid(now time.Time) bool {
+	return it != nil && (it.expiry.IsZero() || now.Add(oauth2ExpiryDelta).Before(it.expiry))
+}
+
+// oauth2TokenResponse is the successful token response of RFC 6749, section 5.1. `expires_in` is
+// decoded loosely because some servers send it as a string.
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   any    `json:"expires_in"`
+}
+
+// HasOAuth2 reports whether the provider obtains its bearer token through the client credentials
+// grant.
+func (it *Configuration) HasOAuth2() bool {
+	return it != nil && it.OAuth2 != nil && it.OAuth2.TokenURL != ""
+}
+
+// OAuth2Token returns the cached access token, fetching a new one when there is none yet or the
+// cached one is about to expire. Concurrent callers wait for a single fetch.
+func (it *InternalContext) OAuth2Token(ctx context.Context) (string, error) {
+	it.oauth2Mutex.Lock()
+	defer it.oauth2Mutex.Unlock()
+
+	if it.oauth2Token.valid(time.Now()) {
+		return it.oauth2Token.accessToken, nil
+	}
+
+	token, err := it.fetchOAuth2Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	it.oauth2Token = token
+
+	return token.accessToken, nil
+}
+
+// InvalidateOAuth2Token drops the cached token when it is still the given one, so the next call to
+// OAuth2Token fetches a fresh one. Comparing first keeps requests that were rejected with the same
+// stale token from discarding the token another one already refreshed.
+func (it *InternalContext) InvalidateOAuth2Token(stale string) {
+	it.oauth2Mutex.Lock()
+	defer it.oauth2Mutex.Unlock()
+
+	if it.oauth2Token != nil && it.oauth2Token.accessToken == stale {
+		it.oauth2Token = nil
+	}
+}
+
+// fetchOAuth2Token runs the client credentials grant. The client authenticates with HTTP basic
+// authentication, as RFC 6749, section 2.3.1 requires servers to support.
+func (it *InternalContext) fetchOAuth2Token(ctx context.Context) (*oauth2Token, error) {
+	if !it.Config.HasOAuth2() {
+		return nil, errors.New("oauth2 is not configured")
+	}
+	cfg := it.Config.OAuth2
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	if cfg.Audience != "" {
+		form.Set("audience", cfg.Audience)
+	}
+	for name, value := range cfg.ExtraParams {
+		form.Set(name, value)
+	}
+
+	if it.Config.RequestTimeoutMs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(it.Config.RequestTimeoutMs)*time.Millisecond)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("building the oauth2 token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+
+	client := it.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting an oauth2 token: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading the oauth2 token response: %w", err)
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("oauth2 token request failed with %s: %s", response.Status, string(body))
+	}
+
+	var decoded oauth2TokenResponse
+	if err = json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("decoding the oauth2 token response: %w", err)
+	}
+	if decoded.AccessToken == "" {
+		return nil, errors.New("the oauth2 token response carries no access_token")
+	}
+
+	token := &oauth2Token{accessToken: decoded.AccessToken}
+	if seconds := expiresInSeconds(decoded.ExpiresIn); seconds > 0 {
+		token.expiry = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+
+	return token, nil
+}
+
+// expiresInSeconds reads `expires_in` whether it was sent as a number or as a string, returning 0
+// when it is absent or unreadable.
+func expiresInSeconds(value any) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(v)
+	case string:
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0
+		}
+
+		return seconds
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig routes requests through a proxy instead of the one the environment names. An empty
+// URL keeps the environment proxy and only applies NoProxy to it.
+type ProxyConfig struct {
+	URL string
+	// Username and Password authenticate against the proxy. They take precedence over any
+	// credentials embedded in URL.
+	Username string
+	Password string
+	// NoProxy lists the hosts reached directly, in the format of the NO_PROXY environment
+	// variable: host names, domain suffixes, IP addresses, CIDR ranges or "*" for every host.
+	NoProxy []string
+}
+
+// IsEmpty reports whether the configuration changes nothing, including on a nil receiver.
+func (it *ProxyConfig) IsEmpty() bool {
+	return it == nil || (it.URL == "" && it.Username == "" && it.Password == "" && len(it.NoProxy) == 0)
+}
+
+// ParseProxyURL parses a proxy URL, rejecting a scheme the transport cannot dial.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the proxy URL: %w", err)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("the proxy URL %q has no host", raw)
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+		return parsed, nil
+	default:
+		return nil, fmt.Errorf("the proxy URL scheme %q is not one of http, https, socks5 or socks5h", parsed.Scheme)
+	}
+}
+
+// ProxyFunc returns the function an http.Transport calls to pick the proxy of a request. Requests
+// to localhost and loopback addresses are never proxied, as with the environment variables.
+func (it *ProxyConfig) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	var config httpproxy.Config
+	if it.URL == "" {
+		if it.Username != "" || it.Password != "" {
+			return nil, errors.New("proxy credentials need a proxy URL")
+		}
+		config = *httpproxy.FromEnvironment()
+	} else {
+		proxyURL, err := ParseProxyURL(it.URL)
+		if err != nil {
+			return nil, err
+		}
+		if it.Username != "" || it.Password != "" {
+			proxyURL.User = url.UserPassword(it.Username, it.Password)
+		}
+		config.HTTPProxy = proxyURL.String()
+		config.HTTPSProxy = proxyURL.String()
+	}
+	if len(it.NoProxy) > 0 {
+		config.NoProxy = strings.Join(it.NoProxy, ",")
+	}
+
+	proxyForURL := config.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyForURL(req.URL)
+	}, nil
+}
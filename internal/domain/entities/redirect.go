@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultMaxRedirects is how many redirects a request follows when nothing says otherwise, the
+// limit of the Go HTTP client.
+const DefaultMaxRedirects = 10
+
+// RedirectPolicy decides which redirects a request follows and how. Start from
+// DefaultRedirectPolicy: the zero value follows none.
+type RedirectPolicy struct {
+	// Follow is false to return the redirect response itself instead of following it.
+	Follow bool
+	// MaxRedirects is how many redirects are followed before the request fails.
+	MaxRedirects int64
+	// PreserveMethod re-sends the original method and body after a 301, 302 or 303, which the Go
+	// client otherwise turns into a GET without a body.
+	PreserveMethod bool
+	// ForwardAuth keeps the Authorization header when a redirect leaves the original host and its
+	// subdomains, where the Go client otherwise drops it.
+	ForwardAuth bool
+}
+
+// DefaultRedirectPolicy returns the behavior of the Go HTTP client.
+func DefaultRedirectPolicy() RedirectPolicy {
+	return RedirectPolicy{Follow: true, MaxRedirects: DefaultMaxRedirects}
+}
+
+// CheckRedirect is the http.Client hook that applies the policy to the upcoming request, via
+// holding the requests already sent, oldest first.
+func (it RedirectPolicy) CheckRedirect(req *http.Request, via []*http.Request) error {
+	if !it.Follow {
+		return http.ErrUseLastResponse
+	}
+	// the wording matches the Go client, which retry policies recognize as not worth retrying
+	if int64(len(via)) > it.MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", it.MaxRedirects)
+	}
+
+	original := via[0]
+	if it.PreserveMethod {
+		req.Method = original.Method
+		// once a hop dropped the body, the Go client leaves it out of every later hop as well
+		if req.Body == nil && original.GetBody != nil {
+			body, err := original.GetBody()
+			if err != nil {
+				return fmt.Errorf("replaying the request body on redirect: %w", err)
+			}
+			req.Body = body
+			req.GetBody = original.GetBody
+			req.ContentLength = original.ContentLength
+		}
+		for _, name := range []string{"Content-Type", "Content-Encoding", "Content-Language"} {
+			if value := original.Header.Get(name); value != "" && req.Header.Get(name) == "" {
+				req.Header.Set(name, value)
+			}
+		}
+	}
+
+	if it.ForwardAuth && req.Header.Get("Authorization") == "" {
+		if value := original.Header.Get("Authorization"); value != "" {
+			req.Header.Set("Authorization", value)
+		}
+	}
+
+	return nil
+}
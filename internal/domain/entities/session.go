@@ -0,0 +1,184 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SessionConfig configures the login whose session cookies authenticate every request of the
+// provider, as legacy admin consoles expect from a browser.
+type SessionConfig struct {
+	LoginURL    string
+	LoginMethod string
+	Headers     map[string]string
+	Body        string
+	// SuccessStatusCodes are the statuses of a successful login, once redirects are followed. Empty
+	// accepts any 2xx.
+	SuccessStatusCodes []int
+	// SuccessCookie names a cookie the login must set. Empty accepts a login that sets none.
+	SuccessCookie string
+	// LogoutURL is requested with LogoutMethod when the provider shuts down. Empty skips the logout.
+	LogoutURL    string
+	LogoutMethod string
+}
+
+// Session logs in once and keeps the cookies of the session in a jar every client of the provider
+// shares, logging in again when a request is rejected. It is safe for the concurrent CRUD calls
+// Terraform makes against one provider.
+type Session struct {
+	config SessionConfig
+	client *http.Client
+	// Jar holds the cookies of the session; every request of the provider sends and updates them.
+	Jar http.CookieJar
+
+	mutex sync.Mutex
+	// generation counts the logins, so a rejected request only renews the session it was sent with.
+	generation uint64
+	loggedIn   bool
+}
+
+// NewSession returns a session that logs in with a copy of the client given, which brings the
+// transport, timeout and redirect policy of the provider.
+func NewSession(config SessionConfig, client *http.Client) *Session {
+	jar, _ := cookiejar.New(nil) // it only fails on options, which are not given
+	sessionClient := *client
+	sessionClient.Jar = jar
+
+	return &Session{config: config, client: &sessionClient, Jar: jar}
+}
+
+// Login logs in unless the session already is, and returns the generation of the session the
+// caller's requests are sent with. Concurrent callers wait for a single login.
+func (it *Session) Login(ctx context.Context) (uint64, error) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if it.loggedIn {
+		return it.generation, nil
+	}
+	if err := it.login(ctx); err != nil {
+		return 0, err
+	}
+	it.generation++
+	it.loggedIn = true
+
+	return it.generation, nil
+}
+
+// Invalidate marks the session as expired when it is still the given generation, so the next call
+// to Login logs in again. Comparing first keeps the requests rejected within one stale session
+// from discarding the session another one already renewed.
+func (it *Session) Invalidate(stale uint64) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if it.generation == stale {
+		it.loggedIn = false
+	}
+}
+
+// Logout ends the session with the logout request, when one is configured and a login happened.
+func (it *Session) Logout(ctx context.Context) error {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if !it.loggedIn || it.config.LogoutURL == "" {
+		return nil
+	}
+	it.loggedIn = false
+
+	response, err := it.send(ctx, it.config.LogoutMethod, it.config.LogoutURL, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("logging out: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	return nil
+}
+
+// login sends the login request and checks its status and the cookie it must set.
+func (it *Session) login(ctx context.Context) error {
+	jar := &recordingJar{CookieJar: it.Jar}
+	response, err := it.send(ctx, it.config.LoginMethod, it.config.LoginURL, it.config.Body, it.config.Headers, jar)
+	if err != nil {
+		return fmt.Errorf("logging in: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("reading the login response: %w", err)
+	}
+	if !it.successful(response.StatusCode) {
+		return fmt.Errorf("login failed with %s: %s", response.Status, string(body))
+	}
+	if it.config.SuccessCookie != "" && !slices.Contains(jar.names, it.config.SuccessCookie) {
+		return fmt.Errorf("login answered %s without setting the %q cookie", response.Status, it.config.SuccessCookie)
+	}
+
+	return nil
+}
+
+func (it *Session) successful(statusCode int) bool {
+	if len(it.config.SuccessStatusCodes) == 0 {
+		return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+	}
+
+	return slices.Contains(it.config.SuccessStatusCodes, statusCode)
+}
+
+// send issues a login or logout request, POST when no method is given, storing the cookies it
+// receives through jar, or through the jar of the session when jar is nil.
+func (it *Session) send(
+	ctx context.Context, method, target, body string, headers map[string]string, jar http.CookieJar,
+) (*http.Response, error) {
+	if method == "" {
+		method = http.MethodPost
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building the request: %w", err)
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	client := it.client
+	if jar != nil {
+		withJar := *it.client
+		withJar.Jar = jar
+		client = &withJar
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return response, nil
+}
+
+// recordingJar records the names of the cookies stored through it, which includes those set by
+// the redirects a login follows.
+type recordingJar struct {
+	http.CookieJar
+
+	mutex sync.Mutex
+	names []string
+}
+
+func (it *recordingJar) SetCookies(target *url.URL, cookies []*http.Cookie) {
+	it.mutex.Lock()
+	for _, cookie := range cookies {
+		it.names = append(it.names, cookie.Name)
+	}
+	it.mutex.Unlock()
+	it.CookieJar.SetCookies(target, cookies)
+}
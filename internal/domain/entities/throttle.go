@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// ThrottleConfig caps the requests a provider sends. A zero RequestsPerSecond or
+// MaxConcurrentRequests leaves the corresponding limit off.
+type ThrottleConfig struct {
+	// RequestsPerSecond is the sustained rate requests are started at.
+	RequestsPerSecond float64
+	// Burst is how many requests may start at once before the rate applies.
+	Burst int
+	// MaxConcurrentRequests caps the requests in flight, a response counting until its body is
+	// closed.
+	MaxConcurrentRequests int
+	// PerHost applies each limit to every host separately instead of to all requests together.
+	PerHost bool
+}
+
+// IsEmpty reports whether the configuration sets no limit, in which case no throttle is needed.
+func (it ThrottleConfig) IsEmpty() bool {
+	return it.RequestsPerSecond <= 0 && it.MaxConcurrentRequests <= 0
+}
+
+// Throttle enforces a ThrottleConfig across every client of a provider, which Terraform calls
+// concurrently. A nil *Throttle enforces nothing.
+type Throttle struct {
+	config ThrottleConfig
+
+	mutex    sync.Mutex
+	limiters map[string]*rate.Limiter
+	slots    map[string]chan struct{}
+}
+
+// NewThrottle returns a throttle enforcing the configuration, or nil when it sets no limit.
+func NewThrottle(config ThrottleConfig) *Throttle {
+	if config.IsEmpty() {
+		return nil
+	}
+	if config.Burst < 1 {
+		config.Burst = 1
+	}
+
+	return &Throttle{
+		config:   config,
+		limiters: make(map[string]*rate.Limiter),
+		slots:    make(map[string]chan struct{}),
+	}
+}
+
+// Wrap returns a round tripper that passes every request through the throttle before next, a nil
+// next meaning http.DefaultTransport. Every attempt of a retried request is throttled on its own. A
+// nil throttle returns next unchanged.
+func (it *Throttle) Wrap(next http.RoundTripper) http.RoundTripper {
+	if it == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &throttledTransport{next: next, throttle: it}
+}
+
+// scope returns the limiter and the concurrency slots of a host, creating them on first use. Either
+// is nil when its limit is off.
+func (it *Throttle) scope(host string) (*rate.Limiter, chan struct{}) {
+	if !it.config.PerHost {
+		host = ""
+	}
+
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	limiter, ok := it.limiters[host]
+	if !ok && it.config.RequestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(it.config.RequestsPerSecond), it.config.Burst)
+		it.limiters[host] = limiter
+	}
+	slots, ok := it.slots[host]
+	if !ok && it.config.MaxConcurrentRequests > 0 {
+		slots = make(chan struct{}, it.config.MaxConcurrentRequests)
+		it.slots[host] = slots
+	}
+
+	return limiter, slots
+}
+
+// throttledTransport is the round tripper Throttle.Wrap returns.
+type throttledTransport struct {
+	next     http.RoundTripper
+	throttle *Throttle
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport.
+func (it *throttledTransport) CloseIdleConnections() {
+	CloseIdleConnections(it.next)
+}
+
+// RoundTrip waits for a concurrency slot and then for the rate limiter, giving up when the request
+// context ends first, and holds the slot until the response body is closed.
+func (it *throttledTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	limiter, slots := it.throttle.scope(request.URL.Host)
+
+	release := func() {}
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-slots }) }
+	}
+
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			release()
+
+			return nil, err
+		}
+	}
+
+	response, err := it.next.RoundTrip(request)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+	response.Body = &releasingBody{ReadCloser: response.Body, release: release}
+
+	return response, nil
+}
+
+// releasingBody frees the concurrency slot of a response once its body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (it *releasingBody) Close() error {
+	defer it.release()
+
+	return it.ReadCloser.Close()
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// TLSConfig customizes how the server certificate is verified and presents a client certificate
+// for mutual TLS. Every field is optional; the PEM fields hold certificate contents, with any file
+// already read.
+type TLSConfig struct {
+	// CACertPEM holds the certificates trusted in addition to the system pool.
+	CACertPEM     string
+	ClientCertPEM string
+	ClientKeyPEM  string
+	// ServerName overrides the name the server certificate is verified against, which is also
+	// sent as SNI.
+	ServerName string
+	// MinVersion is the lowest TLS version accepted, as understood by TLSVersion.
+	MinVersion string
+}
+
+// TLSVersion returns the protocol version of a `min_tls_version` value: "1.0", "1.1", "1.2" or
+// "1.3".
+func TLSVersion(name string) (uint16, bool) {
+	switch name {
+	case "1.0":
+		return tls.VersionTLS10, true
+	case "1.1":
+		return tls.VersionTLS11, true
+	case "1.2":
+		return tls.VersionTLS12, true
+	case "1.3":
+		return tls.VersionTLS13, true
+	default:
+		return 0, false
+	}
+}
+
+// IsEmpty reports whether the configuration changes nothing, including on a nil receiver.
+func (it *TLSConfig) IsEmpty() bool {
+	return it == nil || *it == TLSConfig{}
+}
+
+// ClientConfig builds the crypto/tls configuration, including on a nil receiver. insecure keeps
+// the `ignore_tls` behavior of skipping verification while the client certificate is still
+// presented.
+func (it *TLSConfig) ClientConfig(insecure bool) (*tls.Config, error) {
+	if it == nil {
+		it = &TLSConfig{}
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: it.ServerName,
+		//nolint:gosec // purposefully ignore TLS verification according to user configuration
+		InsecureSkipVerify: insecure,
+	}
+
+	if it.MinVersion != "" {
+		version, ok := TLSVersion(it.MinVersion)
+		if !ok {
+			return nil, fmt.Errorf("unsupported minimum TLS version %q", it.MinVersion)
+		}
+		config.MinVersion = version
+	}
+
+	if it.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(it.CACertPEM)) {
+			return nil, errors.New("the CA bundle holds no PEM-encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	if it.ClientCertPEM != "" || it.ClientKeyPEM != "" {
+		certificate, err := tls.X509KeyPair([]byte(it.ClientCertPEM), []byte(it.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("loading the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// ConnectionPool tunes how connections are kept for reuse. A zero field keeps the value of
+// http.DefaultTransport.
+type ConnectionPool struct {
+	// MaxIdleConns caps the idle connections kept in total and per host: a configuration usually
+	// talks to a single API, so the whole pool may serve one host.
+	MaxIdleConns int
+	// IdleConnTimeout is how long an idle connection is kept before it is closed.
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives closes every connection after its request instead of reusing it.
+	DisableKeepAlives bool
+}
+
+// InsecureTLSConfig is the TLS configuration of `ignore_tls` when no other TLS setting is made.
+func InsecureTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		//nolint:gosec // purposefully ignore TLS verification according to user configuration
+		InsecureSkipVerify: true,
+	}
+}
+
+// CloseIdleConnections closes the idle connections of a round tripper that keeps any, as
+// http.Client.CloseIdleConnections does, so the wrappers of a transport can pass the call on.
+func CloseIdleConnections(roundTripper http.RoundTripper) {
+	if closer, ok := roundTripper.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// NewTransport returns a transport with the defaults of http.DefaultTransport -- proxy from the
+// environment, connection pooling, HTTP/2 -- that uses the given TLS configuration, proxy and
+// connection pool. A nil argument keeps the corresponding default.
+func NewTransport(tlsConfig *tls.Config, proxy *ProxyConfig, pool *ConnectionPool) (*http.Transport, error) {
+	//nolint:errcheck,forcetypeassert // http.DefaultTransport is always an *http.Transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+
+	if !proxy.IsEmpty() {
+		proxyFunc, err := proxy.ProxyFunc()
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxyFunc
+	}
+
+	if pool != nil {
+		if pool.MaxIdleConns > 0 {
+			transport.MaxIdleConns = pool.MaxIdleConns
+			transport.MaxIdleConnsPerHost = pool.MaxIdleConns
+		}
+		if pool.IdleConnTimeout > 0 {
+			transport.IdleConnTimeout = pool.IdleConnTimeout
+		}
+		transport.DisableKeepAlives = pool.DisableKeepAlives
+	}
+
+	return transport, nil
+}
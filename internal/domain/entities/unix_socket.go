@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// UnixSocketScheme is the scheme of a base URL naming the Unix domain socket an HTTP server listens
+// on, such as unix:///var/run/docker.sock.
+const UnixSocketScheme = "unix"
+
+// DefaultUnixSocketHost is the HTTP host of the requests sent over a socket whose URL names none.
+const DefaultUnixSocketHost = "localhost"
+
+// UnixSocket is a Unix domain socket an HTTP server listens on.
+type UnixSocket struct {
+	// Path is the socket file.
+	Path string
+	// Host is the HTTP host the requests are addressed to, sent in the Host header.
+	Host string
+}
+
+// UnixSocketOf returns the socket a `unix://[host]/path/to/socket` base URL names, or nil for a URL
+// of any other scheme. The whole path of the URL is the socket file; the optional host is the HTTP
+// host of the requests.
+func UnixSocketOf(baseURL *url.URL) (*UnixSocket, error) {
+	if !strings.EqualFold(baseURL.Scheme, UnixSocketScheme) {
+		return nil, nil //nolint:nilnil // nil means the URL is reached over TCP
+	}
+	if baseURL.Path == "" {
+		return nil, errors.New("a unix base URL must name the socket file, as in unix:///var/run/docker.sock")
+	}
+
+	host := baseURL.Host
+	if host == "" {
+		host = DefaultUnixSocketHost
+	}
+
+	return &UnixSocket{Path: baseURL.Path, Host: host}, nil
+}
+
+// URL returns the base URL of the requests sent over the socket: plain HTTP to its host, with no
+// path of its own.
+func (it *UnixSocket) URL() *url.URL {
+	return &url.URL{Scheme: "http", Host: it.Host}
+}
+
+// NewUnixSocketTransport returns a transport that dials the socket for every request, whatever
+// host it is addressed to, with the given connection pool. Proxies never apply to a socket.
+func NewUnixSocketTransport(socketPath string, pool *ConnectionPool) (*http.Transport, error) {
+	transport, err := NewTransport(nil, nil, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", socketPath)
+	}
+
+	return transport, nil
+}
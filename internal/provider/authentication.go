@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// Environment variables the provider-level `bearer_auth` and `api_key` fall back to when an
+// argument is not configured.
+const (
+	envBearerToken = "PROVIDER_HTTP_BEARER_TOKEN"
+	envAPIKeyName  = "PROVIDER_HTTP_API_KEY_NAME"
+	envAPIKeyValue = "PROVIDER_HTTP_API_KEY"
+	envAPIKeyIn    = "PROVIDER_HTTP_API_KEY_IN"
+)
+
+const descAPIKeyIn = "Where the key is sent: `header` (the default) or `query`."
+
+// bearerAuthObjectAttrTypes returns the attribute types of the `bearer_auth` nested object. Like
+// waitForObjectAttrTypes, it MUST be used wherever a typed null `bearer_auth` value is produced.
+func bearerAuthObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrToken: types.StringType,
+	}
+}
+
+// apiKeyObjectAttrTypes returns the attribute types of the `api_key` nested object.
+func apiKeyObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrName:  types.StringType,
+		attrValue: types.StringType,
+		attrIn:    types.StringType,
+	}
+}
+
+// addAuthenticationAttributes adds the token-based alternatives to `basic_auth`. Like `basic_auth`
+// they never force replacement: a changed credential re-sends the request in place.
+func addAuthenticationAttributes(attrs map[string]schema.Attribute) {
+	attrs[attrBearerAuth] = schema.SingleNestedAttribute{
+		Description: "Bearer token authentication for this specific request, sent as " +
+			"\"Authorization: Bearer <token>\". When specified, this overrides the provider-level " +
+			"basic_auth and bearer_auth configuration. Conflicts with basic_auth.",
+		MarkdownDescription: "Bearer token authentication for this specific request, sent as " +
+			"`Authorization: Bearer <token>`. When specified, this overrides the provider-level " +
+			"`basic_auth` and `bearer_auth` configuration. Conflicts with `basic_auth`.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			attrToken: schema.StringAttribute{
+				Description:         "The bearer token.",
+				MarkdownDescription: "The bearer token.",
+				Required:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+	attrs[attrAPIKey] = schema.SingleNestedAttribute{
+		Description: "API key authentication for this specific request. When specified, this overrides " +
+			"the provider-level api_key configuration.",
+		MarkdownDescription: "API key authentication for this specific request. When specified, this overrides " +
+			"the provider-level `api_key` configuration.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			attrName: schema.StringAttribute{
+				Description:         "The name of the header or query parameter carrying the key (e.g. X-API-Key).",
+				MarkdownDescription: "The name of the header or query parameter carrying the key (e.g. `X-API-Key`).",
+				Required:            true,
+			},
+			attrValue: schema.StringAttribute{
+				Description:         "The API key.",
+				MarkdownDescription: "The API key.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			attrIn: schema.StringAttribute{
+				Description:         "Where the key is sent: header (the default) or query.",
+				MarkdownDescription: descAPIKeyIn,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// validateAuthentication rejects a resource that configures two schemes writing the same
+// Authorization header, and an `api_key` location other than `header` or `query`.
+func validateAuthentication(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var basicAuth, bearerAuth, digestAuth types.Object
+	var apiKeyIn types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrBasicAuth), &basicAuth)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrBearerAuth), &bearerAuth)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrDigestAuth), &digestAuth)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrAPIKey).AtName(attrIn), &apiKeyIn)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !basicAuth.IsNull() && !bearerAuth.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrBearerAuth),
+			"Conflicting authentication",
+			"`basic_auth` and `bearer_auth` both set the Authorization header, so only one of them can be set.",
+		)
+	}
+	if !digestAuth.IsNull() && (!basicAuth.IsNull() || !bearerAuth.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrDigestAuth),
+			"Conflicting authentication",
+			"`digest_auth` sets the Authorization header like `basic_auth` and `bearer_auth`, so it cannot be "+
+				"combined with either of them.",
+		)
+	}
+
+	checkAPIKeyLocation(path.Root(attrAPIKey).AtName(attrIn), apiKeyIn, &resp.Diagnostics)
+}
+
+// checkAPIKeyLocation reports an `api_key` location other than `header` or `query`. A null or
+// unknown value passes, the former meaning `header`.
+func checkAPIKeyLocation(attributePath path.Path, in types.String, diagnostics *diag.Diagnostics) {
+	if in.IsNull() || in.IsUnknown() {
+		return
+	}
+
+	switch strings.ToLower(in.ValueString()) {
+	case entities.APIKeyInHeader, entities.APIKeyInQuery:
+	default:
+		diagnostics.AddAttributeError(
+			attributePath,
+			"Invalid api_key location",
+			fmt.Sprintf("%q is not a valid location; use %q or %q.",
+				in.ValueString(), entities.APIKeyInHeader, entities.APIKeyInQuery),
+		)
+	}
+}
+
+// objectStringOf returns the string attribute of a nested object, or "" when the object or the
+// attribute is null or unknown.
+func objectStringOf(obj types.Object, name string) string {
+	if obj.IsNull() || obj.IsUnknown() {
+		return ""
+	}
+
+	value, ok := obj.Attributes()[name].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+
+	return value.ValueString()
+}
+
+// providerBearerAuth resolves the provider-level bearer token, falling back to its environment
+// variable. It returns nil when neither supplies one.
+func providerBearerAuth(obj types.Object) *entities.BearerAuth {
+	token := objectStringOf(obj, attrToken)
+	if token == "" {
+		token = os.Getenv(envBearerToken)
+	}
+	if token == "" {
+		return nil
+	}
+
+	return &entities.BearerAuth{Token: token}
+}
+
+// providerAPIKey resolves the provider-level API key, each argument falling back to its
+// environment variable. It returns nil when neither supplies a name or a value, and reports a key
+// missing one of the two.
+func providerAPIKey(obj types.Object, diagnostics *diag.Diagnostics) *entities.APIKey {
+	valueOr := func(name, env string) string {
+		if value := objectStringOf(obj, name); value != "" {
+			return value
+		}
+
+		return os.Getenv(env)
+	}
+
+	apiKey := &entities.APIKey{
+		Name:  valueOr(attrName, envAPIKeyName),
+		Value: valueOr(attrValue, envAPIKeyValue),
+		In:    strings.ToLower(valueOr(attrIn, envAPIKeyIn)),
+	}
+	if apiKey.Name == "" && apiKey.Value == "" {
+		return nil
+	}
+
+	if apiKey.Name == "" || apiKey.Value == "" {
+		diagnostics.AddAttributeError(
+			path.Root(attrAPIKey),
+			"Incomplete API key for HTTP client",
+			fmt.Sprintf("An API key needs both a name and a value. Set `name` and `value` in the `api_key` "+
+				"block, or use the %s and %s environment variables.", envAPIKeyName, envAPIKeyValue),
+		)
+
+		return nil
+	}
+
+	if apiKey.In == "" {
+		apiKey.In = entities.APIKeyInHeader
+	}
+	checkAPIKeyLocation(path.Root(attrAPIKey).AtName(attrIn), types.StringValue(apiKey.In), diagnostics)
+
+	return apiKey
+}
+
+// apiKeyOf converts a resource-level `api_key` object, returning nil when it is not set.
+func apiKeyOf(obj types.Object) *entities.APIKey {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	apiKey := &entities.APIKey{
+		Name:  objectStringOf(obj, attrName),
+		Value: objectStringOf(obj, attrValue),
+		In:    strings.ToLower(objectStringOf(obj, attrIn)),
+	}
+	if apiKey.In == "" {
+		apiKey.In = entities.APIKeyInHeader
+	}
+
+	return apiKey
+}
+
+// resolveAPIKey returns the API key a request sends, the resource-level one winning over the
+// provider-level one, or nil when neither is set.
+func (it *HTTPRequestResource) resolveAPIKey(model HTTPRequestResourceModel) *entities.APIKey {
+	if apiKey := apiKeyOf(model.APIKey); apiKey != nil {
+		return apiKey
+	}
+	if config := it.providerConfig(); config.HasAPIKey() {
+		return config.APIKey
+	}
+
+	return nil
+}
+
+// providerOAuth2 converts the provider-level `oauth2` object, returning nil when it is not set.
+func providerOAuth2(ctx context.Context, obj types.Object, diagnostics *diag.Diagnostics) *entities.OAuth2Config {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	cfg := &entities.OAuth2Config{
+		TokenURL:     objectStringOf(obj, attrTokenURL),
+		ClientID:     objectStringOf(obj, attrClientID),
+		ClientSecret: objectStringOf(obj, attrClientSecret),
+		Audience:     objectStringOf(obj, attrAudience),
+	}
+	if scopes, ok := obj.Attributes()[attrScopes].(types.List); ok && !scopes.IsNull() && !scopes.IsUnknown() {
+		diagnostics.Append(scopes.ElementsAs(ctx, &cfg.Scopes, false)...)
+	}
+	if params, ok := obj.Attributes()[attrExtraParams].(types.Map); ok {
+		cfg.ExtraParams = stringMapOf(ctx, params, diagnostics)
+	}
+
+	if parsed, err := url.Parse(cfg.TokenURL); err != nil || !parsed.IsAbs() {
+		diagnostics.AddAttributeError(
+			path.Root(attrOAuth2).AtName(attrTokenURL),
+			"Invalid oauth2 token URL",
+			fmt.Sprintf("%q is not an absolute URL.", cfg.TokenURL),
+		)
+	}
+
+	return cfg
+}
+
+// hasOwnAuthorization reports whether the resource, or the endpoint it selects, brings its own
+// Authorization scheme, which discards every provider-level one.
+func (it *HTTPRequestResource) hasOwnAuthorization(model HTTPRequestResourceModel) bool {
+	if !model.BasicAuth.IsNull() || !model.BearerAuth.IsNull() || !model.DigestAuth.IsNull() {
+		return true
+	}
+	endpoint := it.endpoint(model)
+
+	return endpoint != nil && endpoint.BasicAuth != nil
+}
+
+// usesOAuth2 reports whether the provider-level oauth2 token authenticates requests of this
+// model, which is the case unless the resource brings its own Authorization scheme or the provider
+// signs with `aws_sigv4`.
+func (it *HTTPRequestResource) usesOAuth2(model HTTPRequestResourceModel) bool {
+	config := it.providerConfig()
+
+	return !it.hasOwnAuthorization(model) && !config.HasAWSSigV4() && config.HasOAuth2()
+}
+
+// doAuthenticated sends the request within the provider `session`, logging in first when needed,
+// and sends it once more when it was rejected: with a new session after a `401 Unauthorized` or
+// `403 Forbidden`, as the session may have expired, or with a freshly fetched oauth2 token after a
+// 401, as the token may have been revoked or may have expired earlier than announced. A second
+// rejection is returned as it is.
+func (it *HTTPRequestResource) doAuthenticated(
+	ctx context.Context,
+	client *http.Client,
+	model HTTPRequestResourceModel,
+	endpoint string,
+	request *http.Request,
+) (*http.Response, error) {
+	session := it.session()
+	var generation uint64
+	if session != nil {
+		var err error
+		if generation, err = session.Login(ctx); err != nil {
+			return nil, fmt.Errorf("opening the session: %w", err)
+		}
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return response, err
+	}
+	switch {
+	case session != nil && isSessionRejection(response):
+		tflog.Debug(ctx, "Logging in again after "+response.Status+"...")
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+		session.Invalidate(generation)
+		if _, err = session.Login(ctx); err != nil {
+			return nil, fmt.Errorf("renewing the session: %w", err)
+		}
+	case response.StatusCode == http.StatusUnauthorized && it.usesOAuth2(model):
+		tflog.Debug(ctx, "Retrying with a fresh oauth2 token after 401 Unauthorized...")
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+		it.internal.InvalidateOAuth2Token(strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer "))
+	default:
+		return response, nil
+	}
+
+	retry, err := it.buildRequest(ctx, model, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Do(retry)
+}
+
+// applyAuthentication authenticates the request, each resource-level scheme taking precedence over
+// the provider-level one it competes with. `basic_auth` and `bearer_auth` both write the
+// Authorization header, as `digest_auth` does once challenged, so any of them on the resource
+// discards every provider-level one, as the `basic_auth` of the endpoint the resource selects
+// does, and at provider level `aws_sigv4` wins over `oauth2`, which wins over `bearer_auth`, which
+// wins over `digest_auth`, which wins over `basic_auth`. `api_key` only competes with itself.
+func (it *HTTPRequestResource) applyAuthentication(
+	ctx context.Context, req *http.Request, model HTTPRequestResourceModel,
+) error {
+	config := it.providerConfig()
+	endpoint := it.endpoint(model)
+
+	switch {
+	case !model.BasicAuth.IsNull():
+		authAttrs := model.BasicAuth.Attributes()
+		username, ok := authAttrs[attrUsername].(types.String)
+		if !ok {
+			return errors.New("failed to get username from basic_auth")
+		}
+		password, ok := authAttrs[attrPassword].(types.String)
+		if !ok {
+			return errors.New("failed to get password from basic_auth")
+		}
+		req.SetBasicAuth(username.ValueString(), password.ValueString())
+	case !model.BearerAuth.IsNull():
+		token := objectStringOf(model.BearerAuth, attrToken)
+		if token == "" {
+			return errors.New("failed to get token from bearer_auth")
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case !model.DigestAuth.IsNull():
+		// answered by the client once the server sends its challenge; see resolveDigestAuth
+	case endpoint != nil && endpoint.BasicAuth != nil:
+		req.SetBasicAuth(endpoint.BasicAuth.Username, endpoint.BasicAuth.Password)
+	case config.HasAWSSigV4():
+		// signed by buildRequest once the request is complete, the signature covering every header
+	case config.HasOAuth2():
+		token, err := it.internal.OAuth2Token(ctx)
+		if err != nil {
+			return fmt.Errorf("obtaining the oauth2 token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case config.HasBearerAuth():
+		req.Header.Set("Authorization", "Bearer "+config.BearerAuth.Token)
+	case config.HasDigestAuth():
+		// answered by the client like a resource-level digest_auth
+	case config.HasAuthentication():
+		req.SetBasicAuth(config.BasicAuth.Username, config.BasicAuth.Password)
+	}
+
+	apiKey := it.resolveAPIKey(model)
+	if apiKey == nil {
+		return nil
+	}
+
+	if apiKey.In == entities.APIKeyInQuery {
+		query := req.URL.Query()
+		query.Set(apiKey.Name, apiKey.Value)
+		req.URL.RawQuery = query.Encode()
+
+		return nil
+	}
+	req.Header.Set(apiKey.Name, apiKey.Value)
+
+	return nil
+}
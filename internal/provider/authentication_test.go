@@ -0,0 +1,343 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// resourceWithProviderAuth builds a resource whose provider configuration authenticates with the
+// given schemes, any of which may be nil.
+func resourceWithProviderAuth(
+	basicAuth *entities.BasicAuth, bearerAuth *entities.BearerAuth, apiKey *entities.APIKey,
+) *HTTPRequestResource {
+	config := entities.NewConfiguration("https://example.test")
+	config.BasicAuth = basicAuth
+	config.BearerAuth = bearerAuth
+	config.APIKey = apiKey
+
+	return &HTTPRequestResource{internal: entities.NewInternalContext(false, config)}
+}
+
+// bearerAuthObject builds a resource-level `bearer_auth` value.
+func bearerAuthObject(token string) types.Object {
+	return types.ObjectValueMust(bearerAuthObjectAttrTypes(), map[string]attr.Value{
+		attrToken: types.StringValue(token),
+	})
+}
+
+// apiKeyObject builds a resource-level `api_key` value; an empty location is left null.
+func apiKeyObject(name, value, in string) types.Object {
+	location := types.StringNull()
+	if in != "" {
+		location = types.StringValue(in)
+	}
+
+	return types.ObjectValueMust(apiKeyObjectAttrTypes(), map[string]attr.Value{
+		attrName:  types.StringValue(name),
+		attrValue: types.StringValue(value),
+		attrIn:    location,
+	})
+}
+
+func TestBuildRequestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send the provider bearer token in preference to its basic auth", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(
+			&entities.BasicAuth{Username: "user", Password: "pass"},
+			&entities.BearerAuth{Token: "provider-token"},
+			nil,
+		)
+		model := requestModel(types.MapNull(types.StringType))
+
+		// when
+		request := buildTestRequest(t, it, model)
+
+		// then
+		assert.Equal(t, "Bearer provider-token", request.Header.Get("Authorization"))
+	})
+
+	t.Run("should let a resource bearer token override the provider basic auth", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(&entities.BasicAuth{Username: "user", Password: "pass"}, nil, nil)
+		model := requestModel(types.MapNull(types.StringType))
+		model.BearerAuth = bearerAuthObject("resource-token")
+
+		// when
+		request := buildTestRequest(t, it, model)
+
+		// then
+		assert.Equal(t, "Bearer resource-token", request.Header.Get("Authorization"))
+	})
+
+	t.Run("should send the API key as a header by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := requestModel(types.MapNull(types.StringType))
+		model.APIKey = apiKeyObject("X-API-Key", "secret", "")
+
+		// when
+		request := buildTestRequest(t, it, model)
+
+		// then
+		assert.Equal(t, "secret", request.Header.Get("X-API-Key"))
+		assert.Empty(t, request.URL.RawQuery)
+	})
+
+	t.Run("should let a resource API key in the query override the provider one", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(nil, &entities.BearerAuth{Token: "provider-token"},
+			&entities.APIKey{Name: "X-API-Key", Value: "provider-key", In: entities.APIKeyInHeader})
+		model := requestModel(types.MapNull(types.StringType))
+		model.APIKey = apiKeyObject("api_key", "resource-key", "query")
+
+		// when
+		request := buildTestRequest(t, it, model)
+
+		// then
+		assert.Equal(t, "resource-key", request.URL.Query().Get("api_key"))
+		assert.Empty(t, request.Header.Get("X-API-Key"), "the provider key is replaced, not added to")
+		assert.Equal(t, "Bearer provider-token", request.Header.Get("Authorization"),
+			"an API key does not compete with the provider bearer token")
+	})
+}
+
+func TestValidateAuthentication(t *testing.T) {
+	t.Parallel()
+
+	basicAuthType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		attrUsername: tftypes.String,
+		attrPassword: tftypes.String,
+	}}
+	bearerAuthType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{attrToken: tftypes.String}}
+	apiKeyType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		attrName:  tftypes.String,
+		attrValue: tftypes.String,
+		attrIn:    tftypes.String,
+	}}
+
+	t.Run("should reject basic and bearer auth on the same resource", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		config := resourceConfigWith(t, map[string]tftypes.Value{
+			attrBasicAuth: tftypes.NewValue(basicAuthType, map[string]tftypes.Value{
+				attrUsername: tftypes.NewValue(tftypes.String, "user"),
+				attrPassword: tftypes.NewValue(tftypes.String, "pass"),
+			}),
+			attrBearerAuth: tftypes.NewValue(bearerAuthType, map[string]tftypes.Value{
+				attrToken: tftypes.NewValue(tftypes.String, "token"),
+			}),
+		})
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateAuthentication(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		// then
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Conflicting authentication", resp.Diagnostics[0].Summary())
+	})
+
+	t.Run("should reject an API key location other than header or query", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		config := resourceConfigWith(t, map[string]tftypes.Value{
+			attrAPIKey: tftypes.NewValue(apiKeyType, map[string]tftypes.Value{
+				attrName:  tftypes.NewValue(tftypes.String, "key"),
+				attrValue: tftypes.NewValue(tftypes.String, "secret"),
+				attrIn:    tftypes.NewValue(tftypes.String, "cookie"),
+			}),
+		})
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateAuthentication(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		// then
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Invalid api_key location", resp.Diagnostics[0].Summary())
+	})
+}
+
+//nolint:paralleltest // t.Setenv cannot be combined with t.Parallel
+func TestProviderAPIKey(t *testing.T) {
+	t.Run("should fall back to the environment for unset arguments", func(t *testing.T) {
+		// given
+		t.Setenv(envAPIKeyValue, "from-env")
+		t.Setenv(envAPIKeyIn, "QUERY")
+		block := types.ObjectValueMust(apiKeyObjectAttrTypes(), map[string]attr.Value{
+			attrName:  types.StringValue("api_key"),
+			attrValue: types.StringNull(),
+			attrIn:    types.StringNull(),
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		apiKey := providerAPIKey(block, &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), "%v", diagnostics)
+		assert.Equal(t, &entities.APIKey{Name: "api_key", Value: "from-env", In: entities.APIKeyInQuery}, apiKey)
+	})
+
+	t.Run("should report a key without a name", func(t *testing.T) {
+		// given
+		t.Setenv(envAPIKeyName, "")
+		t.Setenv(envAPIKeyValue, "from-env")
+		var diagnostics diag.Diagnostics
+
+		// when
+		apiKey := providerAPIKey(types.ObjectNull(apiKeyObjectAttrTypes()), &diagnostics)
+
+		// then
+		assert.Nil(t, apiKey)
+		require.True(t, diagnostics.HasError())
+		assert.Equal(t, "Incomplete API key for HTTP client", diagnostics[0].Summary())
+	})
+}
+
+// oauth2Server issues a new access token on every token request, counting them, and serves an API
+// that accepts only the latest token, answering `401 Unauthorized` to any other.
+func oauth2Server(t *testing.T, issued *atomic.Int32, form *http.Request) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if form != nil {
+			_ = r.ParseForm()
+			*form = *r.Clone(context.Background())
+		}
+		count := issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token-` + strconv.Itoa(int(count)) +
+			`","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-"+strconv.Itoa(int(issued.Load())) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// resourceWithOAuth2 builds a resource whose provider obtains its token from the given server.
+func resourceWithOAuth2(serverURL string) *HTTPRequestResource {
+	config := entities.NewConfiguration(serverURL)
+	config.OAuth2 = &entities.OAuth2Config{
+		TokenURL:     serverURL + "/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+		Audience:     "https://api.example.test",
+		ExtraParams:  map[string]string{"resource": "widgets"},
+	}
+
+	return &HTTPRequestResource{internal: entities.NewInternalContext(false, config)}
+}
+
+func TestOAuth2Authentication(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should fetch the token once and reuse it for later requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var issued atomic.Int32
+		var tokenRequest http.Request
+		server := oauth2Server(t, &issued, &tokenRequest)
+		it := resourceWithOAuth2(server.URL)
+		model := requestModel(types.MapNull(types.StringType))
+		var diagnostics diag.Diagnostics
+
+		// when
+		first, _ := it.performRequest(context.Background(), model, &diagnostics)
+		second, _ := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), "%v", diagnostics)
+		assert.Equal(t, http.StatusOK, first.statusCode)
+		assert.Equal(t, http.StatusOK, second.statusCode)
+		assert.Equal(t, int32(1), issued.Load(), "the cached token is reused")
+		clientID, clientSecret, _ := tokenRequest.BasicAuth()
+		assert.Equal(t, "client", clientID)
+		assert.Equal(t, "secret", clientSecret)
+		assert.Equal(t, "client_credentials", tokenRequest.PostForm.Get("grant_type"))
+		assert.Equal(t, "read write", tokenRequest.PostForm.Get("scope"))
+		assert.Equal(t, "https://api.example.test", tokenRequest.PostForm.Get("audience"))
+		assert.Equal(t, "widgets", tokenRequest.PostForm.Get("resource"))
+	})
+
+	t.Run("should retry once with a fresh token after a 401", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var issued atomic.Int32
+		server := oauth2Server(t, &issued, nil)
+		it := resourceWithOAuth2(server.URL)
+		model := requestModel(types.MapNull(types.StringType))
+		var diagnostics diag.Diagnostics
+		_, _ = it.performRequest(context.Background(), model, &diagnostics)
+		issued.Add(1) // the server rotates its key: the cached token-1 is now rejected
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "%v", diagnostics)
+		assert.Equal(t, http.StatusOK, exchange.statusCode, "the retry carries the freshly issued token")
+		assert.Equal(t, int32(3), issued.Load())
+	})
+
+	t.Run("should leave a resource bearer token alone", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var issued atomic.Int32
+		server := oauth2Server(t, &issued, nil)
+		it := resourceWithOAuth2(server.URL)
+		model := requestModel(types.MapNull(types.StringType))
+		model.BearerAuth = bearerAuthObject("resource-token")
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "%v", diagnostics)
+		assert.Equal(t, http.StatusUnauthorized, exchange.statusCode, "a rejected resource token is not retried")
+		assert.Equal(t, int32(0), issued.Load(), "no token is fetched")
+	})
+}
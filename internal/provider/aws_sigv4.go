@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+const (
+	attrAWSSigV4     = "aws_sigv4"
+	attrRegion       = "region"
+	attrService      = "service"
+	attrAccessKey    = "access_key"
+	attrSecretKey    = "secret_key"
+	attrSessionToken = "session_token"
+)
+
+// The standard AWS environment variables `aws_sigv4` falls back to, as the AWS CLI and SDKs do.
+const (
+	envAWSRegion          = "AWS_REGION"
+	envAWSDefaultRegion   = "AWS_DEFAULT_REGION"
+	envAWSAccessKeyID     = "AWS_ACCESS_KEY_ID"
+	envAWSSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+	envAWSSessionToken    = "AWS_SESSION_TOKEN"
+)
+
+const descAWSSigV4 = "AWS Signature Version 4 signing, for APIs behind IAM authentication such as API " +
+	"Gateway. Every request made by this provider, including destroy, refresh and the read an import " +
+	"issues, is signed once its headers, query and body are final, so the signature covers all of them. " +
+	"It takes precedence over the provider-level `oauth2`, `bearer_auth`, `digest_auth` and `basic_auth`, " +
+	"and a resource's own `basic_auth`, `bearer_auth` or `digest_auth` overrides it."
+
+// addProviderAWSSigV4Attribute adds the `aws_sigv4` attribute to the provider schema.
+func addProviderAWSSigV4Attribute(attrs map[string]schema.Attribute) {
+	attrs[attrAWSSigV4] = schema.SingleNestedAttribute{
+		Description:         descAWSSigV4,
+		MarkdownDescription: descAWSSigV4,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			attrRegion: providerOptionalString("The AWS region of the API, such as `eu-west-1`. Can also be "+
+				"set with the `AWS_REGION` or `AWS_DEFAULT_REGION` environment variable.", false),
+			attrService: schema.StringAttribute{
+				Description:         "The signing name of the AWS service, such as execute-api for API Gateway.",
+				MarkdownDescription: "The signing name of the AWS service, such as `execute-api` for API Gateway.",
+				Required:            true,
+			},
+			attrAccessKey: providerOptionalString("The access key ID. Can also be set with the "+
+				"`AWS_ACCESS_KEY_ID` environment variable.", false),
+			attrSecretKey: providerOptionalString("The secret access key. Can also be set with the "+
+				"`AWS_SECRET_ACCESS_KEY` environment variable.", true),
+			attrSessionToken: providerOptionalString("The session token of temporary credentials. Can also "+
+				"be set with the `AWS_SESSION_TOKEN` environment variable, which is only read when the access "+
+				"key comes from the environment too, so credentials from two sources are never mixed.", true),
+		},
+	}
+}
+
+// providerAWSSigV4 resolves the provider-level `aws_sigv4` object, each argument falling back to its
+// environment variable. It returns nil when the object is not set, and reports a configuration
+// missing the region or the credentials.
+func providerAWSSigV4(obj types.Object, diagnostics *diag.Diagnostics) *entities.AWSSigV4Config {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	cfg := &entities.AWSSigV4Config{
+		Region:       objectStringOf(obj, attrRegion),
+		Service:      objectStringOf(obj, attrService),
+		AccessKey:    objectStringOf(obj, attrAccessKey),
+		SecretKey:    objectStringOf(obj, attrSecretKey),
+		SessionToken: objectStringOf(obj, attrSessionToken),
+	}
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv(envAWSRegion)
+	}
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv(envAWSDefaultRegion)
+	}
+	if cfg.AccessKey == "" && cfg.SecretKey == "" {
+		cfg.AccessKey = os.Getenv(envAWSAccessKeyID)
+		cfg.SecretKey = os.Getenv(envAWSSecretAccessKey)
+		if cfg.SessionToken == "" {
+			cfg.SessionToken = os.Getenv(envAWSSessionToken)
+		}
+	}
+
+	if cfg.Region == "" {
+		diagnostics.AddAttributeError(
+			path.Root(attrAWSSigV4).AtName(attrRegion),
+			"Missing aws_sigv4 region",
+			fmt.Sprintf("Set `region` in the `aws_sigv4` block, or use the %s or %s environment variable.",
+				envAWSRegion, envAWSDefaultRegion),
+		)
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		diagnostics.AddAttributeError(
+			path.Root(attrAWSSigV4),
+			"Incomplete aws_sigv4 credentials",
+			fmt.Sprintf("Signing needs both an access key and a secret key. Set `access_key` and `secret_key` "+
+				"in the `aws_sigv4` block, or use the %s and %s environment variables.",
+				envAWSAccessKeyID, envAWSSecretAccessKey),
+		)
+	}
+
+	return cfg
+}
+
+// usesAWSSigV4 reports whether the provider-level `aws_sigv4` signs requests of this model, which is
+// the case unless the resource brings its own Authorization scheme.
+func (it *HTTPRequestResource) usesAWSSigV4(model HTTPRequestResourceModel) bool {
+	return !it.hasOwnAuthorization(model) && it.providerConfig().HasAWSSigV4()
+}
+
+// signAWSSigV4 signs a complete request with the provider-level `aws_sigv4` credentials.
+func (it *HTTPRequestResource) signAWSSigV4(request *http.Request) error {
+	if err := it.providerConfig().AWSSigV4.Sign(request, time.Now()); err != nil {
+		return fmt.Errorf("signing the request with aws_sigv4: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// awsTestSuiteConfig holds the credentials, region and service of the AWS Signature Version 4 test
+// suite.
+func awsTestSuiteConfig() *entities.AWSSigV4Config {
+	return &entities.AWSSigV4Config{
+		Region:    "us-east-1",
+		Service:   "service",
+		AccessKey: "AKIDEXAMPLE",
+		SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+}
+
+// awsSigV4Object builds a provider-level `aws_sigv4` value; empty arguments are left null.
+func awsSigV4Object(region, accessKey, secretKey, sessionToken string) types.Object {
+	stringOrNull := func(value string) types.String {
+		if value == "" {
+			return types.StringNull()
+		}
+
+		return types.StringValue(value)
+	}
+
+	return types.ObjectValueMust(map[string]attr.Type{
+		attrRegion:       types.StringType,
+		attrService:      types.StringType,
+		attrAccessKey:    types.StringType,
+		attrSecretKey:    types.StringType,
+		attrSessionToken: types.StringType,
+	}, map[string]attr.Value{
+		attrRegion:       stringOrNull(region),
+		attrService:      types.StringValue("execute-api"),
+		attrAccessKey:    stringOrNull(accessKey),
+		attrSecretKey:    stringOrNull(secretKey),
+		attrSessionToken: stringOrNull(sessionToken),
+	})
+}
+
+// streamedBody is a replayable body of zeros that records the largest read asked of it, which stays
+// at the size of a copy buffer as long as the body is streamed rather than read whole.
+type streamedBody struct {
+	remaining   int
+	largestRead *int
+}
+
+func (it *streamedBody) Read(p []byte) (int, error) {
+	*it.largestRead = max(*it.largestRead, len(p))
+	if it.remaining == 0 {
+		return 0, io.EOF
+	}
+	n := min(len(p), it.remaining)
+	clear(p[:n])
+	it.remaining -= n
+
+	return n, nil
+}
+
+// largeUpload returns a request whose 8 MiB body is replayed through streamedBody, and the largest
+// read the signer asked of it.
+func largeUpload(t *testing.T) (*http.Request, *int) {
+	t.Helper()
+
+	request, err := http.NewRequest(http.MethodPost, "https://example.amazonaws.com/upload", strings.NewReader("x"))
+	require.NoError(t, err)
+	largestRead := new(int)
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(&streamedBody{remaining: 8 << 20, largestRead: largestRead}), nil
+	}
+
+	return request, largestRead
+}
+
+func TestAWSSigV4Sign(t *testing.T) {
+	t.Parallel()
+
+	signedAt := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	const credential = "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "
+
+	// the requests and expected signatures of the published test suite, followed by query names
+	// that share a prefix, which sort by name before value
+	for _, vector := range []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		contentType   string
+		authorization string
+	}{
+		{
+			name:   "get-vanilla",
+			method: http.MethodGet, url: "https://example.amazonaws.com/",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:   "get-vanilla-query-order-key-case",
+			method: http.MethodGet, url: "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name:   "get-vanilla-utf8-query",
+			method: http.MethodGet, url: "https://example.amazonaws.com/?%E1%88%B4=bar",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=2cdec8eed098649ff3a119c94853b13c643bcf08f8b0a1d91e12c9027818dd04",
+		},
+		{
+			name:   "post-vanilla",
+			method: http.MethodPost, url: "https://example.amazonaws.com/",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:   "post-x-www-form-urlencoded",
+			method: http.MethodPost, url: "https://example.amazonaws.com/",
+			body: "Param1=value1", contentType: "application/x-www-form-urlencoded",
+			authorization: credential + "SignedHeaders=content-type;host;x-amz-date, " +
+				"Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+		{
+			name:   "query-names-sharing-a-prefix",
+			method: http.MethodGet, url: "https://example.amazonaws.com/?page2=x&page=1",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=30620a9043ccd2ec441290353608a3a3411441488a613106216377242730e6d9",
+		},
+		{
+			name:   "query-names-sharing-a-prefix-before-a-hyphen",
+			method: http.MethodGet, url: "https://example.amazonaws.com/?a-b=2&a=1",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=321dff75bd2a219c1b95fc5dbc497343614dbe8f73319c9d9c415bca43078ce2",
+		},
+	} {
+		t.Run("should match the "+vector.name+" test vector", func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			request, err := http.NewRequest(vector.method, vector.url, strings.NewReader(vector.body))
+			require.NoError(t, err)
+			if vector.contentType != "" {
+				request.Header.Set("Content-Type", vector.contentType)
+			}
+
+			// when
+			err = awsTestSuiteConfig().Sign(request, signedAt)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, "20150830T123600Z", request.Header.Get("X-Amz-Date"))
+			assert.Equal(t, vector.authorization, request.Header.Get("Authorization"))
+		})
+	}
+
+	t.Run("should sign the session token of temporary credentials", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		config := awsTestSuiteConfig()
+		config.SessionToken = "session"
+		request, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+		require.NoError(t, err)
+
+		// when
+		err = config.Sign(request, signedAt)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "session", request.Header.Get("X-Amz-Security-Token"))
+		assert.Contains(t, request.Header.Get("Authorization"), "SignedHeaders=host;x-amz-date;x-amz-security-token,")
+	})
+
+	t.Run("should leave the body readable after hashing it", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		request, err := http.NewRequest(http.MethodPost, "https://example.amazonaws.com/", strings.NewReader(`{"a":1}`))
+		require.NoError(t, err)
+
+		// when
+		err = awsTestSuiteConfig().Sign(request, signedAt)
+
+		// then
+		require.NoError(t, err)
+		body := new(strings.Builder)
+		_, err = io.Copy(body, request.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a":1}`, body.String())
+	})
+
+	t.Run("should stream a replayable body through the hash", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		request, largestRead := largeUpload(t)
+
+		// when
+		err := awsTestSuiteConfig().Sign(request, signedAt)
+
+		// then
+		require.NoError(t, err)
+		assert.LessOrEqual(t, *largestRead, 32<<10, "the upload is never held in memory whole")
+	})
+}
+
+func TestBuildRequestAWSSigV4(t *testing.T) {
+	t.Parallel()
+
+	resourceWithSigV4 := func() *HTTPRequestResource {
+		config := entities.NewConfiguration("https://example.test")
+		config.AWSSigV4 = awsTestSuiteConfig()
+		config.OAuth2 = &entities.OAuth2Config{TokenURL: "https://example.test/token"}
+
+		return &HTTPRequestResource{internal: entities.NewInternalContext(false, config)}
+	}
+
+	t.Run("should sign the final request in preference to the provider oauth2", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithSigV4()
+		model := requestModel(resourceHeaderMap(t, map[string]string{"X-Tenant": "acme"}))
+		model.Method = types.StringValue(http.MethodPost)
+		model.RequestBody = types.StringValue(`{"name":"widget"}`)
+
+		// when
+		request := buildTestRequest(t, it, model)
+
+		// then
+		authorization := request.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), authorization)
+		assert.Contains(t, authorization, "SignedHeaders=content-type;host;x-amz-date;x-tenant,")
+	})
+
+	t.Run("should let a resource bearer token override the signature", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithSigV4()
+		model := requestModel(types.MapNull(types.StringType))
+		model.BearerAuth = bearerAuthObject("resource-token")
+
+		// when
+		request := buildTestRequest(t, it, model)
+
+		// then
+		assert.Equal(t, "Bearer resource-token", request.Header.Get("Authorization"))
+		assert.Empty(t, request.Header.Get("X-Amz-Date"))
+	})
+}
+
+//nolint:paralleltest // t.Setenv cannot be combined with t.Parallel
+func TestProviderAWSSigV4(t *testing.T) {
+	t.Run("should fall back to the standard AWS environment variables", func(t *testing.T) {
+		// given
+		t.Setenv(envAWSRegion, "")
+		t.Setenv(envAWSDefaultRegion, "eu-west-1")
+		t.Setenv(envAWSAccessKeyID, "AKIDENV")
+		t.Setenv(envAWSSecretAccessKey, "env-secret")
+		t.Setenv(envAWSSessionToken, "env-token")
+		var diagnostics diag.Diagnostics
+
+		// when
+		config := providerAWSSigV4(awsSigV4Object("", "", "", ""), &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), "%v", diagnostics)
+		assert.Equal(t, &entities.AWSSigV4Config{
+			Region: "eu-west-1", Service: "execute-api",
+			AccessKey: "AKIDENV", SecretKey: "env-secret", SessionToken: "env-token",
+		}, config)
+	})
+
+	t.Run("should not mix a configured access key with the environment session token", func(t *testing.T) {
+		// given
+		t.Setenv(envAWSSessionToken, "env-token")
+		var diagnostics diag.Diagnostics
+
+		// when
+		config := providerAWSSigV4(awsSigV4Object("us-east-1", "AKIDCONFIG", "secret", ""), &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), "%v", diagnostics)
+		assert.Empty(t, config.SessionToken)
+	})
+
+	t.Run("should report missing credentials", func(t *testing.T) {
+		// given
+		t.Setenv(envAWSAccessKeyID, "")
+		t.Setenv(envAWSSecretAccessKey, "")
+		var diagnostics diag.Diagnostics
+
+		// when
+		providerAWSSigV4(awsSigV4Object("us-east-1", "", "", ""), &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Incomplete aws_sigv4 credentials", diagnostics[0].Summary())
+	})
+}
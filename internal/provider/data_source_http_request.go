@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// Ensure HTTPRequestDataSource satisfies various data source interfaces.
+var (
+	_ datasource.DataSource                   = &HTTPRequestDataSource{}
+	_ datasource.DataSourceWithConfigure      = &HTTPRequestDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &HTTPRequestDataSource{}
+)
+
+// HTTPRequestDataSource defines the data source implementation.
+//
+// It is the read-only counterpart of the http_request resource: a lookup that is re-executed on
+// every plan and never lands in a destroy plan. The round trip is delegated to the resource so both
+// build, authenticate, retry and interpret a request identically.
+type HTTPRequestDataSource struct {
+	internal *entities.InternalContext
+}
+
+// httpLookupModel holds the request arguments shared by the data source and the ephemeral
+// resource, both of which send a single request and keep nothing between runs.
+type httpLookupModel struct {
+	Method                 types.String `tfsdk:"method"`
+	Path                   types.String `tfsdk:"path"`
+	Headers                types.Map    `tfsdk:"headers"`
+	RequestBody            types.String `tfsdk:"request_body"`
+	IsResponseBodyJSON     types.Bool   `tfsdk:"is_response_body_json"`
+	ResponseBodyIDFilter   types.String `tfsdk:"response_body_id_filter"`
+	QueryParameters        types.Map    `tfsdk:"query_parameters"`
+	ToleratedStatusCodes   types.Set    `tfsdk:"tolerated_status_codes"`
+	CaptureResponseHeaders types.Set    `tfsdk:"capture_response_headers"`
+
+	// request-level configuration (alternative to provider-level)
+	BaseURL          types.String `tfsdk:"base_url"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+	BasicAuth        types.Object `tfsdk:"basic_auth"`
+	IgnoreTLS        types.Bool   `tfsdk:"ignore_tls"`
+	RequestTimeoutMs types.Int64  `tfsdk:"request_timeout_ms"`
+	Retry            types.Object `tfsdk:"retry"`
+}
+
+// HTTPRequestDataSourceModel describes the data source data model.
+type HTTPRequestDataSourceModel struct {
+	httpLookupModel
+
+	// state
+	ID               types.String `tfsdk:"id"`
+	ResponseCode     types.Int32  `tfsdk:"response_code"`
+	ResponseBody     types.String `tfsdk:"response_body"`
+	ResponseBodyID   types.String `tfsdk:"response_body_id"`
+	ResponseBodyJSON types.Map    `tfsdk:"response_body_json"`
+	ResponseHeaders  types.Map    `tfsdk:"response_headers"`
+}
+
+func NewHTTPRequestDataSource() datasource.DataSource {
+	return &HTTPRequestDataSource{}
+}
+
+func GetHTTPRequestDataSourceSchema() schema.Schema {
+	description := "Sends an HTTP request on every plan and exposes the response, for read-only lookups " +
+		"that should neither be kept in state between runs nor appear in a destroy plan. It shares the " +
+		"provider's base URL, headers, authentication, timeout and retry configuration with the " +
+		"`http_request` resource."
+
+	return schema.Schema{
+		Description:         description,
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			attrMethod: dataSourceOptionalString(
+				"The HTTP method to be used for the request. Defaults to GET. The request is sent on every " +
+					"plan, so anything other than a safe method repeats its side effect each time."),
+			attrPath: schema.StringAttribute{
+				Description: "The URL path for the HTTP request. This should be a relative path " +
+					"(e.g., /api/v1/resource).",
+				MarkdownDescription: "The URL path for the HTTP request. This should be a relative path " +
+					"(e.g., /api/v1/resource).",
+				Required: true,
+			},
+			attrHeaders: dataSourceOptionalStringMap(
+				"A map of HTTP headers to include in the request. They are applied after the provider " +
+					"`headers`, so a header named in both takes the value given here."),
+			attrRequestBody: dataSourceOptionalString(
+				"The body content to be sent with the HTTP request."),
+			attrIsResponseBodyJSON: schema.BoolAttribute{
+				Description:         "A boolean flag indicating whether the response body is expected to be in JSON format.",
+				MarkdownDescription: "A boolean flag indicating whether the response body is expected to be in JSON format.",
+				Optional:            true,
+			},
+			attrResponseBodyIDFilter: dataSourceOptionalString(
+				"A JSONPath filter used to extract a specific ID from the JSON response body. " +
+					"Required when `is_response_body_json` is true."),
+			attrQueryParameters: dataSourceOptionalStringMap(
+				"Optional query parameters to append to the request path"),
+			attrToleratedStatusCodes: schema.SetAttribute{
+				Description: "HTTP status codes that should be treated as successful in addition to the " +
+					"default 2xx range, e.g. [404] to look up something that may not exist.",
+				MarkdownDescription: "HTTP status codes that should be treated as successful in addition to the " +
+					"default 2xx range, e.g. `[404]` to look up something that may not exist.",
+				Optional:    true,
+				ElementType: types.Int32Type,
+			},
+			attrCaptureResponseHeaders: schema.SetAttribute{
+				Description:         "Names of the response headers to expose in `response_headers`. Nothing is exposed when unset.",
+				MarkdownDescription: "Names of the response headers to expose in `response_headers`. Nothing is exposed when unset.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			attrBaseURL: dataSourceOptionalString(
+				"The base URL for this specific HTTP request. When specified, this overrides the " +
+					"provider-level URL configuration." + descUnixSocketURL),
+			attrEndpoint: dataSourceOptionalString(descEndpoint),
+			attrBasicAuth: schema.SingleNestedAttribute{
+				Description: "Credentials for basic authentication for this specific request. " +
+					"When specified, this overrides the provider-level basic authentication configuration.",
+				MarkdownDescription: "Credentials for basic authentication for this specific request. " +
+					"When specified, this overrides the provider-level basic authentication configuration.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					attrUsername: schema.StringAttribute{
+						Description:         "The username for basic authentication.",
+						MarkdownDescription: "The username for basic authentication.",
+						Required:            true,
+					},
+					attrPassword: schema.StringAttribute{
+						Description:         "The password for basic authentication.",
+						MarkdownDescription: "The password for basic authentication.",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			attrIgnoreTLS: schema.BoolAttribute{
+				Description: "A boolean flag to indicate whether TLS certificate verification should be " +
+					"ignored for this specific request. When specified, this overrides the provider-level " +
+					"ignore_tls configuration.",
+				MarkdownDescription: "A boolean flag to indicate whether TLS certificate verification should be " +
+					"ignored for this specific request. When specified, this overrides the provider-level " +
+					"ignore_tls configuration.",
+				Optional: true,
+			},
+			attrRequestTimeoutMs: schema.Int64Attribute{
+				Description: "The per-request timeout in milliseconds for this specific HTTP request. When " +
+					"specified, this overrides the provider-level request_timeout_ms.",
+				MarkdownDescription: "The per-request timeout in milliseconds for this specific HTTP request. When " +
+					"specified, this overrides the provider-level request_timeout_ms.",
+				Optional: true,
+			},
+			attrID: dataSourceComputedString("The full URL the request was sent to."),
+			attrResponseCode: schema.Int32Attribute{
+				Description:         "The HTTP status code returned by the server.",
+				MarkdownDescription: "The HTTP status code returned by the server.",
+				Computed:            true,
+			},
+			attrResponseBody: dataSourceComputedString(
+				"The raw body content returned by the server."),
+			attrResponseBodyID: dataSourceComputedString(
+				"The extracted ID from the JSON response body, based on the provided " +
+					"`response_body_id_filter`. This is only populated if `is_response_body_json` is true."),
+			attrResponseBodyJSON: schema.MapAttribute{
+				Description: "The response body parsed as a Terraform map object. Nested items can be " +
+					"accessed using dot notation (e.g., \"response_body_json[\"nested.item.value\"]\").",
+				MarkdownDescription: "The response body parsed as a Terraform map object. Nested items can be " +
+					"accessed using dot notation (e.g., \"response_body_json[\"nested.item.value\"]\").",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			attrResponseHeaders: schema.MapAttribute{
+				Description:         "The response headers listed in `capture_response_headers`, keyed by their canonical name.",
+				MarkdownDescription: "The response headers listed in `capture_response_headers`, keyed by their canonical name.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			attrRetry: schema.SingleNestedBlock{
+				Description: "Retry configuration for this specific HTTP request. When specified, this " +
+					"overrides the provider-level retry configuration.",
+				MarkdownDescription: "Retry configuration for this specific HTTP request. When specified, this " +
+					"overrides the provider-level retry configuration.",
+				Attributes: map[string]schema.Attribute{
+					attrAttempts:   dataSourceOptionalInt64(descRetryAttempts),
+					attrMinDelayMs: dataSourceOptionalInt64(descRetryMinDelayMs),
+					attrMaxDelayMs: dataSourceOptionalInt64(descRetryMaxDelayMs),
+					attrRetryOnStatusCodes: schema.SetAttribute{
+						Description:         descRetryOnStatusCodes,
+						MarkdownDescription: descRetryOnStatusCodes,
+						Optional:            true,
+						ElementType:         types.Int32Type,
+					},
+					attrRespectRetryAfter:  dataSourceOptionalBool(descRespectRetryAfter),
+					attrJitter:             dataSourceOptionalBool(descJitter),
+					attrRetryNonIdempotent: dataSourceOptionalBool(descRetryNonIdempotent),
+					attrRetryOnBodyMatch:   dataSourceOptionalString(descRetryOnBodyMatch),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOptionalString(description string) schema.StringAttribute {
+	return schema.StringAttribute{Description: description, MarkdownDescription: description, Optional: true}
+}
+
+func dataSourceOptionalStringMap(description string) schema.MapAttribute {
+	return schema.MapAttribute{
+		Description:         description,
+		MarkdownDescription: description,
+		Optional:            true,
+		ElementType:         types.StringType,
+	}
+}
+
+func dataSourceOptionalBool(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{Description: description, MarkdownDescription: description, Optional: true}
+}
+
+func dataSourceOptionalInt64(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{Description: description, MarkdownDescription: description, Optional: true}
+}
+
+func dataSourceComputedString(description string) schema.StringAttribute {
+	return schema.StringAttribute{Description: description, MarkdownDescription: description, Computed: true}
+}
+
+func (it *HTTPRequestDataSource) Metadata(
+	_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_request"
+}
+
+func (it *HTTPRequestDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = GetHTTPRequestDataSourceSchema()
+}
+
+func (it *HTTPRequestDataSource) ValidateConfig(
+	ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse,
+) {
+	var model HTTPRequestDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkResponseBodyIDFilter(model.IsResponseBodyJSON, model.ResponseBodyIDFilter, &resp.Diagnostics)
+	checkStatusCodes(ctx, attrToleratedStatusCodes, model.ToleratedStatusCodes, &resp.Diagnostics)
+	checkRetryArguments(ctx, model.Retry, &resp.Diagnostics)
+}
+
+func (it *HTTPRequestDataSource) Configure(
+	_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// added a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	internal, ok := req.ProviderData.(*entities.InternalContext)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *InternalContext, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	it.internal = internal
+}
+
+func (it *HTTPRequestDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	tflog.Info(ctx, "Starting HTTP lookup...")
+
+	var config HTTPRequestDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, ok := (&HTTPRequestResource{internal: it.internal}).lookup(ctx, config.httpLookupModel, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	config.ID = model.ID
+	config.ResponseCode = model.ResponseCode
+	config.ResponseBody = model.ResponseBody
+	config.ResponseBodyID = model.ResponseBodyID
+	config.ResponseBodyJSON = model.ResponseBodyJSON
+	config.ResponseHeaders = model.ResponseHeaders
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+
+	tflog.Info(ctx, "Completed HTTP lookup...", map[string]any{"success": true})
+}
+
+// lookup sends the request a lookup describes and returns it as a resource model whose response
+// attributes are populated. Its `id` is the endpoint: the natural identity of a lookup, and unlike a
+// generated UUID it does not change on every plan.
+func (it *HTTPRequestResource) lookup(
+	ctx context.Context,
+	params httpLookupModel,
+	diagnostics *diag.Diagnostics,
+) (HTTPRequestResourceModel, bool) {
+	model := params.toResourceModel()
+
+	exchange, ok := it.performRequest(ctx, model, diagnostics)
+	if !ok || !it.acceptExchange(ctx, model, exchange, diagnostics) {
+		return model, false
+	}
+
+	model.ID = types.StringValue(exchange.endpoint)
+	populateResponseState(ctx, &model, exchange, diagnostics)
+
+	return model, !diagnostics.HasError()
+}
+
+// toResourceModel lifts the lookup into the resource model the shared request code accepts. Every
+// attribute the data source does not have is a typed null, which that code reads as "not set".
+func (m httpLookupModel) toResourceModel() HTTPRequestResourceModel {
+	method := http.MethodGet
+	if isNonEmptyString(m.Method) {
+		method = strings.ToUpper(strings.TrimSpace(m.Method.ValueString()))
+	}
+
+	return HTTPRequestResourceModel{
+		Method:                 types.StringValue(method),
+		Path:                   m.Path,
+		Headers:                m.Headers,
+		RequestBody:            m.RequestBody,
+		RequestBodyFile:        types.StringNull(),
+		RequestBodyBase64:      types.StringNull(),
+		ContentType:            types.StringNull(),
+		RequestBodyHash:        types.StringNull(),
+		Multipart:              types.ObjectNull(multipartObjectAttrTypes()),
+		IsResponseBodyJSON:     m.IsResponseBodyJSON,
+		ResponseBodyIDFilter:   m.ResponseBodyIDFilter,
+		QueryParameters:        m.QueryParameters,
+		ToleratedStatusCodes:   m.ToleratedStatusCodes,
+		IgnoreChanges:          types.SetNull(types.StringType),
+		CaptureResponseHeaders: m.CaptureResponseHeaders,
+		BaseURL:                m.BaseURL,
+		Endpoint:               m.Endpoint,
+		BasicAuth:              m.BasicAuth,
+		IgnoreTLS:              m.IgnoreTLS,
+		RequestTimeoutMs:       m.RequestTimeoutMs,
+		Retry:                  m.Retry,
+		IsDeleteEnabled:        types.BoolNull(),
+		DeleteMethod:           types.StringNull(),
+		DeletePath:             types.StringNull(),
+		DeleteHeaders:          types.MapNull(types.StringType),
+		DeleteRequestBody:      types.StringNull(),
+		DeleteResolvedPath:     types.StringNull(),
+		IsRefreshEnabled:       types.BoolNull(),
+		RefreshPath:            types.StringNull(),
+		RefreshMethod:          types.StringNull(),
+		RefreshHeaders:         types.MapNull(types.StringType),
+		RefreshRequestBody:     types.StringNull(),
+		RefreshGoneStatusCodes: types.SetNull(types.Int32Type),
+		DriftDetection:         types.ObjectNull(driftDetectionObjectAttrTypes()),
+		DeleteWait:             types.ObjectNull(deleteWaitObjectAttrTypes()),
+		BearerAuth:             types.ObjectNull(bearerAuthObjectAttrTypes()),
+		APIKey:                 types.ObjectNull(apiKeyObjectAttrTypes()),
+		HMACSignature:          types.ObjectNull(hmacSignatureObjectAttrTypes()),
+		DigestAuth:             types.ObjectNull(digestAuthObjectAttrTypes()),
+		tlsArguments:           nullTLSArguments(),
+		proxyArguments:         nullProxyArguments(),
+		redirectArguments:      nullRedirectArguments(),
+		UpdateMethod:           types.StringNull(),
+		UpdatePath:             types.StringNull(),
+		UpdateHeaders:          types.MapNull(types.StringType),
+		UpdateRequestBody:      types.StringNull(),
+		WaitFor:                types.ObjectNull(waitForObjectAttrTypes()),
+		ID:                     types.StringNull(),
+		ImportID:               types.StringNull(),
+		ResponseCode:           types.Int32Null(),
+		ResponseBody:           types.StringNull(),
+		ResponseBodyID:         types.StringNull(),
+		ResponseBodyJSON:       types.MapNull(types.StringType),
+		ResponseHeaders:        types.MapNull(types.StringType),
+		FinalURL:               types.StringNull(),
+	}
+}
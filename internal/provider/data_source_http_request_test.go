@@ -0,0 +1,138 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dataSourceConfigWith builds a data source configuration holding the given values, every other
+// attribute being null.
+func dataSourceConfigWith(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	dataSourceSchema := GetHTTPRequestDataSourceSchema()
+	objectType, ok := dataSourceSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	require.True(t, ok, "the data source schema must describe an object")
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return tfsdk.Config{Raw: tftypes.NewValue(objectType, attributes), Schema: dataSourceSchema}
+}
+
+// readDataSource runs Read against the given configuration and returns the resulting model.
+func readDataSource(t *testing.T, config tfsdk.Config) (HTTPRequestDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Raw:    tftypes.NewValue(config.Raw.Type(), nil),
+			Schema: config.Schema,
+		},
+	}
+	NewHTTPRequestDataSource().Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var model HTTPRequestDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	}
+
+	return model, resp
+}
+
+func TestHTTPRequestDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send a GET by default and expose the parsed response", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotMethod string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte(`{"id":"42","owner":{"name":"alice"}}`))
+		}))
+		t.Cleanup(server.Close)
+		config := dataSourceConfigWith(t, map[string]tftypes.Value{
+			attrBaseURL:              tftypes.NewValue(tftypes.String, server.URL),
+			attrPath:                 tftypes.NewValue(tftypes.String, "/widgets/42"),
+			attrIsResponseBodyJSON:   tftypes.NewValue(tftypes.Bool, true),
+			attrResponseBodyIDFilter: tftypes.NewValue(tftypes.String, "$.id"),
+			attrCaptureResponseHeaders: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String},
+				[]tftypes.Value{tftypes.NewValue(tftypes.String, "etag")}),
+		})
+
+		// when
+		model, resp := readDataSource(t, config)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "the lookup succeeds: %v", resp.Diagnostics)
+		assert.Equal(t, http.MethodGet, gotMethod)
+		assert.Equal(t, server.URL+"/widgets/42", model.ID.ValueString(), "the id is the endpoint")
+		assert.Equal(t, int32(http.StatusOK), model.ResponseCode.ValueInt32())
+		assert.Equal(t, "42", model.ResponseBodyID.ValueString())
+		assert.Equal(t, types.StringValue("alice"), model.ResponseBodyJSON.Elements()["owner.name"])
+		assert.Equal(t, types.StringValue(`"v1"`), model.ResponseHeaders.Elements()["Etag"])
+	})
+
+	t.Run("should accept a tolerated status code", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		t.Cleanup(server.Close)
+		config := dataSourceConfigWith(t, map[string]tftypes.Value{
+			attrBaseURL: tftypes.NewValue(tftypes.String, server.URL),
+			attrPath:    tftypes.NewValue(tftypes.String, "/missing"),
+			attrToleratedStatusCodes: tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number},
+				[]tftypes.Value{tftypes.NewValue(tftypes.Number, http.StatusNotFound)}),
+		})
+
+		// when
+		model, resp := readDataSource(t, config)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "a tolerated 404 is not an error: %v", resp.Diagnostics)
+		assert.Equal(t, int32(http.StatusNotFound), model.ResponseCode.ValueInt32())
+	})
+
+	t.Run("should fail on a status code that is not tolerated", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(server.Close)
+		config := dataSourceConfigWith(t, map[string]tftypes.Value{
+			attrBaseURL: tftypes.NewValue(tftypes.String, server.URL),
+			attrPath:    tftypes.NewValue(tftypes.String, "/broken"),
+		})
+
+		// when
+		_, resp := readDataSource(t, config)
+
+		// then
+		assert.True(t, resp.Diagnostics.HasError())
+	})
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+// deleteWaitObjectAttrTypes returns the attribute types of the `delete_wait` nested object. Like
+// waitForObjectAttrTypes, it MUST be used wherever a typed null `delete_wait` value is produced.
+func deleteWaitObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrPath:            types.StringType,
+		attrGoneStatusCodes: types.SetType{ElemType: types.Int32Type},
+		attrIntervalMs:      types.Int64Type,
+		attrTimeoutMs:       types.Int64Type,
+	}
+}
+
+// resourceDeleteWaitBlock returns the `delete_wait` block. Like `wait_for` it is stored in state,
+// because Delete receives no configuration.
+func resourceDeleteWaitBlock() schema.SingleNestedBlock {
+	description := "Waits for an asynchronous deletion to complete. When set, destroy only removes " +
+		"the resource from state once a GET against `path` answers with one of `gone_status_codes`, so " +
+		"a create that reuses the name of the deleted object does not conflict with it. The probe sends " +
+		"`refresh_headers`, or `delete_headers` when those are unset, and no `query_parameters`. Only " +
+		"used when `is_delete_enabled` is true."
+
+	return schema.SingleNestedBlock{
+		Description:         description,
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			attrPath: helpers.StringAttributeNoReplace(false,
+				"Path to probe. Defaults to the path the delete request was sent to "+
+					"(`delete_resolved_path`, or `path`). Supports the same inline tokens as `delete_path`, "+
+					"evaluated against the captured `response_body` and `response_headers`."),
+			attrGoneStatusCodes: schema.SetAttribute{
+				Description:         "HTTP status codes of the probe that mean the object is gone. Defaults to [404, 410].",
+				MarkdownDescription: "HTTP status codes of the probe that mean the object is gone. Defaults to `[404, 410]`.",
+				Optional:            true,
+				ElementType:         types.Int32Type,
+			},
+			attrIntervalMs: helpers.Int64AttributeNoReplace(false,
+				"Delay between two probes, in milliseconds. Defaults to `2000`."),
+			attrTimeoutMs: helpers.Int64AttributeNoReplace(false,
+				"How long to keep probing before failing, in milliseconds. Defaults to `600000`."),
+		},
+	}
+}
+
+// deleteWaitConfig is the resolved `delete_wait` block.
+type deleteWaitConfig struct {
+	path            string
+	goneStatusCodes types.Set
+	interval        time.Duration
+	timeout         time.Duration
+}
+
+// deleteWaitConfigFromObject converts a `delete_wait` nested object into its resolved form,
+// applying defaults. It returns nil when the object is null or unknown, meaning "do not wait".
+func deleteWaitConfigFromObject(obj types.Object) *deleteWaitConfig {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	attrs := obj.Attributes()
+	interval, timeout := pollCadenceOf(attrs)
+	cfg := &deleteWaitConfig{
+		goneStatusCodes: types.SetNull(types.Int32Type),
+		interval:        interval,
+		timeout:         timeout,
+	}
+	if v, ok := attrs[attrPath].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+		cfg.path = strings.TrimSpace(v.ValueString())
+	}
+	if v, ok := attrs[attrGoneStatusCodes].(types.Set); ok {
+		cfg.goneStatusCodes = v
+	}
+
+	return cfg
+}
+
+// validateDeleteWait checks the status codes of the `delete_wait` block.
+func validateDeleteWait(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var codes types.Set
+	resp.Diagnostics.Append(
+		req.Config.GetAttribute(ctx, path.Root(attrDeleteWait).AtName(attrGoneStatusCodes), &codes)...,
+	)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkStatusCodes(ctx, attrDeleteWait+"."+attrGoneStatusCodes, codes, &resp.Diagnostics)
+}
+
+// makeProbeModel derives the GET that probes a deleted object. It reads the object, so it carries
+// the `refresh_headers`, or the `delete_headers` when those are unset, and never the create headers
+// or `query_parameters`, which describe the request that created it.
+func makeProbeModel(model HTTPRequestResourceModel, probePath string) HTTPRequestResourceModel {
+	probeModel := model
+	probeModel.Method = types.StringValue(http.MethodGet)
+	probeModel.Path = types.StringValue(probePath)
+	probeModel.QueryParameters = types.MapNull(types.StringType)
+	replaceRequestBody(&probeModel, types.StringNull())
+	switch {
+	case !model.RefreshHeaders.IsNull() && !model.RefreshHeaders.IsUnknown():
+		probeModel.Headers = model.RefreshHeaders
+	case !model.DeleteHeaders.IsNull() && !model.DeleteHeaders.IsUnknown():
+		probeModel.Headers = model.DeleteHeaders
+	default:
+		probeModel.Headers = types.MapNull(types.StringType)
+	}
+
+	return probeModel
+}
+
+// awaitDeletion probes the deleted object until it reports being gone. Without a `delete_wait`
+// block it returns immediately. deletedPath is the path the delete request was sent to, which the
+// probe targets unless the block names its own.
+func (it *HTTPRequestResource) awaitDeletion(
+	ctx context.Context,
+	model HTTPRequestResourceModel,
+	deletedPath string,
+	diagnostics *diag.Diagnostics,
+) bool {
+	cfg := deleteWaitConfigFromObject(model.DeleteWait)
+	if cfg == nil {
+		return true
+	}
+
+	probePath := deletedPath
+	if cfg.path != "" {
+		resolved, ok := resolvePathTokens(
+			cfg.path,
+			model.ResponseBody.ValueString(),
+			headersOf(ctx, model.ResponseHeaders, diagnostics),
+			diagnostics,
+		)
+		if !ok {
+			return false
+		}
+		probePath = resolved
+	}
+
+	probeModel := makeProbeModel(model, probePath)
+
+	deadline := time.Now().Add(cfg.timeout)
+	for attempt := 1; ; attempt++ {
+		exchange, ok := it.performRequest(ctx, probeModel, diagnostics)
+		if !ok {
+			return false
+		}
+
+		gone := isGoneStatusCode(ctx, cfg.goneStatusCodes, exchange.statusCode, diagnostics)
+		tflog.Debug(ctx, "Probed deleted object...", map[string]any{
+			"attempt": attempt, attrPath: probePath, "status": exchange.status, "gone": gone,
+		})
+
+		if diagnostics.HasError() {
+			return false
+		}
+
+		if gone {
+			return true
+		}
+
+		if time.Now().Add(cfg.interval).After(deadline) {
+			diagnostics.AddError(
+				"Timed out waiting for the deletion to complete",
+				fmt.Sprintf("GET %s still answered %s after %s, so the object was not removed from state. "+
+					"The next destroy probes it again.", probePath, exchange.status, cfg.timeout),
+			)
+
+			return false
+		}
+
+		select {
+		case <-ctx.Done():
+			diagnostics.AddError("Interrupted while waiting for the deletion to complete", ctx.Err().Error())
+
+			return false
+		case <-time.After(cfg.interval):
+		}
+	}
+}
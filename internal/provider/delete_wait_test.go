@@ -0,0 +1,238 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deleteWaitObject builds a `delete_wait` block probing the given path (the deleted path when
+// empty) every millisecond, giving up after timeoutMs.
+func deleteWaitObject(probePath string, timeoutMs int64) types.Object {
+	pathValue := types.StringNull()
+	if probePath != "" {
+		pathValue = types.StringValue(probePath)
+	}
+
+	return types.ObjectValueMust(deleteWaitObjectAttrTypes(), map[string]attr.Value{
+		attrPath:            pathValue,
+		attrGoneStatusCodes: types.SetNull(types.Int32Type),
+		attrIntervalMs:      types.Int64Value(1),
+		attrTimeoutMs:       types.Int64Value(timeoutMs),
+	})
+}
+
+// deletingServer answers `200 OK` to the first probes and `404 Not Found` from the given probe on,
+// recording the path of the last one.
+func deletingServer(t *testing.T, goneFrom int32, probed *string) *httptest.Server {
+	t.Helper()
+
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*probed = r.Method + " " + r.URL.Path
+		if probes.Add(1) >= goneFrom {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDeleteWaitConfigFromObject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nil when the block is not set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		block := types.ObjectNull(deleteWaitObjectAttrTypes())
+
+		// when
+		cfg := deleteWaitConfigFromObject(block)
+
+		// then
+		assert.Nil(t, cfg)
+	})
+
+	t.Run("should apply the polling defaults to an empty block", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		block := types.ObjectValueMust(deleteWaitObjectAttrTypes(), map[string]attr.Value{
+			attrPath:            types.StringNull(),
+			attrGoneStatusCodes: types.SetNull(types.Int32Type),
+			attrIntervalMs:      types.Int64Null(),
+			attrTimeoutMs:       types.Int64Null(),
+		})
+
+		// when
+		cfg := deleteWaitConfigFromObject(block)
+
+		// then
+		require.NotNil(t, cfg)
+		assert.Empty(t, cfg.path)
+		assert.Equal(t, int64(defaultWaitForIntervalMs), cfg.interval.Milliseconds())
+		assert.Equal(t, int64(defaultWaitForTimeoutMs), cfg.timeout.Milliseconds())
+	})
+}
+
+func TestAwaitDeletion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should probe the deleted path until it answers 404", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var probed string
+		server := deletingServer(t, 3, &probed)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.DeleteWait = deleteWaitObject("", 5000)
+		var diagnostics diag.Diagnostics
+
+		// when
+		ok := (&HTTPRequestResource{}).awaitDeletion(context.Background(), model, "/widgets/7", &diagnostics)
+
+		// then
+		require.True(t, ok, "the deletion completes: %v", diagnostics)
+		assert.Equal(t, "GET /widgets/7", probed)
+	})
+
+	t.Run("should resolve the tokens of the probe path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var probed string
+		server := deletingServer(t, 1, &probed)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.ResponseBody = types.StringValue(`{"id":"abc"}`)
+		model.DeleteWait = deleteWaitObject("/widgets/$.id/status", 5000)
+		var diagnostics diag.Diagnostics
+
+		// when
+		ok := (&HTTPRequestResource{}).awaitDeletion(context.Background(), model, "/widgets/abc", &diagnostics)
+
+		// then
+		require.True(t, ok, "the deletion completes: %v", diagnostics)
+		assert.Equal(t, "GET /widgets/abc/status", probed)
+	})
+
+	t.Run("should fail with the last status once the timeout elapses", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := statusServer(t, http.StatusOK, nil, nil)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.DeleteWait = deleteWaitObject("", 20)
+		var diagnostics diag.Diagnostics
+
+		// when
+		ok := (&HTTPRequestResource{}).awaitDeletion(context.Background(), model, "/widgets/7", &diagnostics)
+
+		// then
+		assert.False(t, ok)
+		require.True(t, diagnostics.HasError())
+		assert.Equal(t, "Timed out waiting for the deletion to complete", diagnostics[0].Summary())
+		assert.Contains(t, diagnostics[0].Detail(), "200 OK")
+	})
+
+	t.Run("should return at once without the block", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := pollingModel("http://127.0.0.1:1", types.ObjectNull(waitForObjectAttrTypes()))
+		model.DeleteWait = types.ObjectNull(deleteWaitObjectAttrTypes())
+		var diagnostics diag.Diagnostics
+
+		// when
+		ok := (&HTTPRequestResource{}).awaitDeletion(context.Background(), model, "/widgets/7", &diagnostics)
+
+		// then
+		assert.True(t, ok)
+		assert.Empty(t, diagnostics)
+	})
+}
+
+func TestAwaitDeletionProbeRequest(t *testing.T) {
+	t.Parallel()
+
+	headerMap := func(name string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{name: types.StringValue("1")})
+	}
+	tests := []struct {
+		name           string
+		refreshHeaders types.Map
+		deleteHeaders  types.Map
+		wantHeader     string
+	}{
+		{
+			name:           "should send the refresh headers",
+			refreshHeaders: headerMap("X-Read"),
+			deleteHeaders:  headerMap("X-Delete"),
+			wantHeader:     "X-Read",
+		},
+		{
+			name:           "should fall back to the delete headers",
+			refreshHeaders: types.MapNull(types.StringType),
+			deleteHeaders:  headerMap("X-Delete"),
+			wantHeader:     "X-Delete",
+		},
+		{
+			name:           "should send no resource headers when neither is set",
+			refreshHeaders: types.MapNull(types.StringType),
+			deleteHeaders:  types.MapNull(types.StringType),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			probes := make(chan *http.Request, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				probes <- r
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			t.Cleanup(server.Close)
+			model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+			model.Headers = headerMap("X-Create")
+			model.QueryParameters = types.MapValueMust(types.StringType, map[string]attr.Value{
+				"async": types.StringValue("true"),
+			})
+			model.RefreshHeaders = tt.refreshHeaders
+			model.DeleteHeaders = tt.deleteHeaders
+			model.DeleteWait = deleteWaitObject("", 5000)
+			var diagnostics diag.Diagnostics
+
+			// when
+			ok := (&HTTPRequestResource{}).awaitDeletion(context.Background(), model, "/widgets/7", &diagnostics)
+
+			// then
+			require.True(t, ok, "the deletion completes: %v", diagnostics)
+			probe := <-probes
+			assert.Empty(t, probe.Header.Get("X-Create"), "the create headers describe another request")
+			assert.Empty(t, probe.URL.RawQuery, "the create query describes another request")
+			for _, name := range []string{"X-Read", "X-Delete"} {
+				if name == tt.wantHeader {
+					assert.Equal(t, "1", probe.Header.Get(name))
+				} else {
+					assert.Empty(t, probe.Header.Get(name))
+				}
+			}
+		})
+	}
+}
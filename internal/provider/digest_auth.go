@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+const attrDigestAuth = "digest_auth"
+
+// Descriptions of the `digest_auth` arguments, shared by the provider and the resource schema
+// builders.
+const (
+	descDigestAuthProvider = "HTTP Digest authentication (RFC 7616), for appliances such as network " +
+		"gear and BMCs that accept nothing else. The client answers the `401` challenge of the server " +
+		"and sends the request again, then reuses the nonce for later requests to the same host until " +
+		"the server issues a new one. `qop=auth` is supported with the `MD5` and `SHA-256` algorithms " +
+		"and their `-sess` variants. It takes precedence over the provider-level `basic_auth`, and a " +
+		"resource's own `basic_auth`, `bearer_auth` or `digest_auth` overrides it."
+	descDigestAuthResource = "HTTP Digest authentication (RFC 7616) for this specific request, answering " +
+		"the challenge of the server as the provider-level `digest_auth` does. When specified, this " +
+		"overrides every provider-level authentication writing the Authorization header. Conflicts with " +
+		"`basic_auth` and `bearer_auth`."
+	descDigestUsername = "The username for Digest authentication."
+	descDigestPassword = "The password for Digest authentication."
+)
+
+// digestAuthObjectAttrTypes returns the attribute types of the `digest_auth` nested object.
+func digestAuthObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrUsername: types.StringType,
+		attrPassword: types.StringType,
+	}
+}
+
+// addDigestAuthAttribute adds `digest_auth` to the resource schema. Like `basic_auth` it never
+// forces replacement.
+func addDigestAuthAttribute(attrs map[string]schema.Attribute) {
+	attrs[attrDigestAuth] = schema.SingleNestedAttribute{
+		Description:         descDigestAuthResource,
+		MarkdownDescription: descDigestAuthResource,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			attrUsername: schema.StringAttribute{
+				Description:         descDigestUsername,
+				MarkdownDescription: descDigestUsername,
+				Required:            true,
+			},
+			attrPassword: schema.StringAttribute{
+				Description:         descDigestPassword,
+				MarkdownDescription: descDigestPassword,
+				Required:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// digestAuthOf converts a `digest_auth` object, returning nil when it is not set.
+func digestAuthOf(obj types.Object) *entities.DigestAuth {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	return &entities.DigestAuth{
+		Username: objectStringOf(obj, attrUsername),
+		Password: objectStringOf(obj, attrPassword),
+	}
+}
+
+// resolveDigestAuth returns the Digest credentials the client answers challenges of this model
+// with: the resource-level ones, or the provider-level ones unless the resource brings another
+// Authorization scheme or the provider prefers one. It returns nil when neither applies.
+func (it *HTTPRequestResource) resolveDigestAuth(model HTTPRequestResourceModel) *entities.DigestAuth {
+	if credentials := digestAuthOf(model.DigestAuth); credentials != nil {
+		return credentials
+	}
+
+	config := it.providerConfig()
+	if it.hasOwnAuthorization(model) || config.HasAWSSigV4() || config.HasOAuth2() ||
+		config.HasBearerAuth() || !config.HasDigestAuth() {
+		return nil
+	}
+
+	return config.DigestAuth
+}
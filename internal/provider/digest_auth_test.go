@@ -0,0 +1,291 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // the server under test answers MD5 challenges
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// The realm, nonce and opaque of the RFC 7616 examples, which the server under test challenges with.
+const (
+	digestTestRealm  = "http-auth@example.org"
+	digestTestNonce  = "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v"
+	digestTestOpaque = "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"
+)
+
+var digestTestParam = regexp.MustCompile(`(\w+)=(?:"([^"]*)"|([^,\s]*))`)
+
+// digestLog records what the server under test received.
+type digestLog struct {
+	mutex      sync.Mutex
+	challenges int
+	// nonceCounts and bodies are those of the requests that authenticated.
+	nonceCounts []string
+	bodies      []string
+}
+
+// digestServer challenges every request without a valid Digest answer for Mufasa and "Circle of
+// Life" with the algorithm given, checking the response it computes on its own.
+func digestServer(t *testing.T, algorithm string, log *digestLog) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		log.mutex.Lock()
+		defer log.mutex.Unlock()
+
+		fields := map[string]string{}
+		if params, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Digest "); ok {
+			for _, match := range digestTestParam.FindAllStringSubmatch(params, -1) {
+				fields[match[1]] = match[2] + match[3]
+			}
+		}
+		newHash := md5.New
+		if algorithm == entities.DigestAlgorithmSHA256 {
+			newHash = sha256.New
+		}
+		digest := func(parts ...string) string {
+			sum := newHash()
+			sum.Write([]byte(strings.Join(parts, ":")))
+
+			return hex.EncodeToString(sum.Sum(nil))
+		}
+		expected := digest(
+			digest("Mufasa", digestTestRealm, "Circle of Life"),
+			digestTestNonce, fields["nc"], fields["cnonce"], "auth",
+			digest(r.Method, r.URL.RequestURI()),
+		)
+
+		if fields["response"] != expected || fields["opaque"] != digestTestOpaque || fields["algorithm"] != algorithm {
+			log.challenges++
+			w.Header().Add("WWW-Authenticate", `Digest realm="`+digestTestRealm+`", qop="auth, auth-int", `+
+				`algorithm=`+algorithm+`, nonce="`+digestTestNonce+`", opaque="`+digestTestOpaque+`"`)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		log.nonceCounts = append(log.nonceCounts, fields["nc"])
+		log.bodies = append(log.bodies, string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// digestAuthObject builds a resource-level `digest_auth` value.
+func digestAuthObject(username, password string) types.Object {
+	return types.ObjectValueMust(digestAuthObjectAttrTypes(), map[string]attr.Value{
+		attrUsername: types.StringValue(username),
+		attrPassword: types.StringValue(password),
+	})
+}
+
+func TestDigestAuth(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{entities.DigestAlgorithmMD5, entities.DigestAlgorithmSHA256} {
+		t.Run("should answer the "+algorithm+" challenge of the server", func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			var log digestLog
+			server := digestServer(t, algorithm, &log)
+			it := resourceWithProviderAuth(nil, nil, nil)
+			model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+			model.DigestAuth = digestAuthObject("Mufasa", "Circle of Life")
+			var diagnostics diag.Diagnostics
+
+			// when
+			exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+			// then
+			require.True(t, ok, "diagnostics: %v", diagnostics)
+			assert.Equal(t, http.StatusOK, exchange.statusCode)
+			assert.Equal(t, 1, log.challenges)
+			assert.Equal(t, []string{"00000001"}, log.nonceCounts)
+		})
+	}
+
+	t.Run("should reuse the nonce instead of being challenged again", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var log digestLog
+		server := digestServer(t, entities.DigestAlgorithmSHA256, &log)
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.DigestAuth = digestAuthObject("Mufasa", "Circle of Life")
+		var diagnostics diag.Diagnostics
+
+		// when
+		for range 3 {
+			_, ok := it.performRequest(context.Background(), model, &diagnostics)
+			require.True(t, ok, "diagnostics: %v", diagnostics)
+		}
+
+		// then
+		assert.Equal(t, 1, log.challenges)
+		assert.Equal(t, []string{"00000001", "00000002", "00000003"}, log.nonceCounts)
+	})
+
+	t.Run("should count every use of the nonce once when requests run in parallel", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var log digestLog
+		server := digestServer(t, entities.DigestAlgorithmSHA256, &log)
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.DigestAuth = digestAuthObject("Mufasa", "Circle of Life")
+		var diagnostics diag.Diagnostics
+		_, ok := it.performRequest(context.Background(), model, &diagnostics)
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		const parallel = 16
+
+		// when
+		var group sync.WaitGroup
+		for range parallel {
+			group.Go(func() {
+				var requestDiagnostics diag.Diagnostics
+				_, requestOK := it.performRequest(context.Background(), model, &requestDiagnostics)
+				assert.True(t, requestOK, "diagnostics: %v", requestDiagnostics)
+			})
+		}
+		group.Wait()
+
+		// then
+		assert.Equal(t, 1, log.challenges)
+		require.Len(t, log.nonceCounts, parallel+1)
+		seen := map[string]struct{}{}
+		for _, nonceCount := range log.nonceCounts {
+			seen[nonceCount] = struct{}{}
+		}
+		assert.Len(t, seen, parallel+1, "nonce counts: %v", log.nonceCounts)
+	})
+
+	t.Run("should send the body again when answering the challenge", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var log digestLog
+		server := digestServer(t, entities.DigestAlgorithmMD5, &log)
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Method = types.StringValue(http.MethodPost)
+		model.RequestBody = types.StringValue(`{"name":"switch-01"}`)
+		model.DigestAuth = digestAuthObject("Mufasa", "Circle of Life")
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, []string{`{"name":"switch-01"}`}, log.bodies)
+	})
+
+	t.Run("should answer with the provider credentials when the resource has none", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var log digestLog
+		server := digestServer(t, entities.DigestAlgorithmMD5, &log)
+		it := resourceWithProviderAuth(nil, nil, nil)
+		it.internal.Config.DigestAuth = &entities.DigestAuth{Username: "Mufasa", Password: "Circle of Life"}
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, exchange.statusCode)
+	})
+}
+
+func TestResolveDigestAuth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should leave the provider credentials out when the resource brings a bearer token", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(nil, nil, nil)
+		it.internal.Config.DigestAuth = &entities.DigestAuth{Username: "admin", Password: "secret"}
+		model := requestModel(types.MapNull(types.StringType))
+		model.BearerAuth = bearerAuthObject("resource-token")
+
+		// when
+		credentials := it.resolveDigestAuth(model)
+
+		// then
+		assert.Nil(t, credentials)
+	})
+
+	t.Run("should leave the provider credentials out when the provider sends a bearer token", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(nil, &entities.BearerAuth{Token: "provider-token"}, nil)
+		it.internal.Config.DigestAuth = &entities.DigestAuth{Username: "admin", Password: "secret"}
+		model := requestModel(types.MapNull(types.StringType))
+
+		// when
+		credentials := it.resolveDigestAuth(model)
+
+		// then
+		assert.Nil(t, credentials)
+	})
+}
+
+func TestValidateDigestAuth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject digest and basic auth on the same resource", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		credentialsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			attrUsername: tftypes.String,
+			attrPassword: tftypes.String,
+		}}
+		credentials := tftypes.NewValue(credentialsType, map[string]tftypes.Value{
+			attrUsername: tftypes.NewValue(tftypes.String, "user"),
+			attrPassword: tftypes.NewValue(tftypes.String, "pass"),
+		})
+		config := resourceConfigWith(t, map[string]tftypes.Value{
+			attrBasicAuth:  credentials,
+			attrDigestAuth: credentials,
+		})
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateAuthentication(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		// then
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Conflicting authentication", resp.Diagnostics[0].Summary())
+	})
+}
@@ -0,0 +1,417 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ohler55/ojg/jp"
+)
+
+// driftDetectionObjectAttrTypes returns the attribute types of the `drift_detection` nested
+// object. Like waitForObjectAttrTypes, it MUST be used wherever a typed null value is produced.
+func driftDetectionObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrJSONPaths:         types.ListType{ElemType: types.StringType},
+		attrResponseJSONPaths: types.MapType{ElemType: types.StringType},
+	}
+}
+
+// resourceDriftDetectionBlock returns the `drift_detection` block. It is stored in state because
+// the comparison happens in Read, which receives no configuration.
+func resourceDriftDetectionBlock() schema.SingleNestedBlock {
+	description := "Compares selected fields of the refresh response with the same fields of " +
+		"`request_body`. A field that differs is written back into `request_body` with its remote value " +
+		"and reported as a warning, so the next plan shows a change of `request_body` that re-sends the " +
+		"desired value -- an in-place update when `update_method` is set. Requires `is_refresh_enabled`."
+
+	return schema.SingleNestedBlock{
+		Description:         description,
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			attrJSONPaths: schema.ListAttribute{
+				Description: "JSONPath expressions selecting the fields of `request_body` to reconcile " +
+					"(e.g. [\"$.name\", \"$.spec.size\"]). Required when the block is set.",
+				MarkdownDescription: "JSONPath expressions selecting the fields of `request_body` to reconcile " +
+					"(e.g. `[\"$.name\", \"$.spec.size\"]`). Required when the block is set.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			attrResponseJSONPaths: schema.MapAttribute{
+				Description: "Where the refresh response holds a field listed in `json_paths`, when it is not " +
+					"at the same path, keyed by the `json_paths` entry (e.g. { \"$.name\" = \"$.data.name\" }).",
+				MarkdownDescription: "Where the refresh response holds a field listed in `json_paths`, when it is not " +
+					"at the same path, keyed by the `json_paths` entry (e.g. `{ \"$.name\" = \"$.data.name\" }`).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// driftDetectionConfig is the resolved `drift_detection` block.
+type driftDetectionConfig struct {
+	requestPaths  []string
+	responsePaths map[string]string
+}
+
+// driftDetectionConfigFromObject converts a `drift_detection` nested object into its resolved
+// form. It returns nil when the object is null or unknown, meaning "do not compare".
+func driftDetectionConfigFromObject(
+	ctx context.Context,
+	obj types.Object,
+	diagnostics *diag.Diagnostics,
+) *driftDetectionConfig {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	cfg := &driftDetectionConfig{}
+	attrs := obj.Attributes()
+	if list, ok := attrs[attrJSONPaths].(types.List); ok && !list.IsNull() && !list.IsUnknown() {
+		diagnostics.Append(list.ElementsAs(ctx, &cfg.requestPaths, false)...)
+	}
+	if mapping, ok := attrs[attrResponseJSONPaths].(types.Map); ok {
+		cfg.responsePaths = stringMapOf(ctx, mapping, diagnostics)
+	}
+
+	return cfg
+}
+
+// validateDriftDetection checks the `drift_detection` block once it is set: it only works on top
+// of refresh, needs at least one field, and every expression must parse.
+func validateDriftDetection(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var block types.Object
+	var isRefreshEnabled types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrDriftDetection), &block)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrIsRefreshEnabled), &isRefreshEnabled)...)
+	if resp.Diagnostics.HasError() || block.IsNull() || block.IsUnknown() {
+		return
+	}
+
+	if !isRefreshEnabled.IsUnknown() && !isBoolTrue(isRefreshEnabled) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrDriftDetection),
+			"drift_detection requires is_refresh_enabled",
+			"Drift is detected while refreshing, so `is_refresh_enabled` must be true.",
+		)
+	}
+
+	if list, ok := block.Attributes()[attrJSONPaths].(types.List); ok && !list.IsUnknown() &&
+		(list.IsNull() || len(list.Elements()) == 0) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrDriftDetection).AtName(attrJSONPaths),
+			"Missing drift_detection argument",
+			"`json_paths` must list at least one field when the `drift_detection` block is set.",
+		)
+	}
+
+	cfg := driftDetectionConfigFromObject(ctx, block, &resp.Diagnostics)
+	if cfg == nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, expression := range cfg.requestPaths {
+		if _, err := jp.ParseString(expression); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attrDriftDetection).AtName(attrJSONPaths),
+				"Invalid JSONPath expression",
+				fmt.Sprintf("%q could not be parsed: %v", expression, err),
+			)
+		}
+	}
+
+	for requestPath, responsePath := range cfg.responsePaths {
+		if !slices.Contains(cfg.requestPaths, requestPath) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attrDriftDetection).AtName(attrResponseJSONPaths),
+				"Unknown drift_detection field",
+				fmt.Sprintf("%q is not listed in `json_paths`.", requestPath),
+			)
+		}
+
+		if _, err := jp.ParseString(responsePath); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attrDriftDetection).AtName(attrResponseJSONPaths),
+				"Invalid JSONPath expression",
+				fmt.Sprintf("%q could not be parsed: %v", responsePath, err),
+			)
+		}
+	}
+}
+
+// reconcileRequestBody compares the configured fields of `request_body` with the refresh response
+// and writes every remote value that differs back into `request_body`, so the next plan proposes
+// to send the desired one again. The drift is reported as a warning naming each field.
+//
+// Only the drifted values are replaced in the text the user wrote: re-encoding the whole document
+// would reorder its keys, and numbers are kept as their literals, as a float64 corrupts integers
+// above 2^53.
+func reconcileRequestBody(
+	ctx context.Context,
+	model *HTTPRequestResourceModel,
+	responseBody []byte,
+	diagnostics *diag.Diagnostics,
+) {
+	cfg := driftDetectionConfigFromObject(ctx, model.DriftDetection, diagnostics)
+	if cfg == nil || diagnostics.HasError() || !isNonEmptyString(model.RequestBody) {
+		return
+	}
+
+	body := []byte(model.RequestBody.ValueString())
+	desired, err := decodeExactJSON(body)
+	if err != nil {
+		diagnostics.AddWarning("Drift detection skipped",
+			fmt.Sprintf("`request_body` is not JSON, so its fields cannot be compared: %v", err))
+
+		return
+	}
+	remote, err := decodeExactJSON(responseBody)
+	if err != nil {
+		diagnostics.AddWarning("Drift detection skipped",
+			fmt.Sprintf("The refresh response is not JSON, so its fields cannot be compared: %v", err))
+
+		return
+	}
+
+	var drifted []string
+	for _, requestPath := range cfg.requestPaths {
+		responsePath := requestPath
+		if mapped, ok := cfg.responsePaths[requestPath]; ok {
+			responsePath = mapped
+		}
+
+		requestExpr, err := jp.ParseString(requestPath)
+		if err != nil {
+			diagnostics.AddError("Invalid JSONPath expression in drift_detection", fmt.Sprintf("%q: %v", requestPath, err))
+
+			return
+		}
+		responseExpr, err := jp.ParseString(responsePath)
+		if err != nil {
+			diagnostics.AddError("Invalid JSONPath expression in drift_detection", fmt.Sprintf("%q: %v", responsePath, err))
+
+			return
+		}
+
+		desiredValue, inRequest := requestExpr.FirstFound(desired)
+		remoteValue, inResponse := responseExpr.FirstFound(remote)
+		if !inRequest || !inResponse {
+			tflog.Debug(ctx, "Skipping a drift detection field missing from one side...", map[string]any{
+				"field": requestPath, "in_request": inRequest, "in_response": inResponse,
+			})
+
+			continue
+		}
+
+		if sameJSONValue(desiredValue, remoteValue) {
+			continue
+		}
+
+		locations := requestExpr.Locate(desired, 1)
+		if len(locations) == 0 {
+			continue
+		}
+		if body, err = patchJSONValue(body, locations[0], remoteValue); err != nil {
+			diagnostics.AddError("Unable to record drift", fmt.Sprintf("%s: %v", requestPath, err))
+
+			return
+		}
+		drifted = append(drifted, fmt.Sprintf("%s: sent %s, remote has %s",
+			requestPath, renderJSON(desiredValue), renderJSON(remoteValue)))
+	}
+
+	if len(drifted) == 0 {
+		return
+	}
+
+	model.RequestBody = types.StringValue(string(body))
+	diagnostics.AddWarning(
+		"Drift detected in request_body",
+		"The remote object no longer matches the request that created it:\n"+strings.Join(drifted, "\n"),
+	)
+}
+
+// renderJSON renders a decoded JSON value back to its JSON text for a diagnostic.
+func renderJSON(value any) string {
+	encoded, err := encodeJSON(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(encoded)
+}
+
+// decodeExactJSON decodes a JSON document keeping its numbers as json.Number literals.
+func decodeExactJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON document")
+	}
+
+	return value, nil
+}
+
+// encodeJSON encodes a decoded JSON value without escaping `<`, `>` and `&` for HTML.
+func encodeJSON(value any) ([]byte, error) {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return bytes.TrimSuffix(encoded.Bytes(), []byte("\n")), nil
+}
+
+// sameJSONValue reports whether two values decoded by decodeExactJSON are equal, comparing numbers
+// by value so that `2` and `2.0` do not count as drift.
+func sameJSONValue(left, right any) bool {
+	switch typed := left.(type) {
+	case json.Number:
+		other, ok := right.(json.Number)
+		if !ok {
+			return false
+		}
+		leftValue, leftOK := new(big.Rat).SetString(typed.String())
+		rightValue, rightOK := new(big.Rat).SetString(other.String())
+
+		return leftOK && rightOK && leftValue.Cmp(rightValue) == 0
+	case map[string]any:
+		other, ok := right.(map[string]any)
+		if !ok || len(typed) != len(other) {
+			return false
+		}
+		for key, value := range typed {
+			otherValue, found := other[key]
+			if !found || !sameJSONValue(value, otherValue) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		other, ok := right.([]any)
+		if !ok || len(typed) != len(other) {
+			return false
+		}
+		for index := range typed {
+			if !sameJSONValue(typed[index], other[index]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(left, right)
+	}
+}
+
+// patchJSONValue replaces the value at location, a normalized path of child names and indexes,
+// in the JSON document, leaving every other byte of it as it is.
+func patchJSONValue(document []byte, location jp.Expr, value any) ([]byte, error) {
+	start, end, err := jsonValueSpan(document, location)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := encodeJSON(value)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := make([]byte, 0, len(document)-(end-start)+len(encoded))
+	patched = append(patched, document[:start]...)
+	patched = append(patched, encoded...)
+
+	return append(patched, document[end:]...), nil
+}
+
+// jsonValueSpan returns the byte offsets of the value at location in the JSON document. Where an
+// object repeats a key, the last one is the value, as it is when decoding.
+func jsonValueSpan(document []byte, location jp.Expr) (int, int, error) {
+	for len(location) > 0 {
+		if _, ok := location[0].(jp.Root); !ok {
+			break
+		}
+		location = location[1:]
+	}
+	start := len(document) - len(bytes.TrimLeft(document, " \t\r\n"))
+	end := len(bytes.TrimRight(document, " \t\r\n"))
+	if len(location) == 0 {
+		return start, end, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	opening, err := decoder.Token()
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w", err)
+	}
+
+	found := false
+	for index := 0; decoder.More(); index++ {
+		var matches bool
+		switch fragment := location[0].(type) {
+		case jp.Child:
+			if opening != json.Delim('{') {
+				return 0, 0, fmt.Errorf("%q is not in an object", string(fragment))
+			}
+			key, keyErr := decoder.Token()
+			if keyErr != nil {
+				return 0, 0, fmt.Errorf("%w", keyErr)
+			}
+			matches = key == string(fragment)
+		case jp.Nth:
+			if opening != json.Delim('[') {
+				return 0, 0, fmt.Errorf("[%d] is not in an array", int(fragment))
+			}
+			matches = index == int(fragment)
+		default:
+			return 0, 0, fmt.Errorf("unsupported path fragment %v", fragment)
+		}
+
+		// a value never starts with the separators and blanks that precede it
+		valueStart := int(decoder.InputOffset())
+		valueStart += len(document[valueStart:]) - len(bytes.TrimLeft(document[valueStart:], ":, \t\r\n"))
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			return 0, 0, fmt.Errorf("%w", err)
+		}
+		if matches {
+			start, end, found = valueStart, int(decoder.InputOffset()), true
+		}
+	}
+	if !found {
+		return 0, 0, fmt.Errorf("%v is not in the document", location[0])
+	}
+
+	innerStart, innerEnd, err := jsonValueSpan(document[start:end], location[1:])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start + innerStart, start + innerEnd, nil
+}
@@ -0,0 +1,191 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// driftDetectionObject builds a `drift_detection` block comparing the given request paths, with
+// the optional response path mapping.
+func driftDetectionObject(requestPaths []string, responsePaths map[string]string) types.Object {
+	paths := make([]attr.Value, 0, len(requestPaths))
+	for _, requestPath := range requestPaths {
+		paths = append(paths, types.StringValue(requestPath))
+	}
+	mapping := types.MapNull(types.StringType)
+	if responsePaths != nil {
+		values := make(map[string]attr.Value, len(responsePaths))
+		for requestPath, responsePath := range responsePaths {
+			values[requestPath] = types.StringValue(responsePath)
+		}
+		mapping = types.MapValueMust(types.StringType, values)
+	}
+
+	return types.ObjectValueMust(driftDetectionObjectAttrTypes(), map[string]attr.Value{
+		attrJSONPaths:         types.ListValueMust(types.StringType, paths),
+		attrResponseJSONPaths: mapping,
+	})
+}
+
+func TestReconcileRequestBody(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write the remote value of a drifted field back into request_body", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := requestModel(types.MapNull(types.StringType))
+		model.RequestBody = types.StringValue(`{"name":"desired","size":2,"note":"untracked"}`)
+		model.DriftDetection = driftDetectionObject([]string{"$.name", "$.size"}, nil)
+		var diagnostics diag.Diagnostics
+
+		// when
+		reconcileRequestBody(context.Background(), &model,
+			[]byte(`{"id":7,"name":"changed","size":2,"note":"other"}`), &diagnostics)
+
+		// then
+		assert.JSONEq(t, `{"name":"changed","size":2,"note":"untracked"}`, model.RequestBody.ValueString(),
+			"only the tracked field that differs takes the remote value")
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Drift detected in request_body", diagnostics[0].Summary())
+		assert.Contains(t, diagnostics[0].Detail(), `$.name: sent "desired", remote has "changed"`)
+	})
+
+	t.Run("should read a field from its mapped response path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := requestModel(types.MapNull(types.StringType))
+		model.RequestBody = types.StringValue(`{"name":"desired"}`)
+		model.DriftDetection = driftDetectionObject([]string{"$.name"}, map[string]string{"$.name": "$.data.name"})
+		var diagnostics diag.Diagnostics
+
+		// when
+		reconcileRequestBody(context.Background(), &model, []byte(`{"data":{"name":"changed"}}`), &diagnostics)
+
+		// then
+		assert.JSONEq(t, `{"name":"changed"}`, model.RequestBody.ValueString())
+	})
+
+	t.Run("should replace only the drifted value in the body as written", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := requestModel(types.MapNull(types.StringType))
+		model.RequestBody = types.StringValue(
+			`{ "owner_id": 9007199254740993, "limit": 1e21, "rule": "a<b && c>d", "name": "desired" }`,
+		)
+		model.DriftDetection = driftDetectionObject([]string{"$.name", "$.owner_id", "$.limit"}, nil)
+		var diagnostics diag.Diagnostics
+
+		// when
+		reconcileRequestBody(context.Background(), &model,
+			[]byte(`{"owner_id":9007199254740993,"limit":1000000000000000000000,"name":"changed"}`), &diagnostics)
+
+		// then
+		assert.Equal(t,
+			`{ "owner_id": 9007199254740993, "limit": 1e21, "rule": "a<b && c>d", "name": "changed" }`,
+			model.RequestBody.ValueString(), "the integers, the keys and the rule stay as written")
+		require.Len(t, diagnostics, 1)
+		assert.NotContains(t, diagnostics[0].Detail(), "owner_id")
+		assert.NotContains(t, diagnostics[0].Detail(), "limit")
+	})
+
+	t.Run("should write a drifted number back as the literal the remote sent", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := requestModel(types.MapNull(types.StringType))
+		model.RequestBody = types.StringValue(`{"quota":{"owner_id":1},"tags":["a","b"]}`)
+		model.DriftDetection = driftDetectionObject([]string{"$.quota.owner_id", "$.tags[1]"}, nil)
+		var diagnostics diag.Diagnostics
+
+		// when
+		reconcileRequestBody(context.Background(), &model,
+			[]byte(`{"quota":{"owner_id":9007199254740993},"tags":["a","<c>"]}`), &diagnostics)
+
+		// then
+		assert.Equal(t, `{"quota":{"owner_id":9007199254740993},"tags":["a","<c>"]}`, model.RequestBody.ValueString())
+		require.Len(t, diagnostics, 1)
+		assert.Contains(t, diagnostics[0].Detail(), "$.quota.owner_id: sent 1, remote has 9007199254740993")
+	})
+
+	t.Run("should leave request_body untouched when nothing drifted", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		original := `{ "name": "desired" }`
+		model := requestModel(types.MapNull(types.StringType))
+		model.RequestBody = types.StringValue(original)
+		model.DriftDetection = driftDetectionObject([]string{"$.name", "$.missing"}, nil)
+		var diagnostics diag.Diagnostics
+
+		// when
+		reconcileRequestBody(context.Background(), &model, []byte(`{"name":"desired"}`), &diagnostics)
+
+		// then
+		assert.Equal(t, original, model.RequestBody.ValueString(), "the body is not even re-encoded")
+		assert.Empty(t, diagnostics)
+	})
+}
+
+func TestValidateDriftDetection(t *testing.T) {
+	t.Parallel()
+
+	blockType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		attrJSONPaths:         tftypes.List{ElementType: tftypes.String},
+		attrResponseJSONPaths: tftypes.Map{ElementType: tftypes.String},
+	}}
+	block := func(mapping map[string]tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(blockType, map[string]tftypes.Value{
+			attrJSONPaths: tftypes.NewValue(tftypes.List{ElementType: tftypes.String},
+				[]tftypes.Value{tftypes.NewValue(tftypes.String, "$.name")}),
+			attrResponseJSONPaths: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, mapping),
+		})
+	}
+
+	t.Run("should require refresh to be enabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		config := resourceConfigWith(t, map[string]tftypes.Value{attrDriftDetection: block(nil)})
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateDriftDetection(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		// then
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "drift_detection requires is_refresh_enabled", resp.Diagnostics[0].Summary())
+	})
+
+	t.Run("should reject a response path for a field that is not compared", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		config := resourceConfigWith(t, map[string]tftypes.Value{
+			attrIsRefreshEnabled: tftypes.NewValue(tftypes.Bool, true),
+			attrDriftDetection: block(map[string]tftypes.Value{
+				"$.size": tftypes.NewValue(tftypes.String, "$.data.size"),
+			}),
+		})
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateDriftDetection(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		// then
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Unknown drift_detection field", resp.Diagnostics[0].Summary())
+	})
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+const attrEndpoint = "endpoint"
+
+// Descriptions of the provider `endpoint` block and of the `endpoint` argument that selects one.
+const (
+	descEndpointBlock = "A named profile for one of the services this provider talks to, with its own base " +
+		"URL, credentials, headers, TLS verification, timeout and retries. A request that selects it with " +
+		"`endpoint` uses them instead of the provider-level ones, so a dozen services need neither a " +
+		"provider alias each nor the same arguments repeated on every resource. The block can be repeated, " +
+		"once per service."
+	descEndpointName = "The name a request selects the endpoint with. It must be unique within the provider."
+	descEndpointURL  = "The base URL of the requests to this endpoint, in place of the provider `url`." +
+		descUnixSocketURL
+	descEndpointBasicAuth = "Credentials for basic authentication sent to this endpoint. They take " +
+		"precedence over every provider-level authentication scheme, and a request's own `basic_auth`, " +
+		"`bearer_auth` or `digest_auth` overrides them."
+	descEndpointHeaders = "Headers sent on every request to this endpoint, after the provider-level " +
+		"`headers` and before the request's own, so each level overrides the one before it."
+	descEndpointIgnoreTLS = "Whether TLS certificate verification is skipped for this endpoint, in place " +
+		"of the provider-level `ignore_tls`."
+	descEndpointRequestTimeoutMs = "The per-request timeout in milliseconds of this endpoint, in place of " +
+		"the provider-level `request_timeout_ms`."
+	descEndpointRetry = "Retry configuration of this endpoint, in place of the provider-level `retry` " +
+		"block. A request's own `retry` block overrides it."
+	descEndpoint = "The name of a provider `endpoint` whose base URL, credentials, headers, TLS verification, " +
+		"timeout and retries this request uses. Every argument the request sets itself still wins over the " +
+		"endpoint's."
+)
+
+// endpointModel mirrors one `endpoint` block of the provider.
+type endpointModel struct {
+	Name             types.String `tfsdk:"name"`
+	URL              types.String `tfsdk:"url"`
+	BasicAuth        types.Object `tfsdk:"basic_auth"`
+	Headers          types.Map    `tfsdk:"headers"`
+	IgnoreTLS        types.Bool   `tfsdk:"ignore_tls"`
+	RequestTimeoutMs types.Int64  `tfsdk:"request_timeout_ms"`
+	Retry            types.Object `tfsdk:"retry"`
+}
+
+// endpointBlock builds the repeatable `endpoint` block of the provider schema. Terraform offers
+// providers no labelled blocks, so the name is an argument of the block.
+func endpointBlock() schema.ListNestedBlock {
+	retry := retryBlock()
+	retry.Description = descEndpointRetry
+	retry.MarkdownDescription = descEndpointRetry
+
+	return schema.ListNestedBlock{
+		Description:         descEndpointBlock,
+		MarkdownDescription: descEndpointBlock,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				attrName: schema.StringAttribute{
+					Description:         descEndpointName,
+					MarkdownDescription: descEndpointName,
+					Required:            true,
+				},
+				"url": providerOptionalString(descEndpointURL, false),
+				attrBasicAuth: schema.SingleNestedAttribute{
+					Description:         descEndpointBasicAuth,
+					MarkdownDescription: descEndpointBasicAuth,
+					Optional:            true,
+					Attributes: map[string]schema.Attribute{
+						attrUsername: schema.StringAttribute{
+							Description:         "The username for basic authentication.",
+							MarkdownDescription: "The username for basic authentication.",
+							Required:            true,
+						},
+						attrPassword: schema.StringAttribute{
+							Description:         "The password for basic authentication.",
+							MarkdownDescription: "The password for basic authentication.",
+							Required:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+				attrHeaders: schema.MapAttribute{
+					Description:         descEndpointHeaders,
+					MarkdownDescription: descEndpointHeaders,
+					Optional:            true,
+					// sensitive for the reason the provider-level headers are
+					Sensitive:   true,
+					ElementType: types.StringType,
+				},
+				attrIgnoreTLS:        providerOptionalBool(descEndpointIgnoreTLS),
+				attrRequestTimeoutMs: providerOptionalInt64(descEndpointRequestTimeoutMs),
+			},
+			Blocks: map[string]schema.Block{
+				attrRetry: retry,
+			},
+		},
+	}
+}
+
+// checkEndpointArguments reports an `endpoint` block without a name or with the name of another,
+// and checks the `retry` block of each. Unknown values pass.
+func checkEndpointArguments(ctx context.Context, model HTTPProviderModel, diagnostics *diag.Diagnostics) {
+	if model.Endpoints.IsNull() || model.Endpoints.IsUnknown() {
+		return
+	}
+
+	var endpoints []endpointModel
+	diagnostics.Append(model.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	seen := make(map[string]struct{}, len(endpoints))
+	for index, endpoint := range endpoints {
+		at := path.Root(attrEndpoint).AtListIndex(index)
+		checkRetryArgumentsAt(ctx, at.AtName(attrRetry), endpoint.Retry, diagnostics)
+		if endpoint.Name.IsUnknown() {
+			continue
+		}
+
+		name := endpoint.Name.ValueString()
+		if name == "" {
+			diagnostics.AddAttributeError(at.AtName(attrName), "Invalid endpoint",
+				"`name` must not be empty, as requests select the endpoint by it.")
+
+			continue
+		}
+		if _, duplicate := seen[name]; duplicate {
+			diagnostics.AddAttributeError(at.AtName(attrName), "Invalid endpoint",
+				fmt.Sprintf("Another endpoint is already named %q.", name))
+		}
+		seen[name] = struct{}{}
+	}
+}
+
+// providerEndpoints converts the `endpoint` blocks into the profiles of the configuration, keyed by
+// name. It returns nil when there are none.
+func providerEndpoints(
+	ctx context.Context, list types.List, diagnostics *diag.Diagnostics,
+) map[string]*entities.Endpoint {
+	if list.IsNull() || list.IsUnknown() || len(list.Elements()) == 0 {
+		return nil
+	}
+
+	var endpoints []endpointModel
+	diagnostics.Append(list.ElementsAs(ctx, &endpoints, false)...)
+	profiles := make(map[string]*entities.Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		profile := &entities.Endpoint{
+			URL:     endpoint.URL.ValueString(),
+			Headers: stringMapOf(ctx, endpoint.Headers, diagnostics),
+			Retry:   retryConfigFromObject(endpoint.Retry),
+		}
+		if !endpoint.BasicAuth.IsNull() && !endpoint.BasicAuth.IsUnknown() {
+			profile.BasicAuth = &entities.BasicAuth{
+				Username: objectStringOf(endpoint.BasicAuth, attrUsername),
+				Password: objectStringOf(endpoint.BasicAuth, attrPassword),
+			}
+		}
+		if !endpoint.IgnoreTLS.IsNull() && !endpoint.IgnoreTLS.IsUnknown() {
+			ignoreTLS := endpoint.IgnoreTLS.ValueBool()
+			profile.IgnoreTLS = &ignoreTLS
+		}
+		if !endpoint.RequestTimeoutMs.IsNull() && !endpoint.RequestTimeoutMs.IsUnknown() {
+			timeout := endpoint.RequestTimeoutMs.ValueInt64()
+			profile.RequestTimeoutMs = &timeout
+		}
+		profiles[endpoint.Name.ValueString()] = profile
+	}
+
+	return profiles
+}
+
+// endpoint returns the provider `endpoint` the request selects, or nil when it selects none or one
+// the provider does not have, which checkEndpoint reports.
+func (it *HTTPRequestResource) endpoint(model HTTPRequestResourceModel) *entities.Endpoint {
+	if !isNonEmptyString(model.Endpoint) {
+		return nil
+	}
+
+	return it.providerConfig().Endpoint(model.Endpoint.ValueString())
+}
+
+// checkEndpoint reports a request that selects an endpoint the provider does not have.
+func (it *HTTPRequestResource) checkEndpoint(model HTTPRequestResourceModel, diagnostics *diag.Diagnostics) {
+	if !isNonEmptyString(model.Endpoint) || it.endpoint(model) != nil {
+		return
+	}
+
+	diagnostics.AddAttributeError(
+		path.Root(attrEndpoint),
+		"Unknown endpoint",
+		fmt.Sprintf("The provider has no `endpoint` block named %q.", model.Endpoint.ValueString()),
+	)
+}
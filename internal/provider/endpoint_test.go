@@ -0,0 +1,274 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// authEcho answers every request with its Authorization and X-Tenant headers.
+func authEcho(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization") + "|" + r.Header.Get("X-Tenant")))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// resourceWithEndpoint returns a resource whose provider has the single endpoint `billing`, and
+// the model of a request that selects it.
+func resourceWithEndpoint(endpoint *entities.Endpoint) (*HTTPRequestResource, HTTPRequestResourceModel) {
+	it := resourceWithProviderAuth(nil, &entities.BearerAuth{Token: "provider-token"}, nil)
+	it.internal.Config.Headers = map[string]string{"X-Tenant": "provider"}
+	it.internal.Config.Endpoints = map[string]*entities.Endpoint{"billing": endpoint}
+	model := pollingModel("", types.ObjectNull(waitForObjectAttrTypes()))
+	model.BaseURL = types.StringNull()
+	model.Endpoint = types.StringValue("billing")
+
+	return it, model
+}
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send the request with the url, credentials and headers of the endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := authEcho(t)
+		it, model := resourceWithEndpoint(&entities.Endpoint{
+			URL:       server.URL,
+			BasicAuth: &entities.BasicAuth{Username: "billing", Password: "secret"},
+			Headers:   map[string]string{"X-Tenant": "billing"},
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "Basic YmlsbGluZzpzZWNyZXQ=|billing", string(exchange.body))
+	})
+
+	t.Run("should let the arguments of the resource win over those of the endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := authEcho(t)
+		it, model := resourceWithEndpoint(&entities.Endpoint{
+			URL:       "https://billing.example.test",
+			BasicAuth: &entities.BasicAuth{Username: "billing", Password: "secret"},
+			Headers:   map[string]string{"X-Tenant": "billing"},
+		})
+		model.BaseURL = types.StringValue(server.URL)
+		model.BearerAuth = bearerAuthObject("resource-token")
+		model.Headers = resourceHeaderMap(t, map[string]string{"X-Tenant": "resource"})
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "Bearer resource-token|resource", string(exchange.body))
+	})
+
+	t.Run("should fall back to the provider for what the endpoint leaves unset", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := authEcho(t)
+		it, model := resourceWithEndpoint(&entities.Endpoint{URL: server.URL})
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "Bearer provider-token|provider", string(exchange.body))
+	})
+
+	t.Run("should retry with the retry configuration of the endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var hits int64
+		server := sequenceServer(t, &hits, []int{http.StatusServiceUnavailable, http.StatusOK}, []string{"", "ok"})
+		it, model := resourceWithEndpoint(&entities.Endpoint{
+			URL:   server.URL,
+			Retry: &entities.RetryConfig{Attempts: 1, MinDelayMs: 1, MaxDelayMs: 1},
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, exchange.statusCode)
+		assert.Equal(t, int64(2), atomic.LoadInt64(&hits))
+	})
+
+	t.Run("should give up after the timeout of the endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(release) })
+		timeout := int64(20)
+		it, model := resourceWithEndpoint(&entities.Endpoint{URL: server.URL, RequestTimeoutMs: &timeout})
+		var diagnostics diag.Diagnostics
+
+		// when
+		started := time.Now()
+		_, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.False(t, ok)
+		assert.Less(t, time.Since(started), 5*time.Second)
+	})
+
+	t.Run("should skip TLS verification for an endpoint that ignores it", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		t.Cleanup(server.Close)
+		ignoreTLS := true
+		it, model := resourceWithEndpoint(&entities.Endpoint{URL: server.URL, IgnoreTLS: &ignoreTLS})
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "ok", string(exchange.body))
+	})
+
+	t.Run("should report an endpoint the provider does not have", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it, model := resourceWithEndpoint(&entities.Endpoint{URL: "https://billing.example.test"})
+		model.Endpoint = types.StringValue("invoicing")
+
+		// when
+		_, diagnostics := it.buildFullURL(context.Background(), model)
+
+		// then
+		require.True(t, diagnostics.HasError())
+		assert.Equal(t, "Unknown endpoint", diagnostics[0].Summary())
+	})
+}
+
+func TestProviderEndpoints(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should convert every endpoint block by name", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		retryTypes := retryObjectAttrTypes()
+		retryValues := map[string]attr.Value{}
+		for name, attrType := range retryTypes {
+			retryValues[name] = newNullValue(attrType)
+		}
+		retryValues[attrAttempts] = types.Int64Value(3)
+		basicAuthTypes := map[string]attr.Type{attrUsername: types.StringType, attrPassword: types.StringType}
+		endpointTypes := map[string]attr.Type{
+			attrName:             types.StringType,
+			"url":                types.StringType,
+			attrBasicAuth:        types.ObjectType{AttrTypes: basicAuthTypes},
+			attrHeaders:          types.MapType{ElemType: types.StringType},
+			attrIgnoreTLS:        types.BoolType,
+			attrRequestTimeoutMs: types.Int64Type,
+			attrRetry:            types.ObjectType{AttrTypes: retryTypes},
+		}
+		list := types.ListValueMust(types.ObjectType{AttrTypes: endpointTypes}, []attr.Value{
+			types.ObjectValueMust(endpointTypes, map[string]attr.Value{
+				attrName: types.StringValue("billing"),
+				"url":    types.StringValue("https://billing.example.test"),
+				attrBasicAuth: types.ObjectValueMust(basicAuthTypes, map[string]attr.Value{
+					attrUsername: types.StringValue("billing"),
+					attrPassword: types.StringValue("secret"),
+				}),
+				attrHeaders:          types.MapNull(types.StringType),
+				attrIgnoreTLS:        types.BoolValue(true),
+				attrRequestTimeoutMs: types.Int64Value(5000),
+				attrRetry:            types.ObjectValueMust(retryTypes, retryValues),
+			}),
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		endpoints := providerEndpoints(context.Background(), list, &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		require.Contains(t, endpoints, "billing")
+		billing := endpoints["billing"]
+		assert.Equal(t, "https://billing.example.test", billing.URL)
+		assert.Equal(t, &entities.BasicAuth{Username: "billing", Password: "secret"}, billing.BasicAuth)
+		assert.True(t, *billing.IgnoreTLS)
+		assert.Equal(t, int64(5000), *billing.RequestTimeoutMs)
+		assert.Equal(t, int64(3), billing.Retry.Attempts)
+	})
+
+	t.Run("should reject two endpoints with the same name", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		endpointType, _ := fullProviderType().AttributeTypes[attrEndpoint].(tftypes.List)
+		objectType, _ := endpointType.ElementType.(tftypes.Object)
+		endpointNamed := func(name string) tftypes.Value {
+			values := map[string]tftypes.Value{}
+			for attribute, attributeType := range objectType.AttributeTypes {
+				values[attribute] = tftypes.NewValue(attributeType, nil)
+			}
+			values[attrName] = tftypes.NewValue(tftypes.String, name)
+
+			return tftypes.NewValue(objectType, values)
+		}
+		values := fullProviderValues(tftypes.NewValue(tftypes.String, nil), nullBasicAuthValue())
+		values[attrEndpoint] = tftypes.NewValue(endpointType, []tftypes.Value{
+			endpointNamed("billing"), endpointNamed("billing"),
+		})
+
+		// when
+		diagnostics := validateConfigOf(tftypes.NewValue(fullProviderType(), values))
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid endpoint", diagnostics[0].Summary())
+		assert.Contains(t, diagnostics[0].Detail(), `already named "billing"`)
+	})
+}
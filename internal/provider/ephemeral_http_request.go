@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// Ensure HTTPRequestEphemeralResource satisfies various ephemeral resource interfaces.
+var (
+	_ ephemeral.EphemeralResource                   = &HTTPRequestEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &HTTPRequestEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &HTTPRequestEphemeralResource{}
+)
+
+// HTTPRequestEphemeralResource defines the ephemeral resource implementation.
+//
+// It sends the same request the data source does, but its result only lives for the duration of a
+// plan or apply: nothing it returns is written to the plan or to state. That makes it the place to
+// fetch a short-lived credential, e.g. a bearer token from a login endpoint, and hand it to a
+// write-only argument or to a provider configuration.
+type HTTPRequestEphemeralResource struct {
+	internal *entities.InternalContext
+}
+
+// HTTPRequestEphemeralResourceModel describes the ephemeral resource data model.
+type HTTPRequestEphemeralResourceModel struct {
+	httpLookupModel
+
+	// result
+	ResponseCode     types.Int32  `tfsdk:"response_code"`
+	ResponseBody     types.String `tfsdk:"response_body"`
+	ResponseBodyID   types.String `tfsdk:"response_body_id"`
+	ResponseBodyJSON types.Map    `tfsdk:"response_body_json"`
+	ResponseHeaders  types.Map    `tfsdk:"response_headers"`
+}
+
+func NewHTTPRequestEphemeralResource() ephemeral.EphemeralResource {
+	return &HTTPRequestEphemeralResource{}
+}
+
+func GetHTTPRequestEphemeralResourceSchema() schema.Schema {
+	description := "Sends an HTTP request whenever Terraform needs its result and exposes the response " +
+		"without ever persisting it to the plan or to state. Meant for short-lived credentials, such as " +
+		"a token obtained from a login endpoint, that feed write-only arguments or provider " +
+		"configuration. It shares the provider's base URL, headers, authentication, timeout and retry " +
+		"configuration with the `http_request` resource."
+
+	return schema.Schema{
+		Description:         description,
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			attrMethod: ephemeralOptionalString(
+				"The HTTP method to be used for the request. Defaults to GET."),
+			attrPath: schema.StringAttribute{
+				Description: "The URL path for the HTTP request. This should be a relative path " +
+					"(e.g., /api/v1/resource).",
+				MarkdownDescription: "The URL path for the HTTP request. This should be a relative path " +
+					"(e.g., /api/v1/resource).",
+				Required: true,
+			},
+			attrHeaders: schema.MapAttribute{
+				Description: "A map of HTTP headers to include in the request. They are applied after the " +
+					"provider `headers`, so a header named in both takes the value given here.",
+				MarkdownDescription: "A map of HTTP headers to include in the request. They are applied after the " +
+					"provider `headers`, so a header named in both takes the value given here.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			attrRequestBody: schema.StringAttribute{
+				Description:         "The body content to be sent with the HTTP request, e.g. the credentials of a login.",
+				MarkdownDescription: "The body content to be sent with the HTTP request, e.g. the credentials of a login.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			attrIsResponseBodyJSON: schema.BoolAttribute{
+				Description:         "A boolean flag indicating whether the response body is expected to be in JSON format.",
+				MarkdownDescription: "A boolean flag indicating whether the response body is expected to be in JSON format.",
+				Optional:            true,
+			},
+			attrResponseBodyIDFilter: ephemeralOptionalString(
+				"A JSONPath filter used to extract a specific value from the JSON response body into " +
+					"`response_body_id` (e.g. \"$.access_token\"). Required when `is_response_body_json` is true."),
+			attrQueryParameters: schema.MapAttribute{
+				Description:         "Optional query parameters to append to the request path",
+				MarkdownDescription: "Optional query parameters to append to the request path",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			attrToleratedStatusCodes: schema.SetAttribute{
+				Description: "HTTP status codes that should be treated as successful in addition to the " +
+					"default 2xx range.",
+				MarkdownDescription: "HTTP status codes that should be treated as successful in addition to the " +
+					"default 2xx range.",
+				Optional:    true,
+				ElementType: types.Int32Type,
+			},
+			attrCaptureResponseHeaders: schema.SetAttribute{
+				Description:         "Names of the response headers to expose in `response_headers`. Nothing is exposed when unset.",
+				MarkdownDescription: "Names of the response headers to expose in `response_headers`. Nothing is exposed when unset.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			attrBaseURL: ephemeralOptionalString(
+				"The base URL for this specific HTTP request. When specified, this overrides the " +
+					"provider-level URL configuration." + descUnixSocketURL),
+			attrEndpoint: ephemeralOptionalString(descEndpoint),
+			attrBasicAuth: schema.SingleNestedAttribute{
+				Description: "Credentials for basic authentication for this specific request. " +
+					"When specified, this overrides the provider-level basic authentication configuration.",
+				MarkdownDescription: "Credentials for basic authentication for this specific request. " +
+					"When specified, this overrides the provider-level basic authentication configuration.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					attrUsername: schema.StringAttribute{
+						Description:         "The username for basic authentication.",
+						MarkdownDescription: "The username for basic authentication.",
+						Required:            true,
+					},
+					attrPassword: schema.StringAttribute{
+						Description:         "The password for basic authentication.",
+						MarkdownDescription: "The password for basic authentication.",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			attrIgnoreTLS: schema.BoolAttribute{
+				Description: "A boolean flag to indicate whether TLS certificate verification should be " +
+					"ignored for this specific request. When specified, this overrides the provider-level " +
+					"ignore_tls configuration.",
+				MarkdownDescription: "A boolean flag to indicate whether TLS certificate verification should be " +
+					"ignored for this specific request. When specified, this overrides the provider-level " +
+					"ignore_tls configuration.",
+				Optional: true,
+			},
+			attrRequestTimeoutMs: schema.Int64Attribute{
+				Description: "The per-request timeout in milliseconds for this specific HTTP request. When " +
+					"specified, this overrides the provider-level request_timeout_ms.",
+				MarkdownDescription: "The per-request timeout in milliseconds for this specific HTTP request. When " +
+					"specified, this overrides the provider-level request_timeout_ms.",
+				Optional: true,
+			},
+			attrResponseCode: schema.Int32Attribute{
+				Description:         "The HTTP status code returned by the server.",
+				MarkdownDescription: "The HTTP status code returned by the server.",
+				Computed:            true,
+			},
+			attrResponseBody: ephemeralSensitiveComputedString(
+				"The raw body content returned by the server."),
+			attrResponseBodyID: ephemeralSensitiveComputedString(
+				"The value extracted from the JSON response body by `response_body_id_filter`. This is " +
+					"only populated if `is_response_body_json` is true."),
+			attrResponseBodyJSON: schema.MapAttribute{
+				Description: "The response body parsed as a Terraform map object. Nested items can be " +
+					"accessed using dot notation (e.g., \"response_body_json[\"nested.item.value\"]\").",
+				MarkdownDescription: "The response body parsed as a Terraform map object. Nested items can be " +
+					"accessed using dot notation (e.g., \"response_body_json[\"nested.item.value\"]\").",
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			attrResponseHeaders: schema.MapAttribute{
+				Description:         "The response headers listed in `capture_response_headers`, keyed by their canonical name.",
+				MarkdownDescription: "The response headers listed in `capture_response_headers`, keyed by their canonical name.",
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			attrRetry: schema.SingleNestedBlock{
+				Description: "Retry configuration for this specific HTTP request. When specified, this " +
+					"overrides the provider-level retry configuration.",
+				MarkdownDescription: "Retry configuration for this specific HTTP request. When specified, this " +
+					"overrides the provider-level retry configuration.",
+				Attributes: map[string]schema.Attribute{
+					attrAttempts:   ephemeralOptionalInt64(descRetryAttempts),
+					attrMinDelayMs: ephemeralOptionalInt64(descRetryMinDelayMs),
+					attrMaxDelayMs: ephemeralOptionalInt64(descRetryMaxDelayMs),
+					attrRetryOnStatusCodes: schema.SetAttribute{
+						Description:         descRetryOnStatusCodes,
+						MarkdownDescription: descRetryOnStatusCodes,
+						Optional:            true,
+						ElementType:         types.Int32Type,
+					},
+					attrRespectRetryAfter:  ephemeralOptionalBool(descRespectRetryAfter),
+					attrJitter:             ephemeralOptionalBool(descJitter),
+					attrRetryNonIdempotent: ephemeralOptionalBool(descRetryNonIdempotent),
+					attrRetryOnBodyMatch:   ephemeralOptionalString(descRetryOnBodyMatch),
+				},
+			},
+		},
+	}
+}
+
+func ephemeralOptionalString(description string) schema.StringAttribute {
+	return schema.StringAttribute{Description: description, MarkdownDescription: description, Optional: true}
+}
+
+func ephemeralOptionalBool(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{Description: description, MarkdownDescription: description, Optional: true}
+}
+
+func ephemeralOptionalInt64(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{Description: description, MarkdownDescription: description, Optional: true}
+}
+
+func ephemeralSensitiveComputedString(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description:         description,
+		MarkdownDescription: description,
+		Computed:            true,
+		Sensitive:           true,
+	}
+}
+
+func (it *HTTPRequestEphemeralResource) Metadata(
+	_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_request"
+}
+
+func (it *HTTPRequestEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = GetHTTPRequestEphemeralResourceSchema()
+}
+
+func (it *HTTPRequestEphemeralResource) ValidateConfig(
+	ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse,
+) {
+	var model HTTPRequestEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkResponseBodyIDFilter(model.IsResponseBodyJSON, model.ResponseBodyIDFilter, &resp.Diagnostics)
+	checkStatusCodes(ctx, attrToleratedStatusCodes, model.ToleratedStatusCodes, &resp.Diagnostics)
+	checkRetryArguments(ctx, model.Retry, &resp.Diagnostics)
+}
+
+func (it *HTTPRequestEphemeralResource) Configure(
+	_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse,
+) {
+	// added a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	internal, ok := req.ProviderData.(*entities.InternalContext)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *InternalContext, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	it.internal = internal
+}
+
+func (it *HTTPRequestEphemeralResource) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	tflog.Info(ctx, "Opening ephemeral HTTP request...")
+
+	var config HTTPRequestEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, ok := (&HTTPRequestResource{internal: it.internal}).lookup(ctx, config.httpLookupModel, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	config.ResponseCode = model.ResponseCode
+	config.ResponseBody = model.ResponseBody
+	config.ResponseBodyID = model.ResponseBodyID
+	config.ResponseBodyJSON = model.ResponseBodyJSON
+	config.ResponseHeaders = model.ResponseHeaders
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+
+	tflog.Info(ctx, "Opened ephemeral HTTP request...", map[string]any{"success": true})
+}
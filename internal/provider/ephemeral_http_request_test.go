@@ -0,0 +1,137 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ephemeralConfigWith builds an ephemeral resource configuration holding the given values, every
+// other attribute being null.
+func ephemeralConfigWith(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ephemeralSchema := GetHTTPRequestEphemeralResourceSchema()
+	objectType, ok := ephemeralSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	require.True(t, ok, "the ephemeral resource schema must describe an object")
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return tfsdk.Config{Raw: tftypes.NewValue(objectType, attributes), Schema: ephemeralSchema}
+}
+
+// openEphemeral runs Open against the given configuration and returns the resulting model.
+func openEphemeral(
+	t *testing.T,
+	config tfsdk.Config,
+) (HTTPRequestEphemeralResourceModel, *ephemeral.OpenResponse) {
+	t.Helper()
+
+	resp := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{
+			Raw:    tftypes.NewValue(config.Raw.Type(), nil),
+			Schema: config.Schema,
+		},
+	}
+	NewHTTPRequestEphemeralResource().Open(context.Background(), ephemeral.OpenRequest{Config: config}, resp)
+
+	var model HTTPRequestEphemeralResourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.Result.Get(context.Background(), &model)...)
+	}
+
+	return model, resp
+}
+
+func TestHTTPRequestEphemeralResource_Open(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should log in and expose the extracted token", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotMethod, gotBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			gotMethod, gotBody = r.Method, string(body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"s3cr3t","expires_in":3600}`))
+		}))
+		t.Cleanup(server.Close)
+		config := ephemeralConfigWith(t, map[string]tftypes.Value{
+			attrBaseURL:              tftypes.NewValue(tftypes.String, server.URL),
+			attrMethod:               tftypes.NewValue(tftypes.String, "post"),
+			attrPath:                 tftypes.NewValue(tftypes.String, "/login"),
+			attrRequestBody:          tftypes.NewValue(tftypes.String, `{"user":"ci"}`),
+			attrIsResponseBodyJSON:   tftypes.NewValue(tftypes.Bool, true),
+			attrResponseBodyIDFilter: tftypes.NewValue(tftypes.String, "$.access_token"),
+		})
+
+		// when
+		model, resp := openEphemeral(t, config)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "the login succeeds: %v", resp.Diagnostics)
+		assert.Equal(t, http.MethodPost, gotMethod)
+		assert.JSONEq(t, `{"user":"ci"}`, gotBody)
+		assert.Equal(t, int32(http.StatusOK), model.ResponseCode.ValueInt32())
+		assert.Equal(t, "s3cr3t", model.ResponseBodyID.ValueString())
+		assert.Equal(t, types.StringValue("3600"), model.ResponseBodyJSON.Elements()["expires_in"])
+	})
+
+	t.Run("should fail when the login is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		t.Cleanup(server.Close)
+		config := ephemeralConfigWith(t, map[string]tftypes.Value{
+			attrBaseURL: tftypes.NewValue(tftypes.String, server.URL),
+			attrMethod:  tftypes.NewValue(tftypes.String, "POST"),
+			attrPath:    tftypes.NewValue(tftypes.String, "/login"),
+		})
+
+		// when
+		_, resp := openEphemeral(t, config)
+
+		// then
+		assert.True(t, resp.Diagnostics.HasError())
+	})
+}
+
+func TestGetHTTPRequestEphemeralResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should mark every attribute that can carry the credential as sensitive", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		attributes := GetHTTPRequestEphemeralResourceSchema().Attributes
+
+		// when / then
+		for _, name := range []string{
+			attrRequestBody, attrHeaders, attrResponseBody, attrResponseBodyID, attrResponseBodyJSON, attrResponseHeaders,
+		} {
+			assert.True(t, attributes[name].IsSensitive(), "%s must be sensitive", name)
+		}
+	})
+}
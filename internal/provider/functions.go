@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ohler55/ojg/jp"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+// Ensure the provider-defined functions satisfy the function interface.
+var (
+	_ function.Function = &JSONPathFunction{}
+	_ function.Function = &JSONPathAllFunction{}
+	_ function.Function = &URLFunction{}
+)
+
+// JSONPathFunction implements `provider::http::jsonpath`, which returns the first value a JSONPath
+// expression selects -- what `response_body_id_filter` records in `response_body_id`.
+type JSONPathFunction struct{}
+
+func NewJSONPathFunction() function.Function {
+	return &JSONPathFunction{}
+}
+
+func (it *JSONPathFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jsonpath"
+}
+
+func (it *JSONPathFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the first value a JSONPath expression selects in a JSON document",
+		MarkdownDescription: "Evaluates a JSONPath expression against a JSON document, such as the " +
+			"`response_body` of an `http_request`, and returns the first value it selects, rendered the " +
+			"way `response_body_id` is: whole numbers never use scientific notation, and objects, arrays " +
+			"and null are returned as JSON. Fails when the expression selects nothing; wrap the call in " +
+			"`try()` to fall back to a default.",
+		Parameters: jsonPathParameters(),
+		Return:     function.StringReturn{},
+	}
+}
+
+func (it *JSONPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var body, expression string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &body, &expression))
+	if resp.Error != nil {
+		return
+	}
+
+	values, funcErr := evaluateJSONPath(body, expression)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	if len(values) == 0 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q selected no value in the document", expression))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, values[0]))
+}
+
+// JSONPathAllFunction implements `provider::http::jsonpath_all`, which returns every value a
+// JSONPath expression selects.
+type JSONPathAllFunction struct{}
+
+func NewJSONPathAllFunction() function.Function {
+	return &JSONPathAllFunction{}
+}
+
+func (it *JSONPathAllFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jsonpath_all"
+}
+
+func (it *JSONPathAllFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Returns every value a JSONPath expression selects in a JSON document",
+		MarkdownDescription: "Evaluates a JSONPath expression against a JSON document and returns every " +
+			"value it selects, in document order, rendered like `provider::http::jsonpath` renders one. " +
+			"Returns an empty list when the expression selects nothing.",
+		Parameters: jsonPathParameters(),
+		Return:     function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (it *JSONPathAllFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var body, expression string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &body, &expression))
+	if resp.Error != nil {
+		return
+	}
+
+	values, funcErr := evaluateJSONPath(body, expression)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, values))
+}
+
+// URLFunction implements `provider::http::url`, which joins a base URL, a path and query
+// parameters exactly like the URL of a request is built.
+type URLFunction struct{}
+
+func NewURLFunction() function.Function {
+	return &URLFunction{}
+}
+
+func (it *URLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "url"
+}
+
+func (it *URLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds a URL the way the provider builds the URL of a request",
+		MarkdownDescription: "Joins `path` to the path of `base` and appends `query` URL-encoded, with " +
+			"the same rules an `http_request` applies to `base_url`, `path` and `query_parameters`: a " +
+			"missing leading slash is added, a query or fragment written in `path` is kept, and query " +
+			"parameters are sorted by name.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base",
+				Description: "The base URL, e.g. \"https://api.example.com/v1\".",
+			},
+			function.StringParameter{
+				Name:        "path",
+				Description: "The path to append to the base URL, e.g. \"/widgets/42\".",
+			},
+			function.MapParameter{
+				Name:           "query",
+				Description:    "The query parameters to append. May be null.",
+				ElementType:    types.StringType,
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (it *URLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base, relativePath string
+	var query map[string]string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &base, &relativePath, &query))
+	if resp.Error != nil {
+		return
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Error parsing base URL: %v", err))
+		return
+	}
+
+	joined, err := joinURL(baseURL, relativePath, query)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Error parsing path: %v", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, joined))
+}
+
+// jsonPathParameters returns the `(body, expression)` parameters shared by both JSONPath functions.
+func jsonPathParameters() []function.Parameter {
+	return []function.Parameter{
+		function.StringParameter{
+			Name:        "body",
+			Description: "The JSON document to evaluate, e.g. the `response_body` of an `http_request`.",
+		},
+		function.StringParameter{
+			Name:        "expression",
+			Description: "The JSONPath expression, e.g. \"$.items[*].id\".",
+		},
+	}
+}
+
+// evaluateJSONPath returns every value the expression selects in the body, rendered as strings.
+func evaluateJSONPath(body, expression string) ([]string, *function.FuncError) {
+	var document any
+	if err := json.Unmarshal([]byte(body), &document); err != nil {
+		return nil, function.NewArgumentFuncError(0, fmt.Sprintf("The body is not valid JSON: %v", err))
+	}
+
+	expr, err := jp.ParseString(expression)
+	if err != nil {
+		return nil, function.NewArgumentFuncError(1, fmt.Sprintf("%q could not be parsed: %v", expression, err))
+	}
+
+	selected := expr.Get(document)
+	values := make([]string, 0, len(selected))
+	for _, value := range selected {
+		switch value.(type) {
+		case map[string]any, []any, nil:
+			encoded, encodeErr := json.Marshal(value)
+			if encodeErr != nil {
+				return nil, function.NewFuncError(encodeErr.Error())
+			}
+			values = append(values, string(encoded))
+		default:
+			values = append(values, helpers.FormatJSONScalar(value))
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,150 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFunction calls a provider-defined function with the given arguments, the way Terraform does.
+func runFunction(fn function.Function, result attr.Value, arguments ...attr.Value) *function.RunResponse {
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	fn.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, resp)
+
+	return resp
+}
+
+func TestJSONPathFunction(t *testing.T) {
+	t.Parallel()
+
+	body := types.StringValue(`{"id":803554429,"owner":{"name":"alice"},"tags":["a","b"],"deleted":null}`)
+
+	t.Run("should return the first selected value rendered like response_body_id", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		resp := runFunction(NewJSONPathFunction(), types.StringUnknown(), body, types.StringValue("$.id"))
+
+		// then
+		require.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue("803554429"), resp.Result.Value(),
+			"a whole number must not be rendered in scientific notation")
+	})
+
+	t.Run("should render objects, arrays and null as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		for expression, expected := range map[string]string{
+			"$.owner":   `{"name":"alice"}`,
+			"$.tags":    `["a","b"]`,
+			"$.deleted": `null`,
+		} {
+			// when
+			resp := runFunction(NewJSONPathFunction(), types.StringUnknown(), body, types.StringValue(expression))
+
+			// then
+			require.Nil(t, resp.Error, expression)
+			assert.Equal(t, types.StringValue(expected), resp.Result.Value(), expression)
+		}
+	})
+
+	t.Run("should fail when the expression selects nothing", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		resp := runFunction(NewJSONPathFunction(), types.StringUnknown(), body, types.StringValue("$.missing"))
+
+		// then
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, int64(1), *resp.Error.FunctionArgument, "the expression is the argument at fault")
+	})
+
+	t.Run("should fail when the body is not JSON", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		resp := runFunction(NewJSONPathFunction(), types.StringUnknown(),
+			types.StringValue("<html/>"), types.StringValue("$.id"))
+
+		// then
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+	})
+}
+
+func TestJSONPathAllFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return every selected value in document order", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		body := types.StringValue(`{"items":[{"id":1},{"id":2},{"id":3}]}`)
+
+		// when
+		resp := runFunction(NewJSONPathAllFunction(), types.ListUnknown(types.StringType),
+			body, types.StringValue("$.items[*].id"))
+
+		// then
+		require.Nil(t, resp.Error)
+		expected := types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("1"), types.StringValue("2"), types.StringValue("3"),
+		})
+		assert.Equal(t, expected, resp.Result.Value())
+	})
+
+	t.Run("should return an empty list when the expression selects nothing", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		resp := runFunction(NewJSONPathAllFunction(), types.ListUnknown(types.StringType),
+			types.StringValue(`[]`), types.StringValue("$[*].id"))
+
+		// then
+		require.Nil(t, resp.Error)
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{}), resp.Result.Value())
+	})
+}
+
+func TestURLFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should join and encode like the URL of a request", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		query := types.MapValueMust(types.StringType, map[string]attr.Value{
+			"name": types.StringValue("a b"),
+			"page": types.StringValue("2"),
+		})
+
+		// when
+		resp := runFunction(NewURLFunction(), types.StringUnknown(),
+			types.StringValue("https://api.example.com/v1"), types.StringValue("widgets?sort=asc"), query)
+
+		// then
+		require.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue("https://api.example.com/v1/widgets?name=a+b&page=2&sort=asc"),
+			resp.Result.Value())
+	})
+
+	t.Run("should accept a null query", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		resp := runFunction(NewURLFunction(), types.StringUnknown(),
+			types.StringValue("https://api.example.com"), types.StringValue("/widgets/42"),
+			types.MapNull(types.StringType))
+
+		// then
+		require.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue("https://api.example.com/widgets/42"), resp.Result.Value())
+	})
+}
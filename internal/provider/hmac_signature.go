@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+const (
+	attrHMACSignature   = "hmac_signature"
+	attrSecret          = "secret"
+	attrAlgorithm       = "algorithm"
+	attrTemplate        = "template"
+	attrHeader          = "header"
+	attrPrefix          = "prefix"
+	attrEncoding        = "encoding"
+	attrTimestampFormat = "timestamp_format"
+	attrTimestampHeader = "timestamp_header"
+	attrNonceHeader     = "nonce_header"
+)
+
+// Descriptions of the `hmac_signature` arguments.
+const (
+	descHMACSignature = "Signs every request this resource issues -- create, refresh, update, destroy and " +
+		"`wait_for` polls alike -- with an HMAC over a canonical string, as payment gateways and webhook " +
+		"receivers commonly require. The signature is computed once the headers, query and body are final " +
+		"and written to `header`, and again for every retry. Like `basic_auth`, it never forces replacement."
+	descHMACSecret    = "The shared secret the HMAC is keyed with."
+	descHMACAlgorithm = "The hash function of the HMAC, which also hashes the body: `sha256` (the default) " +
+		"or `sha512`."
+	descHMACTemplate = "The canonical string that is signed. The `${method}`, `${host}`, `${path}`, " +
+		"`${query}`, `${timestamp}`, `${nonce}` and `${body_hash}` (the hex digest of the body) tokens are " +
+		"replaced by the values of the request; write them `$${method}` in HCL. Defaults to " +
+		"`${method}\\n${path}\\n${timestamp}\\n${body_hash}`."
+	descHMACHeader          = "The header the signature is sent in, such as `X-Signature`."
+	descHMACPrefix          = "A prefix written before the signature in `header`, such as `sha256=`."
+	descHMACEncoding        = "How the signature is encoded: `hex` (the default) or `base64`."
+	descHMACTimestampFormat = "How `${timestamp}` is written: `unix` seconds (the default), `unix_ms` " +
+		"milliseconds or `rfc3339`."
+	descHMACTimestampHeader = "A header the timestamp is also sent in, so the receiver can rebuild the " +
+		"canonical string and reject stale requests."
+	descHMACNonceHeader = "A header a random nonce, generated for every request and every retry of it, is " +
+		"sent in. The same nonce replaces `${nonce}`."
+)
+
+// hmacTemplateToken matches the `${name}` tokens of a canonical string template.
+var hmacTemplateToken = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// hmacSignatureObjectAttrTypes returns the attribute types of the `hmac_signature` nested object.
+func hmacSignatureObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrSecret:          types.StringType,
+		attrAlgorithm:       types.StringType,
+		attrTemplate:        types.StringType,
+		attrHeader:          types.StringType,
+		attrPrefix:          types.StringType,
+		attrEncoding:        types.StringType,
+		attrTimestampFormat: types.StringType,
+		attrTimestampHeader: types.StringType,
+		attrNonceHeader:     types.StringType,
+	}
+}
+
+// addHMACSignatureAttribute adds the `hmac_signature` attribute to the resource schema.
+func addHMACSignatureAttribute(attrs map[string]schema.Attribute) {
+	optional := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description:         description,
+			MarkdownDescription: description,
+			Optional:            true,
+		}
+	}
+
+	attrs[attrHMACSignature] = schema.SingleNestedAttribute{
+		Description:         descHMACSignature,
+		MarkdownDescription: descHMACSignature,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			attrSecret: schema.StringAttribute{
+				Description:         descHMACSecret,
+				MarkdownDescription: descHMACSecret,
+				Required:            true,
+				Sensitive:           true,
+			},
+			attrHeader: schema.StringAttribute{
+				Description:         descHMACHeader,
+				MarkdownDescription: descHMACHeader,
+				Required:            true,
+			},
+			attrAlgorithm:       optional(descHMACAlgorithm),
+			attrTemplate:        optional(descHMACTemplate),
+			attrPrefix:          optional(descHMACPrefix),
+			attrEncoding:        optional(descHMACEncoding),
+			attrTimestampFormat: optional(descHMACTimestampFormat),
+			attrTimestampHeader: optional(descHMACTimestampHeader),
+			attrNonceHeader:     optional(descHMACNonceHeader),
+		},
+	}
+}
+
+// validateHMACSignature checks the `hmac_signature` attribute of the resource with
+// checkHMACSignature.
+func validateHMACSignature(
+	ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse,
+) {
+	var signature types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrHMACSignature), &signature)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkHMACSignature(signature, &resp.Diagnostics)
+}
+
+// checkHMACSignature reports an algorithm, an encoding or a timestamp format that is not supported,
+// and a template token that is not known. Unknown values pass.
+func checkHMACSignature(signature types.Object, diagnostics *diag.Diagnostics) {
+	if signature.IsNull() || signature.IsUnknown() {
+		return
+	}
+
+	for _, setting := range []struct {
+		name    string
+		choices []string
+	}{
+		{attrAlgorithm, []string{entities.HMACAlgorithmSHA256, entities.HMACAlgorithmSHA512}},
+		{attrEncoding, []string{entities.HMACEncodingHex, entities.HMACEncodingBase64}},
+		{attrTimestampFormat, []string{
+			entities.HMACTimestampUnix, entities.HMACTimestampUnixMilli, entities.HMACTimestampRFC3339,
+		}},
+	} {
+		value := objectStringOf(signature, setting.name)
+		if value == "" || slices.Contains(setting.choices, value) {
+			continue
+		}
+		diagnostics.AddAttributeError(
+			path.Root(attrHMACSignature).AtName(setting.name),
+			"Invalid hmac_signature",
+			fmt.Sprintf("%q is not a valid `%s`; use one of: %s.", value, setting.name, strings.Join(setting.choices, ", ")),
+		)
+	}
+
+	for _, match := range hmacTemplateToken.FindAllStringSubmatch(objectStringOf(signature, attrTemplate), -1) {
+		if slices.Contains(entities.HMACTemplateTokens, match[1]) {
+			continue
+		}
+		diagnostics.AddAttributeError(
+			path.Root(attrHMACSignature).AtName(attrTemplate),
+			"Invalid hmac_signature",
+			fmt.Sprintf("The template token %q is not known; use one of: %s.",
+				match[0], strings.Join(entities.HMACTemplateTokens, ", ")),
+		)
+	}
+}
+
+// hmacSignatureOf converts a resource-level `hmac_signature` object, returning nil when it is not set.
+func hmacSignatureOf(obj types.Object) *entities.HMACSignatureConfig {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	return &entities.HMACSignatureConfig{
+		Secret:          objectStringOf(obj, attrSecret),
+		Algorithm:       objectStringOf(obj, attrAlgorithm),
+		Template:        objectStringOf(obj, attrTemplate),
+		Header:          objectStringOf(obj, attrHeader),
+		Prefix:          objectStringOf(obj, attrPrefix),
+		Encoding:        objectStringOf(obj, attrEncoding),
+		TimestampFormat: objectStringOf(obj, attrTimestampFormat),
+		TimestampHeader: objectStringOf(obj, attrTimestampHeader),
+		NonceHeader:     objectStringOf(obj, attrNonceHeader),
+	}
+}
+
+// requestSignerKey is the context key of the function that signs a request again.
+type requestSignerKey struct{}
+
+// withRequestSigner returns a context whose requests resignRequest signs with sign.
+func withRequestSigner(ctx context.Context, sign func(*http.Request) error) context.Context {
+	return context.WithValue(ctx, requestSignerKey{}, sign)
+}
+
+// resignRequest is the retryablehttp.PrepareRetry of every retrying client. A retry is otherwise
+// sent with the headers of the first attempt, whose nonce and timestamp a receiver enforcing their
+// uniqueness or freshness rejects, so it is signed again before being sent.
+func resignRequest(request *http.Request) error {
+	sign, _ := request.Context().Value(requestSignerKey{}).(func(*http.Request) error)
+	if sign == nil {
+		return nil
+	}
+	// the previous attempt shares the header map, so the new signature goes on a copy
+	request.Header = request.Header.Clone()
+
+	return sign(request)
+}
+
+// signHMAC signs a complete request with the `hmac_signature` of the model, if it has one.
+func signHMAC(request *http.Request, model HTTPRequestResourceModel) error {
+	signature := hmacSignatureOf(model.HMACSignature)
+	if signature == nil {
+		return nil
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating the hmac_signature nonce: %w", err)
+	}
+	if err := signature.Sign(request, time.Now(), hex.EncodeToString(nonce)); err != nil {
+		return fmt.Errorf("signing the request with hmac_signature: %w", err)
+	}
+
+	return nil
+}
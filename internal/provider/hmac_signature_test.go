@@ -0,0 +1,218 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// hmacSignatureObject builds a resource-level `hmac_signature` value keyed with "whsec" and written
+// to X-Signature, with the given optional arguments set and the others null.
+func hmacSignatureObject(optional map[string]string) types.Object {
+	values := map[string]attr.Value{
+		attrSecret: types.StringValue("whsec"),
+		attrHeader: types.StringValue("X-Signature"),
+	}
+	for name := range hmacSignatureObjectAttrTypes() {
+		if _, set := values[name]; !set {
+			values[name] = types.StringNull()
+		}
+	}
+	for name, value := range optional {
+		values[name] = types.StringValue(value)
+	}
+
+	return types.ObjectValueMust(hmacSignatureObjectAttrTypes(), values)
+}
+
+func TestHMACSignatureSign(t *testing.T) {
+	t.Parallel()
+
+	signedAt := time.Unix(1700000000, 0)
+	newRequest := func(t *testing.T, url string) *http.Request {
+		t.Helper()
+
+		request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"amount":100}`))
+		require.NoError(t, err)
+
+		return request
+	}
+
+	t.Run("should sign the default canonical string with a hex SHA-256", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		request := newRequest(t, "https://pay.example.test/v1/charges")
+		config := &entities.HMACSignatureConfig{Secret: "whsec", Header: "X-Signature"}
+
+		// when
+		err := config.Sign(request, signedAt, "nonce-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "8dbba59afb929e7961cf9102e1be20c827b23aa45c4af942789a3967a003bd8e",
+			request.Header.Get("X-Signature"))
+	})
+
+	t.Run("should follow the template, algorithm, encoding and prefix configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		request := newRequest(t, "https://pay.example.test/v1/charges?currency=eur")
+		config := &entities.HMACSignatureConfig{
+			Secret:          "whsec",
+			Algorithm:       entities.HMACAlgorithmSHA512,
+			Template:        "${method} ${path}?${query} ${timestamp} ${nonce} ${body_hash}",
+			Header:          "Signature",
+			Prefix:          "v1=",
+			Encoding:        entities.HMACEncodingBase64,
+			TimestampFormat: entities.HMACTimestampRFC3339,
+			TimestampHeader: "X-Timestamp",
+			NonceHeader:     "X-Nonce",
+		}
+
+		// when
+		err := config.Sign(request, signedAt, "nonce-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "v1=+SdNlWRpTVfHFq+4DX5UpVpLcIsiWB2GV/g7xbYZkRFKUa9ChhfaV7PHfIyCLRhYSyUhtBKKabIbtXqxjJ7PFg==",
+			request.Header.Get("Signature"))
+		assert.Equal(t, "2023-11-14T22:13:20Z", request.Header.Get("X-Timestamp"))
+		assert.Equal(t, "nonce-1", request.Header.Get("X-Nonce"))
+	})
+
+	t.Run("should stream a replayable body through the body hash", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		request, largestRead := largeUpload(t)
+		config := &entities.HMACSignatureConfig{Secret: "whsec", Header: "X-Signature"}
+
+		// when
+		err := config.Sign(request, signedAt, "nonce-1")
+
+		// then
+		require.NoError(t, err)
+		assert.LessOrEqual(t, *largestRead, 32<<10, "the upload is never held in memory whole")
+	})
+}
+
+func TestBuildRequestHMACSignature(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should sign the request with a fresh nonce every time", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := requestModel(types.MapNull(types.StringType))
+		model.HMACSignature = hmacSignatureObject(map[string]string{
+			attrTemplate:    "${nonce}",
+			attrNonceHeader: "X-Nonce",
+		})
+
+		// when
+		first := buildTestRequest(t, it, model)
+		second := buildTestRequest(t, it, model)
+
+		// then
+		assert.Len(t, first.Header.Get("X-Nonce"), 32)
+		assert.NotEqual(t, first.Header.Get("X-Nonce"), second.Header.Get("X-Nonce"))
+		assert.NotEqual(t, first.Header.Get("X-Signature"), second.Header.Get("X-Signature"))
+	})
+
+	t.Run("should sign every retry again with its own nonce", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var mutex sync.Mutex
+		var nonces, invalid []string
+		var hits int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nonce := r.Header.Get("X-Nonce")
+			mac := hmac.New(sha256.New, []byte("whsec"))
+			mac.Write([]byte(nonce))
+			mutex.Lock()
+			nonces = append(nonces, nonce)
+			if r.Header.Get("X-Signature") != hex.EncodeToString(mac.Sum(nil)) {
+				invalid = append(invalid, nonce)
+			}
+			mutex.Unlock()
+			if atomic.AddInt64(&hits, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		t.Cleanup(server.Close)
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Retry = retryObject(types.Int64Value(1), types.Int64Value(1), types.Int64Value(1))
+		model.HMACSignature = hmacSignatureObject(map[string]string{
+			attrTemplate:    "${nonce}",
+			attrNonceHeader: "X-Nonce",
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, exchange.statusCode)
+		require.Len(t, nonces, 2)
+		assert.NotEqual(t, nonces[0], nonces[1], "a retry is a new request to the receiver")
+		assert.Empty(t, invalid, "every attempt carries the signature of its own nonce")
+	})
+}
+
+func TestCheckHMACSignature(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject an algorithm that is not supported", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		signature := hmacSignatureObject(map[string]string{attrAlgorithm: "md5"})
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkHMACSignature(signature, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Contains(t, diagnostics[0].Detail(), "`algorithm`")
+	})
+
+	t.Run("should reject a template token that is not known", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		signature := hmacSignatureObject(map[string]string{attrTemplate: "${method}:${body}"})
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkHMACSignature(signature, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Contains(t, diagnostics[0].Detail(), `"${body}"`)
+	})
+}
@@ -61,9 +61,17 @@ func getSupportedIgnoreAttributes() map[string]IgnoreAttributeKind {
 		attrPath:                 IgnoreKindScalar,
 		attrHeaders:              IgnoreKindMap,
 		attrRequestBody:          IgnoreKindBody,
+		attrRequestBodyHash:      IgnoreKindScalar,
+		attrContentType:          IgnoreKindScalar,
+		attrMultipart:            IgnoreKindObject,
 		attrQueryParameters:      IgnoreKindMap,
 		attrBaseURL:              IgnoreKindScalar,
+		attrEndpoint:             IgnoreKindScalar,
 		attrBasicAuth:            IgnoreKindObject,
+		attrBearerAuth:           IgnoreKindObject,
+		attrAPIKey:               IgnoreKindObject,
+		attrHMACSignature:        IgnoreKindObject,
+		attrDigestAuth:           IgnoreKindObject,
 		attrIgnoreTLS:            IgnoreKindScalar,
 		attrIsResponseBodyJSON:   IgnoreKindScalar,
 		attrResponseBodyIDFilter: IgnoreKindScalar,
@@ -77,6 +85,10 @@ func getIgnoreAppliers() map[string]ignoreApplier {
 	methodGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.Method }
 	pathGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.Path }
 	baseURLGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.BaseURL }
+	endpointGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.Endpoint }
+	requestBodyHashGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.RequestBodyHash }
+	contentTypeGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.ContentType }
+	multipartGetter := func(m *HTTPRequestResourceModel) *types.Object { return &m.Multipart }
 	responseBodyIDFilterGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.ResponseBodyIDFilter }
 	ignoreTLSGetter := func(m *HTTPRequestResourceModel) *types.Bool { return &m.IgnoreTLS }
 	isResponseBodyJSONGetter := func(m *HTTPRequestResourceModel) *types.Bool { return &m.IsResponseBodyJSON }
@@ -84,6 +96,10 @@ func getIgnoreAppliers() map[string]ignoreApplier {
 	queryParametersGetter := func(m *HTTPRequestResourceModel) *types.Map { return &m.QueryParameters }
 	requestBodyGetter := func(m *HTTPRequestResourceModel) *types.String { return &m.RequestBody }
 	basicAuthGetter := func(m *HTTPRequestResourceModel) *types.Object { return &m.BasicAuth }
+	bearerAuthGetter := func(m *HTTPRequestResourceModel) *types.Object { return &m.BearerAuth }
+	apiKeyGetter := func(m *HTTPRequestResourceModel) *types.Object { return &m.APIKey }
+	hmacSignatureGetter := func(m *HTTPRequestResourceModel) *types.Object { return &m.HMACSignature }
+	digestAuthGetter := func(m *HTTPRequestResourceModel) *types.Object { return &m.DigestAuth }
 
 	return map[string]ignoreApplier{
 		attrMethod: makeStringApplier(
@@ -98,6 +114,22 @@ func getIgnoreAppliers() map[string]ignoreApplier {
 			baseURLGetter,
 			baseURLGetter,
 		),
+		attrEndpoint: makeStringApplier(
+			endpointGetter,
+			endpointGetter,
+		),
+		attrRequestBodyHash: makeStringApplier(
+			requestBodyHashGetter,
+			requestBodyHashGetter,
+		),
+		attrContentType: makeStringApplier(
+			contentTypeGetter,
+			contentTypeGetter,
+		),
+		attrMultipart: makeObjectApplier(
+			multipartGetter,
+			multipartGetter,
+		),
 		attrResponseBodyIDFilter: makeStringApplier(
 			responseBodyIDFilterGetter,
 			responseBodyIDFilterGetter,
@@ -126,6 +158,22 @@ func getIgnoreAppliers() map[string]ignoreApplier {
 			basicAuthGetter,
 			basicAuthGetter,
 		),
+		attrBearerAuth: makeObjectApplier(
+			bearerAuthGetter,
+			bearerAuthGetter,
+		),
+		attrAPIKey: makeObjectApplier(
+			apiKeyGetter,
+			apiKeyGetter,
+		),
+		attrHMACSignature: makeObjectApplier(
+			hmacSignatureGetter,
+			hmacSignatureGetter,
+		),
+		attrDigestAuth: makeObjectApplier(
+			digestAuthGetter,
+			digestAuthGetter,
+		),
 	}
 }
 
@@ -12,8 +12,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
 )
 
@@ -48,15 +50,21 @@ type privateStateWriter interface {
 //
 // These are exactly the arguments carrying the conditional RequiresReplace modifier. `basic_auth`
 // is deliberately absent: it never forced replacement, so there is nothing to suppress, and the
-// computed and write-only attributes are absent because a configuration cannot set them.
+// computed and write-only attributes are absent because a configuration cannot set them -- except
+// `request_body_hash`, which the plan computes from the configured body file and which no import
+// identifier carries.
 func adoptableAttributes() map[string]struct{} {
 	return map[string]struct{}{
 		attrMethod:               {},
 		attrPath:                 {},
 		attrHeaders:              {},
 		attrRequestBody:          {},
+		attrRequestBodyHash:      {},
+		attrContentType:          {},
+		attrMultipart:            {},
 		attrQueryParameters:      {},
 		attrBaseURL:              {},
+		attrEndpoint:             {},
 		attrIgnoreTLS:            {},
 		attrIsResponseBodyJSON:   {},
 		attrResponseBodyIDFilter: {},
@@ -172,6 +180,22 @@ func requiresReplaceUnlessAdopted(
 	return !isAdoptionPending(adopt, attribute)
 }
 
+// requiresReplacement decides whether a changed attribute forces replacement: not when a configured
+// update request carries the change in place, and otherwise as requiresReplaceUnlessAdopted does.
+func requiresReplacement(
+	ctx context.Context,
+	attribute string,
+	config tfsdk.Config,
+	private privateStateReader,
+	diagnostics *diag.Diagnostics,
+) bool {
+	if isUpdatedInPlace(ctx, attribute, config, diagnostics) {
+		return false
+	}
+
+	return requiresReplaceUnlessAdopted(ctx, attribute, private, diagnostics)
+}
+
 const (
 	descAdoptReplace = "Changing this argument replaces the resource, except on the first plan " +
 		"after an import that did not specify it, where the value is adopted from the configuration " +
@@ -192,8 +216,8 @@ func replaceableStringAttribute(required bool, description string) schema.String
 				req planmodifier.StringRequest,
 				resp *stringplanmodifier.RequiresReplaceIfFuncResponse,
 			) {
-				resp.RequiresReplace = requiresReplaceUnlessAdopted(
-					ctx, attributeNameOf(req.Path), req.Private, &resp.Diagnostics,
+				resp.RequiresReplace = requiresReplacement(
+					ctx, attributeNameOf(req.Path), req.Config, req.Private, &resp.Diagnostics,
 				)
 			},
 			descAdoptReplace,
@@ -214,8 +238,8 @@ func replaceableMapAttribute(required bool, elementType attr.Type, description s
 				req planmodifier.MapRequest,
 				resp *mapplanmodifier.RequiresReplaceIfFuncResponse,
 			) {
-				resp.RequiresReplace = requiresReplaceUnlessAdopted(
-					ctx, attributeNameOf(req.Path), req.Private, &resp.Diagnostics,
+				resp.RequiresReplace = requiresReplacement(
+					ctx, attributeNameOf(req.Path), req.Config, req.Private, &resp.Diagnostics,
 				)
 			},
 			descAdoptReplace,
@@ -236,8 +260,8 @@ func replaceableBoolAttribute(required bool, description string) schema.BoolAttr
 				req planmodifier.BoolRequest,
 				resp *boolplanmodifier.RequiresReplaceIfFuncResponse,
 			) {
-				resp.RequiresReplace = requiresReplaceUnlessAdopted(
-					ctx, attributeNameOf(req.Path), req.Private, &resp.Diagnostics,
+				resp.RequiresReplace = requiresReplacement(
+					ctx, attributeNameOf(req.Path), req.Config, req.Private, &resp.Diagnostics,
 				)
 			},
 			descAdoptReplace,
@@ -248,6 +272,24 @@ func replaceableBoolAttribute(required bool, description string) schema.BoolAttr
 	return attribute
 }
 
+// replaceableBlockModifier returns the same conditional replacement rule for a nested block, which
+// a change to any argument inside the block triggers.
+func replaceableBlockModifier() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(
+			ctx context.Context,
+			req planmodifier.ObjectRequest,
+			resp *objectplanmodifier.RequiresReplaceIfFuncResponse,
+		) {
+			resp.RequiresReplace = requiresReplacement(
+				ctx, attributeNameOf(req.Path), req.Config, req.Private, &resp.Diagnostics,
+			)
+		},
+		descAdoptReplace,
+		descAdoptReplaceMarkdown,
+	)
+}
+
 // attributeNameOf reduces an attribute path to its top-level name. Every adoptable attribute is a
 // root attribute, so the string form of the path is the name itself.
 func attributeNameOf(attributePath interface{ String() string }) string {
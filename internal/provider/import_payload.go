@@ -28,18 +28,22 @@ import (
 // means a destroy and a create, which is precisely the failure import is supposed to avoid.
 type HTTPRequestResourceModelNative struct {
 	// parameters
-	Method               string            `json:"method"`
-	Path                 string            `json:"path"`
-	Headers              map[string]string `json:"headers,omitempty"`
-	RequestBody          string            `json:"request_body,omitempty"`
-	IsResponseBodyJSON   *bool             `json:"is_response_body_json,omitempty"`
-	ResponseBodyIDFilter string            `json:"response_body_id_filter,omitempty"`
-	QueryParameters      map[string]string `json:"query_parameters,omitempty"`
-	ToleratedStatusCodes []int32           `json:"tolerated_status_codes,omitempty"`
-	IgnoreChanges        []string          `json:"ignore_changes,omitempty"`
+	Method                 string            `json:"method"`
+	Path                   string            `json:"path"`
+	Headers                map[string]string `json:"headers,omitempty"`
+	RequestBody            string            `json:"request_body,omitempty"`
+	RequestBodyFile        string            `json:"request_body_file,omitempty"`
+	ContentType            string            `json:"content_type,omitempty"`
+	IsResponseBodyJSON     *bool             `json:"is_response_body_json,omitempty"`
+	ResponseBodyIDFilter   string            `json:"response_body_id_filter,omitempty"`
+	QueryParameters        map[string]string `json:"query_parameters,omitempty"`
+	ToleratedStatusCodes   []int32           `json:"tolerated_status_codes,omitempty"`
+	IgnoreChanges          []string          `json:"ignore_changes,omitempty"`
+	CaptureResponseHeaders []string          `json:"capture_response_headers,omitempty"`
 
 	// resource-level configuration (alternative to provider-level)
 	BaseURL          string            `json:"base_url,omitempty"`
+	Endpoint         string            `json:"endpoint,omitempty"`
 	BasicAuth        map[string]string `json:"basic_auth,omitempty"`
 	IgnoreTLS        *bool             `json:"ignore_tls,omitempty"`
 	RequestTimeoutMs *int64            `json:"request_timeout_ms,omitempty"`
@@ -53,8 +57,12 @@ type HTTPRequestResourceModelNative struct {
 	DeleteRequestBody string            `json:"delete_request_body,omitempty"`
 
 	// refresh controls
-	IsRefreshEnabled *bool  `json:"is_refresh_enabled,omitempty"`
-	RefreshPath      string `json:"refresh_path,omitempty"`
+	IsRefreshEnabled       *bool             `json:"is_refresh_enabled,omitempty"`
+	RefreshPath            string            `json:"refresh_path,omitempty"`
+	RefreshMethod          string            `json:"refresh_method,omitempty"`
+	RefreshHeaders         map[string]string `json:"refresh_headers,omitempty"`
+	RefreshRequestBody     string            `json:"refresh_request_body,omitempty"`
+	RefreshGoneStatusCodes []int32           `json:"refresh_gone_status_codes,omitempty"`
 
 	// state
 	ID               string            `json:"id,omitempty"`
@@ -62,6 +70,7 @@ type HTTPRequestResourceModelNative struct {
 	ResponseBody     string            `json:"response_body,omitempty"`
 	ResponseBodyID   string            `json:"response_body_id,omitempty"`
 	ResponseBodyJSON map[string]string `json:"response_body_json,omitempty"`
+	ResponseHeaders  map[string]string `json:"response_headers,omitempty"`
 
 	// ImportReadPath is import-only: it is not a schema attribute. When the payload describes a
 	// resource created with an unsafe method (POST, PUT, PATCH), import refuses to replay that
@@ -73,9 +82,14 @@ type HTTPRequestResourceModelNative struct {
 // retryNative is the native (JSON) representation of the `retry` block, used by
 // the import payload.
 type retryNative struct {
-	Attempts   *int64 `json:"attempts,omitempty"`
-	MinDelayMs *int64 `json:"min_delay_ms,omitempty"`
-	MaxDelayMs *int64 `json:"max_delay_ms,omitempty"`
+	Attempts           *int64  `json:"attempts,omitempty"`
+	MinDelayMs         *int64  `json:"min_delay_ms,omitempty"`
+	MaxDelayMs         *int64  `json:"max_delay_ms,omitempty"`
+	RetryOnStatusCodes []int32 `json:"retry_on_status_codes,omitempty"`
+	RespectRetryAfter  *bool   `json:"respect_retry_after,omitempty"`
+	Jitter             *bool   `json:"jitter,omitempty"`
+	RetryNonIdempotent *bool   `json:"retry_non_idempotent,omitempty"`
+	RetryOnBodyMatch   *string `json:"retry_on_body_match,omitempty"`
 }
 
 // importPayload is a decoded `terraform import` identifier.
@@ -486,7 +500,7 @@ func isSafeHTTPMethod(method string) bool {
 //
 // Only the arguments a configuration can set are encoded. Three groups are deliberately left out:
 //
-//   - `basic_auth`, because the identifier lives in plain state and is meant to be copied into
+//   - `basic_auth`, `bearer_auth` and `api_key`, because the identifier lives in plain state and is meant to be copied into
 //     shells and CI logs, where a credential would leak. The configuration supplies it on import.
 //   - the captured response (`response_code`, `response_body`, and the `response_body_id` /
 //     `response_body_json` derived from it). A response body is frequently the most sensitive
@@ -505,22 +519,30 @@ func buildImportID(
 	diagnostics *diag.Diagnostics,
 ) types.String {
 	native := HTTPRequestResourceModelNative{
-		Method:               model.Method.ValueString(),
-		Path:                 model.Path.ValueString(),
-		Headers:              stringMapOf(ctx, model.Headers, diagnostics),
-		RequestBody:          model.RequestBody.ValueString(),
-		IsResponseBodyJSON:   boolValueToPtr(model.IsResponseBodyJSON),
-		ResponseBodyIDFilter: model.ResponseBodyIDFilter.ValueString(),
-		QueryParameters:      stringMapOf(ctx, model.QueryParameters, diagnostics),
-		ToleratedStatusCodes: int32SliceOf(ctx, model.ToleratedStatusCodes, diagnostics),
-		IgnoreChanges:        stringSliceOf(ctx, model.IgnoreChanges, diagnostics),
-		BaseURL:              model.BaseURL.ValueString(),
-		IgnoreTLS:            boolValueToPtr(model.IgnoreTLS),
-		RequestTimeoutMs:     int64ValueToPtr(model.RequestTimeoutMs),
-		Retry:                retryNativeFromObject(model.Retry),
-		IsRefreshEnabled:     boolValueToPtr(model.IsRefreshEnabled),
-		RefreshPath:          model.RefreshPath.ValueString(),
-		ImportReadPath:       importReadPathForIdentifier(model),
+		Method:                 model.Method.ValueString(),
+		Path:                   model.Path.ValueString(),
+		Headers:                stringMapOf(ctx, model.Headers, diagnostics),
+		RequestBody:            model.RequestBody.ValueString(),
+		RequestBodyFile:        model.RequestBodyFile.ValueString(),
+		ContentType:            model.ContentType.ValueString(),
+		IsResponseBodyJSON:     boolValueToPtr(model.IsResponseBodyJSON),
+		ResponseBodyIDFilter:   model.ResponseBodyIDFilter.ValueString(),
+		QueryParameters:        stringMapOf(ctx, model.QueryParameters, diagnostics),
+		ToleratedStatusCodes:   int32SliceOf(ctx, model.ToleratedStatusCodes, diagnostics),
+		IgnoreChanges:          stringSliceOf(ctx, model.IgnoreChanges, diagnostics),
+		CaptureResponseHeaders: stringSliceOf(ctx, model.CaptureResponseHeaders, diagnostics),
+		BaseURL:                model.BaseURL.ValueString(),
+		Endpoint:               model.Endpoint.ValueString(),
+		IgnoreTLS:              boolValueToPtr(model.IgnoreTLS),
+		RequestTimeoutMs:       int64ValueToPtr(model.RequestTimeoutMs),
+		Retry:                  retryNativeFromObject(model.Retry),
+		IsRefreshEnabled:       boolValueToPtr(model.IsRefreshEnabled),
+		RefreshPath:            model.RefreshPath.ValueString(),
+		RefreshMethod:          model.RefreshMethod.ValueString(),
+		RefreshHeaders:         stringMapOf(ctx, model.RefreshHeaders, diagnostics),
+		RefreshRequestBody:     model.RefreshRequestBody.ValueString(),
+		RefreshGoneStatusCodes: int32SliceOf(ctx, model.RefreshGoneStatusCodes, diagnostics),
+		ImportReadPath:         importReadPathForIdentifier(model),
 	}
 
 	if diagnostics.HasError() {
@@ -558,11 +580,7 @@ func importReadPathForIdentifier(model HTTPRequestResourceModel) string {
 	// a read path still succeeds with a warning explaining how to supply one.
 	var ignored diag.Diagnostics
 
-	resolved, ok := resolveDeletePathTokens(
-		model.RefreshPath.ValueString(),
-		model.ResponseBody.ValueString(),
-		&ignored,
-	)
+	resolved, ok := resolveRefreshPath(model, &ignored)
 	if !ok {
 		return ""
 	}
@@ -591,6 +609,31 @@ func retryNativeFromObject(object types.Object) *retryNative {
 		native.MaxDelayMs = int64ValueToPtr(value)
 	}
 
+	if value, ok := attributes[attrRetryOnStatusCodes].(types.Set); ok && !value.IsNull() && !value.IsUnknown() {
+		for _, element := range value.Elements() {
+			if code, isInt := element.(types.Int32); isInt && !code.IsNull() && !code.IsUnknown() {
+				native.RetryOnStatusCodes = append(native.RetryOnStatusCodes, code.ValueInt32())
+			}
+		}
+	}
+
+	if value, ok := attributes[attrRespectRetryAfter].(types.Bool); ok {
+		native.RespectRetryAfter = boolValueToPtr(value)
+	}
+
+	if value, ok := attributes[attrJitter].(types.Bool); ok {
+		native.Jitter = boolValueToPtr(value)
+	}
+
+	if value, ok := attributes[attrRetryNonIdempotent].(types.Bool); ok {
+		native.RetryNonIdempotent = boolValueToPtr(value)
+	}
+
+	if value, ok := attributes[attrRetryOnBodyMatch].(types.String); ok && isKnownString(value) {
+		expression := value.ValueString()
+		native.RetryOnBodyMatch = &expression
+	}
+
 	return native
 }
 
@@ -671,7 +714,7 @@ func buildModelFromNativeData(
 		return nil
 	}
 
-	setToleratedStatusCodesField(model, nativeModel, diagnostics)
+	setStatusCodeFields(model, nativeModel, diagnostics)
 	if diagnostics.HasError() {
 		return nil
 	}
@@ -681,6 +724,11 @@ func buildModelFromNativeData(
 		return nil
 	}
 
+	setCaptureResponseHeadersField(model, nativeModel, diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+
 	return model
 }
 
@@ -691,14 +739,52 @@ func buildModelFromNativeData(
 // force a destroy and a create on the first plan after the import.
 func createBaseModel(nativeModel *HTTPRequestResourceModelNative) *HTTPRequestResourceModel {
 	model := &HTTPRequestResourceModel{
-		Method:               types.StringValue(nativeModel.Method),
-		Path:                 types.StringValue(nativeModel.Path),
-		IsResponseBodyJSON:   boolPtrToValue(nativeModel.IsResponseBodyJSON),
-		ResponseCode:         int32PtrToValue(nativeModel.ResponseCode),
-		IsDeleteEnabled:      boolPtrToValue(nativeModel.IsDeleteEnabled),
-		IsRefreshEnabled:     boolPtrToValue(nativeModel.IsRefreshEnabled),
-		ToleratedStatusCodes: types.SetNull(types.Int32Type),
-		IgnoreChanges:        types.SetNull(types.StringType),
+		Method:                 types.StringValue(nativeModel.Method),
+		Path:                   types.StringValue(nativeModel.Path),
+		IsResponseBodyJSON:     boolPtrToValue(nativeModel.IsResponseBodyJSON),
+		ResponseCode:           int32PtrToValue(nativeModel.ResponseCode),
+		IsDeleteEnabled:        boolPtrToValue(nativeModel.IsDeleteEnabled),
+		IsRefreshEnabled:       boolPtrToValue(nativeModel.IsRefreshEnabled),
+		ToleratedStatusCodes:   types.SetNull(types.Int32Type),
+		RefreshGoneStatusCodes: types.SetNull(types.Int32Type),
+		IgnoreChanges:          types.SetNull(types.StringType),
+		CaptureResponseHeaders: types.SetNull(types.StringType),
+
+		// The update controls are write-only: an import identifier cannot carry them and state
+		// never holds anything but a typed null for them.
+		UpdateMethod:      types.StringNull(),
+		UpdatePath:        types.StringNull(),
+		UpdateHeaders:     types.MapNull(types.StringType),
+		UpdateRequestBody: types.StringNull(),
+
+		// The content of a body file or a base64 body is never in an identifier, so its hash is
+		// adopted from the plan by the first apply, which re-reads the file instead of trusting it.
+		RequestBodyBase64: types.StringNull(),
+		RequestBodyHash:   types.StringNull(),
+
+		// Nor are multipart parts, whose values may be credentials: a configured block is adopted.
+		Multipart: types.ObjectNull(multipartObjectAttrTypes()),
+
+		// Polling and drift detection are not carried by an import identifier; a configured block
+		// is adopted in place by the first apply, which sends no request when only these differ.
+		WaitFor:        types.ObjectNull(waitForObjectAttrTypes()),
+		DriftDetection: types.ObjectNull(driftDetectionObjectAttrTypes()),
+		DeleteWait:     types.ObjectNull(deleteWaitObjectAttrTypes()),
+
+		// Credentials are never part of an import identifier; see buildImportID.
+		BearerAuth:    types.ObjectNull(bearerAuthObjectAttrTypes()),
+		APIKey:        types.ObjectNull(apiKeyObjectAttrTypes()),
+		HMACSignature: types.ObjectNull(hmacSignatureObjectAttrTypes()),
+		DigestAuth:    types.ObjectNull(digestAuthObjectAttrTypes()),
+
+		// Neither are the TLS, proxy and redirect settings: they decide how a request travels, not
+		// which object it addresses, and configured values are adopted in place like `wait_for`.
+		tlsArguments:      nullTLSArguments(),
+		proxyArguments:    nullProxyArguments(),
+		redirectArguments: nullRedirectArguments(),
+
+		// Filled in by captureImportedResponse when it reads the object.
+		FinalURL: types.StringNull(),
 	}
 
 	return model
@@ -714,11 +800,16 @@ func setOptionalStringFields(model *HTTPRequestResourceModel, nativeModel *HTTPR
 		{&model.DeletePath, nativeModel.DeletePath},
 		{&model.DeleteRequestBody, nativeModel.DeleteRequestBody},
 		{&model.RefreshPath, nativeModel.RefreshPath},
+		{&model.RefreshMethod, nativeModel.RefreshMethod},
+		{&model.RefreshRequestBody, nativeModel.RefreshRequestBody},
 		{&model.RequestBody, nativeModel.RequestBody},
+		{&model.RequestBodyFile, nativeModel.RequestBodyFile},
+		{&model.ContentType, nativeModel.ContentType},
 		{&model.ResponseBodyIDFilter, nativeModel.ResponseBodyIDFilter},
 		{&model.ResponseBody, nativeModel.ResponseBody},
 		{&model.ResponseBodyID, nativeModel.ResponseBodyID},
 		{&model.BaseURL, nativeModel.BaseURL},
+		{&model.Endpoint, nativeModel.Endpoint},
 	}
 
 	for _, assignment := range assignments {
@@ -801,7 +892,9 @@ func setMapFields(
 		{&model.Headers, nativeModel.Headers},
 		{&model.QueryParameters, nativeModel.QueryParameters},
 		{&model.DeleteHeaders, nativeModel.DeleteHeaders},
+		{&model.RefreshHeaders, nativeModel.RefreshHeaders},
 		{&model.ResponseBodyJSON, nativeModel.ResponseBodyJSON},
+		{&model.ResponseHeaders, nativeModel.ResponseHeaders},
 	}
 
 	for _, assignment := range assignments {
@@ -816,20 +909,51 @@ func setMapFields(
 	}
 }
 
-// setToleratedStatusCodesField copies `tolerated_status_codes`, leaving it null when absent.
-func setToleratedStatusCodesField(
+// setStatusCodeFields copies `tolerated_status_codes` and `refresh_gone_status_codes`, leaving
+// absent ones null.
+func setStatusCodeFields(
+	model *HTTPRequestResourceModel,
+	nativeModel *HTTPRequestResourceModelNative,
+	diagnostics *diag.Diagnostics,
+) {
+	assignments := []struct {
+		target *types.Set
+		value  []int32
+	}{
+		{&model.ToleratedStatusCodes, nativeModel.ToleratedStatusCodes},
+		{&model.RefreshGoneStatusCodes, nativeModel.RefreshGoneStatusCodes},
+	}
+
+	for _, assignment := range assignments {
+		if len(assignment.value) == 0 {
+			continue
+		}
+
+		value, diags := types.SetValueFrom(context.Background(), types.Int32Type, assignment.value)
+		diagnostics.Append(diags...)
+
+		if diagnostics.HasError() {
+			return
+		}
+
+		*assignment.target = value
+	}
+}
+
+// setIgnoreChangesField copies `ignore_changes`, leaving it null when absent.
+func setIgnoreChangesField(
 	model *HTTPRequestResourceModel,
 	nativeModel *HTTPRequestResourceModelNative,
 	diagnostics *diag.Diagnostics,
 ) {
-	if len(nativeModel.ToleratedStatusCodes) == 0 {
+	if len(nativeModel.IgnoreChanges) == 0 {
 		return
 	}
 
 	value, diags := types.SetValueFrom(
 		context.Background(),
-		types.Int32Type,
-		nativeModel.ToleratedStatusCodes,
+		types.StringType,
+		nativeModel.IgnoreChanges,
 	)
 	diagnostics.Append(diags...)
 
@@ -837,23 +961,23 @@ func setToleratedStatusCodesField(
 		return
 	}
 
-	model.ToleratedStatusCodes = value
+	model.IgnoreChanges = value
 }
 
-// setIgnoreChangesField copies `ignore_changes`, leaving it null when absent.
-func setIgnoreChangesField(
+// setCaptureResponseHeadersField copies `capture_response_headers`, leaving it null when absent.
+func setCaptureResponseHeadersField(
 	model *HTTPRequestResourceModel,
 	nativeModel *HTTPRequestResourceModelNative,
 	diagnostics *diag.Diagnostics,
 ) {
-	if len(nativeModel.IgnoreChanges) == 0 {
+	if len(nativeModel.CaptureResponseHeaders) == 0 {
 		return
 	}
 
 	value, diags := types.SetValueFrom(
 		context.Background(),
 		types.StringType,
-		nativeModel.IgnoreChanges,
+		nativeModel.CaptureResponseHeaders,
 	)
 	diagnostics.Append(diags...)
 
@@ -861,7 +985,7 @@ func setIgnoreChangesField(
 		return
 	}
 
-	model.IgnoreChanges = value
+	model.CaptureResponseHeaders = value
 }
 
 // boolPtrToValue converts an optional native bool into its framework representation.
@@ -898,10 +1022,26 @@ func retryObjectFromNative(nativeRetry *retryNative, diagnostics *diag.Diagnosti
 		return types.ObjectNull(retryObjectAttrTypes())
 	}
 
+	statusCodes := types.SetNull(types.Int32Type)
+	if len(nativeRetry.RetryOnStatusCodes) > 0 {
+		var diags diag.Diagnostics
+		statusCodes, diags = types.SetValueFrom(context.Background(), types.Int32Type, nativeRetry.RetryOnStatusCodes)
+		diagnostics.Append(diags...)
+	}
+	bodyMatch := types.StringNull()
+	if nativeRetry.RetryOnBodyMatch != nil {
+		bodyMatch = types.StringValue(*nativeRetry.RetryOnBodyMatch)
+	}
+
 	object, diags := types.ObjectValue(retryObjectAttrTypes(), map[string]attr.Value{
-		attrAttempts:   int64PtrToValue(nativeRetry.Attempts),
-		attrMinDelayMs: int64PtrToValue(nativeRetry.MinDelayMs),
-		attrMaxDelayMs: int64PtrToValue(nativeRetry.MaxDelayMs),
+		attrAttempts:           int64PtrToValue(nativeRetry.Attempts),
+		attrMinDelayMs:         int64PtrToValue(nativeRetry.MinDelayMs),
+		attrMaxDelayMs:         int64PtrToValue(nativeRetry.MaxDelayMs),
+		attrRetryOnStatusCodes: statusCodes,
+		attrRespectRetryAfter:  boolPtrToValue(nativeRetry.RespectRetryAfter),
+		attrJitter:             boolPtrToValue(nativeRetry.Jitter),
+		attrRetryNonIdempotent: boolPtrToValue(nativeRetry.RetryNonIdempotent),
+		attrRetryOnBodyMatch:   bodyMatch,
 	})
 	if diags.HasError() {
 		diagnostics.Append(diags...)
@@ -219,6 +219,26 @@ func TestDecodeImportIDNullCorrectness(t *testing.T) {
 		assert.True(t, model.BasicAuth.IsNull())
 		assert.Len(t, model.BasicAuth.AttributeTypes(t.Context()), 2)
 	})
+
+	t.Run("should carry the response header allow-list and captured headers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+
+		// when
+		model, _ := provider.DecodeImportIDForTest(
+			`{"method":"POST","path":"/widgets","capture_response_headers":["Location"],`+
+				`"response_headers":{"Location":"/widgets/42"}}`,
+			&diagnostics,
+		)
+
+		// then
+		require.False(t, diagnostics.HasError(), diagnostics.Errors())
+		require.NotNil(t, model)
+		assert.Len(t, model.CaptureResponseHeaders.Elements(), 1)
+		assert.Equal(t, types.StringValue("/widgets/42"), model.ResponseHeaders.Elements()["Location"])
+	})
 }
 
 func TestBuildImportID(t *testing.T) {
@@ -370,11 +390,15 @@ func TestPendingAdoptionFor(t *testing.T) {
 		// then
 		assert.Equal(t, []string{
 			"base_url",
+			"content_type",
+			"endpoint",
 			"headers",
 			"ignore_tls",
 			"is_response_body_json",
+			"multipart",
 			"query_parameters",
 			"request_body",
+			"request_body_hash",
 			"response_body_id_filter",
 		}, pending)
 	})
@@ -385,8 +409,9 @@ func TestPendingAdoptionFor(t *testing.T) {
 		// given
 		specified := map[string]struct{}{
 			"method": {}, "path": {}, "headers": {}, "request_body": {},
-			"query_parameters": {}, "base_url": {}, "ignore_tls": {},
+			"query_parameters": {}, "base_url": {}, "endpoint": {}, "ignore_tls": {},
 			"is_response_body_json": {}, "response_body_id_filter": {},
+			"content_type": {}, "request_body_hash": {}, "multipart": {},
 		}
 
 		// when
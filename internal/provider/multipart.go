@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+const (
+	attrMultipart = "multipart"
+	attrPart      = "part"
+	attrFile      = "file"
+	attrFilename  = "filename"
+)
+
+// multipartPartModel mirrors one `part` block of `multipart`.
+type multipartPartModel struct {
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	File        types.String `tfsdk:"file"`
+	Filename    types.String `tfsdk:"filename"`
+	ContentType types.String `tfsdk:"content_type"`
+	Headers     types.Map    `tfsdk:"headers"`
+}
+
+// multipartPartAttrTypes returns the attribute types of a `part` block.
+func multipartPartAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrName:        types.StringType,
+		attrValue:       types.StringType,
+		attrFile:        types.StringType,
+		attrFilename:    types.StringType,
+		attrContentType: types.StringType,
+		attrHeaders:     types.MapType{ElemType: types.StringType},
+	}
+}
+
+// multipartObjectAttrTypes returns the attribute types of the `multipart` block. It MUST be used
+// wherever a typed null `multipart` value is produced, as retryObjectAttrTypes is for `retry`.
+func multipartObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrPart: types.ListType{ElemType: types.ObjectType{AttrTypes: multipartPartAttrTypes()}},
+	}
+}
+
+// resourceMultipartBlock builds the `multipart` block of the resource. The parts define the request
+// like `request_body` does, so a change to any of them replaces the resource unless an update
+// request is configured, and the content of their files is planned through `request_body_hash`.
+func resourceMultipartBlock() schema.SingleNestedBlock {
+	description := "Sends the request body as `multipart/form-data`, for artifact repositories, " +
+		"document stores and file upload endpoints. The boundary and the `Content-Type` carrying it are " +
+		"set automatically, and file parts are streamed from disk as the request is sent. Conflicts " +
+		"with `request_body`, `request_body_file`, `request_body_base64` and `content_type`."
+
+	return schema.SingleNestedBlock{
+		Description:         description,
+		MarkdownDescription: description,
+		PlanModifiers:       []planmodifier.Object{replaceableBlockModifier()},
+		Blocks: map[string]schema.Block{
+			attrPart: schema.ListNestedBlock{
+				Description:         "A part of the body, sent in the order the blocks are written. Repeatable.",
+				MarkdownDescription: "A part of the body, sent in the order the blocks are written. Repeatable.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						attrName: helpers.StringAttributeNoReplace(true,
+							"The name of the form field the part holds."),
+						attrValue: schema.StringAttribute{
+							Description:         "The content of a form field. Conflicts with `file`.",
+							MarkdownDescription: "The content of a form field. Conflicts with `file`.",
+							Optional:            true,
+							// form fields routinely carry tokens, such as the one a Slack upload expects
+							Sensitive: true,
+						},
+						attrFile: helpers.StringAttributeNoReplace(false,
+							"The path of a file whose content the part holds, read when the request is sent. "+
+								"Conflicts with `value`."),
+						attrFilename: helpers.StringAttributeNoReplace(false,
+							"The file name the part announces. Defaults to the base name of `file`; a `value` "+
+								"part with a file name is uploaded as a file."),
+						attrContentType: helpers.StringAttributeNoReplace(false,
+							"The `Content-Type` of the part. Defaults to `application/octet-stream` for a "+
+								"part with a file name, and to none for a form field."),
+						attrHeaders: helpers.MapAttributeNoReplace(false, types.StringType,
+							"Additional headers of the part. The `Content-Disposition` naming the part is "+
+								"always the one derived from `name` and `filename`."),
+					},
+				},
+			},
+		},
+	}
+}
+
+// multipartPartsOf returns the parts of a `multipart` block, and false while any of them is unknown.
+func multipartPartsOf(
+	ctx context.Context, multipart types.Object, diagnostics *diag.Diagnostics,
+) ([]multipartPartModel, bool) {
+	if multipart.IsNull() {
+		return nil, true
+	}
+	if multipart.IsUnknown() {
+		return nil, false
+	}
+
+	list, _ := multipart.Attributes()[attrPart].(types.List)
+	if list.IsUnknown() {
+		return nil, false
+	}
+	if list.IsNull() {
+		return nil, true
+	}
+
+	var parts []multipartPartModel
+	diagnostics.Append(list.ElementsAs(ctx, &parts, false)...)
+
+	return parts, true
+}
+
+// multipartFilesOf returns the files of the parts of a `multipart` block, which request_body_hash
+// covers, and false while any of them is unknown.
+func multipartFilesOf(ctx context.Context, multipart types.Object, diagnostics *diag.Diagnostics) ([]string, bool) {
+	parts, known := multipartPartsOf(ctx, multipart, diagnostics)
+	if !known {
+		return nil, false
+	}
+
+	var files []string
+	for _, part := range parts {
+		if part.File.IsUnknown() {
+			return nil, false
+		}
+		if isNonEmptyString(part.File) {
+			files = append(files, part.File.ValueString())
+		}
+	}
+
+	return files, true
+}
+
+// multipartBodyOf returns the streaming body of the `multipart` block of the model, or nil when the
+// request has none.
+func multipartBodyOf(ctx context.Context, model HTTPRequestResourceModel) (*entities.MultipartBody, error) {
+	if model.Multipart.IsNull() {
+		return nil, nil
+	}
+
+	var diagnostics diag.Diagnostics
+	parts, _ := multipartPartsOf(ctx, model.Multipart, &diagnostics)
+	converted := make([]entities.MultipartPart, 0, len(parts))
+	for _, part := range parts {
+		converted = append(converted, entities.MultipartPart{
+			Name:        part.Name.ValueString(),
+			Value:       part.Value.ValueString(),
+			File:        part.File.ValueString(),
+			Filename:    part.Filename.ValueString(),
+			ContentType: part.ContentType.ValueString(),
+			Headers:     stringMapOf(ctx, part.Headers, &diagnostics),
+		})
+	}
+	if diagnostics.HasError() {
+		return nil, fmt.Errorf("invalid multipart block: %v", diagnostics.Errors())
+	}
+
+	body, err := entities.NewMultipartBody(ctx, converted)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return body, nil
+}
+
+// validateMultipart rejects a `multipart` block without parts, a part that holds both or neither
+// of `value` and `file`, and a `content_type` that would replace the boundary. Unknown values pass,
+// except that an unknown `value` or `file` still counts as set.
+func validateMultipart(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var multipart types.Object
+	var contentType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrMultipart), &multipart)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrContentType), &contentType)...)
+	if resp.Diagnostics.HasError() || multipart.IsNull() {
+		return
+	}
+
+	if !contentType.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrContentType),
+			"Conflicting content type",
+			"A `multipart` body is sent as `multipart/form-data` with its own boundary; set the type of "+
+				"each part with the `content_type` of its `part` block instead.",
+		)
+	}
+
+	parts, known := multipartPartsOf(ctx, multipart, &resp.Diagnostics)
+	if !known || resp.Diagnostics.HasError() {
+		return
+	}
+	if len(parts) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrMultipart),
+			"Invalid multipart",
+			"A `multipart` block needs at least one `part` block.",
+		)
+
+		return
+	}
+
+	for index, part := range parts {
+		if part.Value.IsNull() == part.File.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attrMultipart).AtName(attrPart).AtListIndex(index),
+				"Invalid multipart",
+				fmt.Sprintf("The %q part must set exactly one of `value` and `file`.", part.Name.ValueString()),
+			)
+		}
+	}
+}
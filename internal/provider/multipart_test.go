@@ -0,0 +1,450 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// formEcho answers every request with the fields and files of its multipart form, one per line
+// and sorted, each file with its name, type and content, followed by its Content-Length.
+func formEcho(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(describeForm(r)))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// describeForm parses the multipart form of a request into the lines formEcho answers with.
+func describeForm(r *http.Request) string {
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		return "unparsable: " + err.Error()
+	}
+
+	var lines []string
+	for name, values := range r.MultipartForm.Value {
+		lines = append(lines, name+"="+strings.Join(values, ","))
+	}
+	for name, headers := range r.MultipartForm.File {
+		for _, header := range headers {
+			content, _ := header.Open()
+			data, _ := io.ReadAll(content)
+			_ = content.Close()
+			lines = append(lines, fmt.Sprintf(
+				"%s=%s:%s:%s", name, header.Filename, header.Header.Get("Content-Type"), data,
+			))
+		}
+	}
+	sort.Strings(lines)
+
+	return strings.Join(append(lines, fmt.Sprintf("length=%d", r.ContentLength)), "\n")
+}
+
+// multipartObject returns the `multipart` block holding the parts given.
+func multipartObject(t *testing.T, parts ...multipartPartModel) types.Object {
+	t.Helper()
+
+	list, diagnostics := types.ListValueFrom(
+		context.Background(), types.ObjectType{AttrTypes: multipartPartAttrTypes()}, parts,
+	)
+	require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+
+	return types.ObjectValueMust(multipartObjectAttrTypes(), map[string]attr.Value{attrPart: list})
+}
+
+// multipartConfigValue returns the configuration of a `multipart` block whose parts set the
+// attributes given, leaving the others null.
+func multipartConfigValue(t *testing.T, parts ...map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objectType, ok := GetHTTPRequestResourceSchema().Type().TerraformType(context.Background()).(tftypes.Object)
+	require.True(t, ok, "the resource schema must describe an object")
+	multipartType, _ := objectType.AttributeTypes[attrMultipart].(tftypes.Object)
+	listType, _ := multipartType.AttributeTypes[attrPart].(tftypes.List)
+	partType, _ := listType.ElementType.(tftypes.Object)
+
+	values := make([]tftypes.Value, 0, len(parts))
+	for _, part := range parts {
+		attributes := make(map[string]tftypes.Value, len(partType.AttributeTypes))
+		for name, attributeType := range partType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for name, value := range part {
+			attributes[name] = value
+		}
+		values = append(values, tftypes.NewValue(partType, attributes))
+	}
+
+	return tftypes.NewValue(multipartType, map[string]tftypes.Value{attrPart: tftypes.NewValue(listType, values)})
+}
+
+// fieldPart returns a form field part.
+func fieldPart(name, value string) multipartPartModel {
+	return multipartPartModel{
+		Name:        types.StringValue(name),
+		Value:       types.StringValue(value),
+		File:        types.StringNull(),
+		Filename:    types.StringNull(),
+		ContentType: types.StringNull(),
+		Headers:     types.MapNull(types.StringType),
+	}
+}
+
+// filePart returns a part holding the content of a file.
+func filePart(name, file string) multipartPartModel {
+	part := fieldPart(name, "")
+	part.Value = types.StringNull()
+	part.File = types.StringValue(file)
+
+	return part
+}
+
+func TestMultipart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send the fields and the files of the parts with the length of the body", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := formEcho(t)
+		report := filePart("report", writeBodyFile(t, "report.csv", []byte("id,name;1,widget")))
+		report.ContentType = types.StringValue("text/csv")
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Multipart = multipartObject(t,
+			fieldPart("channel", "releases"),
+			report,
+			filePart("logo", writeBodyFile(t, "logo.bin", []byte("binary"))),
+		)
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := (&HTTPRequestResource{}).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		lines := strings.Split(string(exchange.body), "\n")
+		require.Len(t, lines, 4, "form: %s", exchange.body)
+		assert.Equal(t, []string{
+			"channel=releases",
+			"logo=logo.bin:application/octet-stream:binary",
+			"report=report.csv:text/csv:id,name;1,widget",
+		}, lines[:3])
+		assert.Regexp(t, `^length=[1-9][0-9]*$`, lines[3])
+	})
+
+	t.Run("should announce the boundary over a content type set in the headers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := bodyEcho(t)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Headers = resourceHeaderMap(t, map[string]string{"Content-Type": "application/json"})
+		model.Multipart = multipartObject(t, fieldPart("channel", "releases"))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := (&HTTPRequestResource{}).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.True(t, strings.HasPrefix(string(exchange.body), "multipart/form-data; boundary="))
+	})
+
+	t.Run("should send the whole body again when the request is retried", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var hits int64
+		forms := make(chan string, 2)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			forms <- describeForm(r)
+			if atomic.AddInt64(&hits, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		t.Cleanup(server.Close)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Retry = retryObject(types.Int64Value(1), types.Int64Value(1), types.Int64Value(1))
+		model.Multipart = multipartObject(t, filePart("artifact", writeBodyFile(t, "build.zip", pngSignature)))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := (&HTTPRequestResource{}).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, exchange.statusCode)
+		first, second := <-forms, <-forms
+		assert.Contains(t, first, "artifact=build.zip:application/octet-stream:"+string(pngSignature))
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("should sign the hash of the body streamed from the files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		received := make(chan [2]string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodyHash := sha256.Sum256(body)
+			mac := hmac.New(sha256.New, []byte("whsec"))
+			mac.Write([]byte(hex.EncodeToString(bodyHash[:])))
+			received <- [2]string{r.Header.Get("X-Signature"), hex.EncodeToString(mac.Sum(nil))}
+		}))
+		t.Cleanup(server.Close)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.HMACSignature = hmacSignatureObject(map[string]string{attrTemplate: "${body_hash}"})
+		model.Multipart = multipartObject(t,
+			fieldPart("channel", "releases"),
+			filePart("artifact", writeBodyFile(t, "build.zip", pngSignature)),
+		)
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := resourceWithProviderAuth(nil, nil, nil).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		signatures := <-received
+		assert.NotEmpty(t, signatures[0])
+		assert.Equal(t, signatures[1], signatures[0], "the signature covers the body that was sent")
+	})
+
+	t.Run("should fail before sending when a file does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var hits int64
+		server := sequenceServer(t, &hits, []int{http.StatusOK}, []string{""})
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Multipart = multipartObject(t, filePart("artifact", filepath.Join(t.TempDir(), "build.zip")))
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := (&HTTPRequestResource{}).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.False(t, ok)
+		assert.Equal(t, int64(0), atomic.LoadInt64(&hits))
+	})
+
+	t.Run("should leave the parts out of the requests derived from the resource", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := pollingModel("https://example.test", types.ObjectNull(waitForObjectAttrTypes()))
+		model.Multipart = multipartObject(t, fieldPart("channel", "releases"))
+		model.RefreshRequestBody = types.StringNull()
+
+		// when
+		refreshModel := makeRefreshModel(model, "/files/1")
+
+		// then
+		assert.True(t, refreshModel.Multipart.IsNull())
+	})
+}
+
+func TestMultipartRequestBodyHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should plan the hash of the files of the parts", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		file := writeBodyFile(t, "document.json", []byte(`{"name":"widget"}`))
+		req := hashPlanRequest(t, map[string]tftypes.Value{
+			attrMultipart: multipartConfigValue(t,
+				map[string]tftypes.Value{
+					attrName:  tftypes.NewValue(tftypes.String, "channel"),
+					attrValue: tftypes.NewValue(tftypes.String, "releases"),
+				},
+				map[string]tftypes.Value{
+					attrName: tftypes.NewValue(tftypes.String, "document"),
+					attrFile: tftypes.NewValue(tftypes.String, file),
+				},
+			),
+		}, nil)
+		resp := &planmodifier.StringResponse{PlanValue: types.StringUnknown()}
+
+		// when
+		requestBodyHashModifier{}.PlanModifyString(context.Background(), req, resp)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		assert.Equal(t, widgetHash, resp.PlanValue.ValueString())
+	})
+
+	t.Run("should leave the hash unknown while the file of a part is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := hashPlanRequest(t, map[string]tftypes.Value{
+			attrMultipart: multipartConfigValue(t, map[string]tftypes.Value{
+				attrName: tftypes.NewValue(tftypes.String, "document"),
+				attrFile: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+		}, nil)
+		resp := &planmodifier.StringResponse{PlanValue: types.StringUnknown()}
+
+		// when
+		requestBodyHashModifier{}.PlanModifyString(context.Background(), req, resp)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		assert.True(t, resp.PlanValue.IsUnknown())
+	})
+
+	t.Run("should fail when the file of a part changed after the plan", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		file := writeBodyFile(t, "document.json", []byte(`{"name":"gadget"}`))
+		model := HTTPRequestResourceModel{
+			Multipart:       multipartObject(t, filePart("document", file)),
+			RequestBodyHash: types.StringValue(widgetHash),
+			IgnoreChanges:   types.SetNull(types.StringType),
+		}
+
+		// when
+		diagnostics := prepareRequestBody(context.Background(), resourceConfigWith(t, nil), &model)
+
+		// then
+		require.True(t, diagnostics.HasError())
+		assert.Equal(t, "Request body changed after plan", diagnostics[0].Summary())
+	})
+}
+
+func TestValidateMultipart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should accept parts that each set a value or a file", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := resource.ValidateConfigRequest{Config: resourceConfigWith(t, map[string]tftypes.Value{
+			attrMultipart: multipartConfigValue(t,
+				map[string]tftypes.Value{
+					attrName:  tftypes.NewValue(tftypes.String, "channel"),
+					attrValue: tftypes.NewValue(tftypes.String, "releases"),
+				},
+				map[string]tftypes.Value{
+					attrName: tftypes.NewValue(tftypes.String, "artifact"),
+					attrFile: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				},
+			),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateMultipart(context.Background(), req, resp)
+
+		// then
+		assert.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+	})
+
+	t.Run("should reject a part that sets both a value and a file", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := resource.ValidateConfigRequest{Config: resourceConfigWith(t, map[string]tftypes.Value{
+			attrMultipart: multipartConfigValue(t, map[string]tftypes.Value{
+				attrName:  tftypes.NewValue(tftypes.String, "artifact"),
+				attrValue: tftypes.NewValue(tftypes.String, "inline"),
+				attrFile:  tftypes.NewValue(tftypes.String, "build.zip"),
+			}),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateMultipart(context.Background(), req, resp)
+
+		// then
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Invalid multipart", resp.Diagnostics[0].Summary())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), `"artifact"`)
+	})
+
+	t.Run("should reject a block without parts", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := resource.ValidateConfigRequest{Config: resourceConfigWith(t, map[string]tftypes.Value{
+			attrMultipart: multipartConfigValue(t),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateMultipart(context.Background(), req, resp)
+
+		// then
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Invalid multipart", resp.Diagnostics[0].Summary())
+	})
+
+	t.Run("should reject a content type that would replace the boundary", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := resource.ValidateConfigRequest{Config: resourceConfigWith(t, map[string]tftypes.Value{
+			attrContentType: tftypes.NewValue(tftypes.String, "application/json"),
+			attrMultipart: multipartConfigValue(t, map[string]tftypes.Value{
+				attrName:  tftypes.NewValue(tftypes.String, "channel"),
+				attrValue: tftypes.NewValue(tftypes.String, "releases"),
+			}),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateMultipart(context.Background(), req, resp)
+
+		// then
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Conflicting content type", resp.Diagnostics[0].Summary())
+	})
+
+	t.Run("should reject a multipart block together with a request body", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := resource.ValidateConfigRequest{Config: resourceConfigWith(t, map[string]tftypes.Value{
+			attrRequestBody: tftypes.NewValue(tftypes.String, `{"name":"widget"}`),
+			attrMultipart: multipartConfigValue(t, map[string]tftypes.Value{
+				attrName:  tftypes.NewValue(tftypes.String, "channel"),
+				attrValue: tftypes.NewValue(tftypes.String, "releases"),
+			}),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateRequestBody(context.Background(), req, resp)
+
+		// then
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "`multipart`")
+	})
+}
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -17,6 +20,19 @@ import (
 
 const (
 	attrBasicAuth        = "basic_auth"
+	attrBearerAuth       = "bearer_auth"
+	attrToken            = "token"
+	attrAPIKey           = "api_key"
+	attrName             = "name"
+	attrValue            = "value"
+	attrIn               = "in"
+	attrOAuth2           = "oauth2"
+	attrTokenURL         = "token_url"
+	attrClientID         = "client_id"
+	attrClientSecret     = "client_secret"
+	attrScopes           = "scopes"
+	attrAudience         = "audience"
+	attrExtraParams      = "extra_params"
 	attrIgnoreTLS        = "ignore_tls"
 	attrUsername         = "username"
 	attrPassword         = "password"
@@ -27,6 +43,22 @@ const (
 	attrMaxDelayMs       = "max_delay_ms"
 )
 
+const (
+	attrMaxIdleConns      = "max_idle_conns"
+	attrIdleConnTimeoutMs = "idle_conn_timeout_ms"
+	attrKeepAlive         = "keep_alive"
+)
+
+// Descriptions of the connection pool arguments.
+const (
+	descMaxIdleConns = "The maximum number of idle connections kept open for reuse, in total and per " +
+		"host, since a configuration usually talks to a single API. Defaults to `100`."
+	descIdleConnTimeoutMs = "How long an idle connection is kept open for reuse, in milliseconds. " +
+		"Defaults to `90000`."
+	descKeepAlive = "Whether connections are kept open and reused across requests. Set it to `false` " +
+		"to open a new connection for every request. Defaults to `true`."
+)
+
 // Descriptions for the retry/timeout knobs. They are shared between the provider
 // and the resource schema builders (same package) so the wording lives in one
 // place and the two schemas cannot drift apart.
@@ -42,7 +74,8 @@ const (
 		"destroy request and the read an import issues. They are applied BEFORE each resource's own " +
 		"`headers`, so a resource that names the same header overrides the value here; the override is " +
 		"case-insensitive, as header names are. Intended for credentials an API expects in a header " +
-		"rather than in `basic_auth` (a bearer token, an API-key header): the import identifier is the " +
+		"rather than in `basic_auth` and that `bearer_auth` and `api_key` do not cover (a tenant or " +
+		"signature header): the import identifier is the " +
 		"only thing `ImportState` receives, so a credential kept in a resource's `headers` cannot reach " +
 		"the read an import performs, while one set here can. Never written to state, and there is no " +
 		"environment-variable equivalent -- unlike the `basic_auth` scalars, a map has no unambiguous " +
@@ -51,8 +84,9 @@ const (
 
 // Ensure HTTPProvider satisfies various provider interfaces.
 var (
-	_ provider.Provider              = &HTTPProvider{}
-	_ provider.ProviderWithFunctions = &HTTPProvider{}
+	_ provider.Provider                       = &HTTPProvider{}
+	_ provider.ProviderWithFunctions          = &HTTPProvider{}
+	_ provider.ProviderWithEphemeralResources = &HTTPProvider{}
 )
 
 // HTTPProvider defines the provider implementation.
@@ -62,12 +96,28 @@ type HTTPProvider struct {
 
 // HTTPProviderModel describes the provider data model.
 type HTTPProviderModel struct {
-	URL              types.String `tfsdk:"url"                json:"url"`
-	BasicAuth        types.Object `tfsdk:"basic_auth"         json:"basic_auth"`
-	Headers          types.Map    `tfsdk:"headers"            json:"-"`
-	IgnoreTLS        types.Bool   `tfsdk:"ignore_tls"         json:"-"`
-	RequestTimeoutMs types.Int64  `tfsdk:"request_timeout_ms" json:"-"`
-	Retry            types.Object `tfsdk:"retry"              json:"-"`
+	URL                   types.String `tfsdk:"url"                     json:"url"`
+	BasicAuth             types.Object `tfsdk:"basic_auth"              json:"basic_auth"`
+	BearerAuth            types.Object `tfsdk:"bearer_auth"             json:"-"`
+	DigestAuth            types.Object `tfsdk:"digest_auth"             json:"-"`
+	APIKey                types.Object `tfsdk:"api_key"                 json:"-"`
+	OAuth2                types.Object `tfsdk:"oauth2"                  json:"-"`
+	AWSSigV4              types.Object `tfsdk:"aws_sigv4"               json:"-"`
+	Headers               types.Map    `tfsdk:"headers"                 json:"-"`
+	IgnoreTLS             types.Bool   `tfsdk:"ignore_tls"              json:"-"`
+	RequestTimeoutMs      types.Int64  `tfsdk:"request_timeout_ms"      json:"-"`
+	Retry                 types.Object `tfsdk:"retry"                   json:"-"`
+	MaxIdleConns          types.Int64  `tfsdk:"max_idle_conns"          json:"-"`
+	IdleConnTimeoutMs     types.Int64  `tfsdk:"idle_conn_timeout_ms"    json:"-"`
+	KeepAlive             types.Bool   `tfsdk:"keep_alive"              json:"-"`
+	RateLimit             types.Object `tfsdk:"rate_limit"              json:"-"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests" json:"-"`
+	ThrottlePerHost       types.Bool   `tfsdk:"throttle_per_host"       json:"-"`
+	Session               types.Object `tfsdk:"session"                 json:"-"`
+	Endpoints             types.List   `tfsdk:"endpoint"                json:"-"`
+	tlsArguments
+	proxyArguments
+	redirectArguments
 }
 
 func New(version string) func() provider.Provider {
@@ -79,7 +129,7 @@ func New(version string) func() provider.Provider {
 }
 
 func GetHTTPProviderSchema() schema.Schema {
-	return schema.Schema{
+	providerSchema := schema.Schema{
 		Description: "The HTTP provider allows you to interact with Web endpoints using HTTP requests. " +
 			"It is useful for interacting with RESTful APIs, webhooks, and other HTTP-based services.",
 		MarkdownDescription: "The HTTP provider allows you to interact with Web endpoints using HTTP requests. " +
@@ -88,10 +138,10 @@ func GetHTTPProviderSchema() schema.Schema {
 			"url": schema.StringAttribute{
 				Description: "The base URL for all HTTP requests made by this provider. " +
 					"This URL serves as the root endpoint for the Web endpoint that the provider will interact with. " +
-					"This is optional when base_url is specified at the resource level.",
+					"This is optional when base_url is specified at the resource level." + descUnixSocketURL,
 				MarkdownDescription: "The base URL for all HTTP requests made by this provider. " +
 					"This URL serves as the root endpoint for the Web endpoint that the provider will interact with. " +
-					"This is optional when base_url is specified at the resource level.",
+					"This is optional when base_url is specified at the resource level." + descUnixSocketURL,
 				Optional: true,
 				// TODO: Validators: []validator.String{validators.NewStringNotEmpty("url")},
 			},
@@ -123,6 +173,120 @@ func GetHTTPProviderSchema() schema.Schema {
 					},
 				},
 			},
+			attrBearerAuth: schema.SingleNestedAttribute{
+				Description: "Bearer token authentication, sent as \"Authorization: Bearer <token>\" on every " +
+					"request made by this provider. It takes precedence over digest_auth and basic_auth when they are " +
+					"set, and a resource's own basic_auth, bearer_auth or digest_auth overrides it.",
+				MarkdownDescription: "Bearer token authentication, sent as `Authorization: Bearer <token>` on every " +
+					"request made by this provider. It takes precedence over `digest_auth` and `basic_auth` when they are " +
+					"set, and a resource's own `basic_auth`, `bearer_auth` or `digest_auth` overrides it.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					attrToken: schema.StringAttribute{
+						Description:         "The bearer token. Can also be set with the PROVIDER_HTTP_BEARER_TOKEN environment variable.",
+						MarkdownDescription: "The bearer token. Can also be set with the `PROVIDER_HTTP_BEARER_TOKEN` environment variable.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			attrDigestAuth: schema.SingleNestedAttribute{
+				Description:         descDigestAuthProvider,
+				MarkdownDescription: descDigestAuthProvider,
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					attrUsername: schema.StringAttribute{
+						Description:         descDigestUsername,
+						MarkdownDescription: descDigestUsername,
+						Required:            true,
+					},
+					attrPassword: schema.StringAttribute{
+						Description:         descDigestPassword,
+						MarkdownDescription: descDigestPassword,
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			attrAPIKey: schema.SingleNestedAttribute{
+				Description: "API key authentication applied to every request made by this provider. " +
+					"A resource's own api_key overrides it.",
+				MarkdownDescription: "API key authentication applied to every request made by this provider. " +
+					"A resource's own `api_key` overrides it.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					attrName: schema.StringAttribute{
+						Description: "The name of the header or query parameter carrying the key (e.g. X-API-Key). " +
+							"Can also be set with the PROVIDER_HTTP_API_KEY_NAME environment variable.",
+						MarkdownDescription: "The name of the header or query parameter carrying the key (e.g. `X-API-Key`). " +
+							"Can also be set with the `PROVIDER_HTTP_API_KEY_NAME` environment variable.",
+						Optional: true,
+					},
+					attrValue: schema.StringAttribute{
+						Description:         "The API key. Can also be set with the PROVIDER_HTTP_API_KEY environment variable.",
+						MarkdownDescription: "The API key. Can also be set with the `PROVIDER_HTTP_API_KEY` environment variable.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					attrIn: schema.StringAttribute{
+						Description: "Where the key is sent: header (the default) or query. " +
+							"Can also be set with the PROVIDER_HTTP_API_KEY_IN environment variable.",
+						MarkdownDescription: descAPIKeyIn + " Can also be set with the `PROVIDER_HTTP_API_KEY_IN` " +
+							"environment variable.",
+						Optional: true,
+					},
+				},
+			},
+			attrOAuth2: schema.SingleNestedAttribute{
+				Description: "OAuth 2.0 client credentials authentication. The provider obtains a token from " +
+					"token_url, caches it until shortly before it expires and sends it as \"Authorization: Bearer " +
+					"<token>\" on every request, including destroy and the read an import issues. A request " +
+					"rejected with 401 is sent once more with a freshly fetched token. It takes precedence over " +
+					"the provider-level bearer_auth, digest_auth and basic_auth, and a resource's own basic_auth, " +
+					"bearer_auth or digest_auth overrides it.",
+				MarkdownDescription: "OAuth 2.0 client credentials authentication. The provider obtains a token from " +
+					"`token_url`, caches it until shortly before it expires and sends it as `Authorization: Bearer " +
+					"<token>` on every request, including destroy and the read an import issues. A request " +
+					"rejected with `401` is sent once more with a freshly fetched token. It takes precedence over " +
+					"the provider-level `bearer_auth`, `digest_auth` and `basic_auth`, and a resource's own `basic_auth`, " +
+					"`bearer_auth` or `digest_auth` overrides it.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					attrTokenURL: schema.StringAttribute{
+						Description:         "The absolute URL of the token endpoint.",
+						MarkdownDescription: "The absolute URL of the token endpoint.",
+						Required:            true,
+					},
+					attrClientID: schema.StringAttribute{
+						Description:         "The client identifier.",
+						MarkdownDescription: "The client identifier.",
+						Required:            true,
+					},
+					attrClientSecret: schema.StringAttribute{
+						Description:         "The client secret.",
+						MarkdownDescription: "The client secret.",
+						Required:            true,
+						Sensitive:           true,
+					},
+					attrScopes: schema.ListAttribute{
+						Description:         "Scopes to request, sent space-separated in the scope parameter.",
+						MarkdownDescription: "Scopes to request, sent space-separated in the `scope` parameter.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					attrAudience: schema.StringAttribute{
+						Description:         "The audience parameter some authorization servers require.",
+						MarkdownDescription: "The `audience` parameter some authorization servers require.",
+						Optional:            true,
+					},
+					attrExtraParams: schema.MapAttribute{
+						Description:         "Additional parameters added to the token request as they are.",
+						MarkdownDescription: "Additional parameters added to the token request as they are.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
 			attrHeaders: schema.MapAttribute{
 				Description:         descHeadersProvider,
 				MarkdownDescription: descHeadersProvider,
@@ -141,12 +305,29 @@ func GetHTTPProviderSchema() schema.Schema {
 					"It is optional and defaults to `false`.",
 				Optional: true,
 			},
-			attrRequestTimeoutMs: providerOptionalInt64(descRequestTimeoutMsProvider),
+			attrRequestTimeoutMs:  providerOptionalInt64(descRequestTimeoutMsProvider),
+			attrMaxIdleConns:      providerOptionalInt64(descMaxIdleConns),
+			attrIdleConnTimeoutMs: providerOptionalInt64(descIdleConnTimeoutMs),
+			attrKeepAlive: schema.BoolAttribute{
+				Description:         descKeepAlive,
+				MarkdownDescription: descKeepAlive,
+				Optional:            true,
+			},
 		},
 		Blocks: map[string]schema.Block{
-			attrRetry: retryBlock(),
+			attrRetry:     retryBlock(),
+			attrRateLimit: rateLimitBlock(),
+			attrSession:   sessionBlock(),
+			attrEndpoint:  endpointBlock(),
 		},
 	}
+	addProviderTLSAttributes(providerSchema.Attributes)
+	addProviderProxyAttributes(providerSchema.Attributes)
+	addProviderRedirectAttributes(providerSchema.Attributes)
+	addProviderThrottleAttributes(providerSchema.Attributes)
+	addProviderAWSSigV4Attribute(providerSchema.Attributes)
+
+	return providerSchema
 }
 
 // providerOptionalInt64 builds an optional provider-level Int64 attribute,
@@ -159,6 +340,86 @@ func providerOptionalInt64(description string) schema.Int64Attribute {
 	}
 }
 
+// providerOptionalBool builds an optional provider-level Bool attribute,
+// keeping `Description` and `MarkdownDescription` in sync.
+func providerOptionalBool(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description:         description,
+		MarkdownDescription: description,
+		Optional:            true,
+	}
+}
+
+// providerOptionalString builds an optional provider-level String attribute,
+// keeping `Description` and `MarkdownDescription` in sync.
+func providerOptionalString(description string, sensitive bool) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description:         description,
+		MarkdownDescription: description,
+		Optional:            true,
+		Sensitive:           sensitive,
+	}
+}
+
+// addProviderTLSAttributes adds the TLS arguments, which every request made by this provider uses
+// unless a resource overrides them.
+func addProviderTLSAttributes(attrs map[string]schema.Attribute) {
+	attrs[attrCACertPEM] = providerOptionalString(descCACertPEM, false)
+	attrs[attrCACertFile] = providerOptionalString(descCACertFile, false)
+	attrs[attrClientCertPEM] = providerOptionalString(descClientCertPEM, false)
+	attrs[attrClientKeyPEM] = providerOptionalString(descClientKeyPEM, true)
+	attrs[attrClientCertFile] = providerOptionalString(descClientCertFile, false)
+	attrs[attrClientKeyFile] = providerOptionalString(descClientKeyFile, false)
+	attrs[attrTLSServerName] = providerOptionalString(descTLSServerName, false)
+	attrs[attrMinTLSVersion] = providerOptionalString(descMinTLSVersion, false)
+}
+
+// addProviderProxyAttributes adds the proxy arguments to the provider schema.
+func addProviderProxyAttributes(attrs map[string]schema.Attribute) {
+	attrs[attrProxyURL] = providerOptionalString(descProxyURL, false)
+	attrs[attrProxyBasicAuth] = schema.SingleNestedAttribute{
+		Description:         descProxyBasicAuth,
+		MarkdownDescription: descProxyBasicAuth,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			attrUsername: schema.StringAttribute{
+				Description:         "The username for the proxy.",
+				MarkdownDescription: "The username for the proxy.",
+				Required:            true,
+			},
+			attrPassword: schema.StringAttribute{
+				Description:         "The password for the proxy.",
+				MarkdownDescription: "The password for the proxy.",
+				Required:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+	attrs[attrNoProxy] = schema.ListAttribute{
+		ElementType:         types.StringType,
+		Description:         descNoProxy,
+		MarkdownDescription: descNoProxy,
+		Optional:            true,
+	}
+}
+
+// addProviderRedirectAttributes adds the redirect arguments, which every request made by this
+// provider uses unless a resource overrides them.
+func addProviderRedirectAttributes(attrs map[string]schema.Attribute) {
+	optionalBool := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description:         description,
+			MarkdownDescription: description,
+			Optional:            true,
+		}
+	}
+
+	attrs[attrFollowRedirects] = optionalBool(descFollowRedirects)
+	attrs[attrMaxRedirects] = providerOptionalInt64(descMaxRedirects)
+	attrs[attrPreserveMethodOnRedirect] = optionalBool(descPreserveMethodOnRedirect)
+	attrs[attrForwardAuthOnRedirect] = optionalBool(descForwardAuthOnRedirect)
+}
+
 // retryBlock returns the provider-level `retry` block. It mirrors the upstream
 // hashicorp/http provider's retry semantics: retries are attempted on connection
 // errors and on 5xx (except 501) responses, with an exponential backoff bounded
@@ -175,6 +436,16 @@ func retryBlock() schema.SingleNestedBlock {
 			attrAttempts:   providerOptionalInt64(descRetryAttempts),
 			attrMinDelayMs: providerOptionalInt64(descRetryMinDelayMs),
 			attrMaxDelayMs: providerOptionalInt64(descRetryMaxDelayMs),
+			attrRetryOnStatusCodes: schema.SetAttribute{
+				Description:         descRetryOnStatusCodes,
+				MarkdownDescription: descRetryOnStatusCodes,
+				Optional:            true,
+				ElementType:         types.Int32Type,
+			},
+			attrRespectRetryAfter:  providerOptionalBool(descRespectRetryAfter),
+			attrJitter:             providerOptionalBool(descJitter),
+			attrRetryNonIdempotent: providerOptionalBool(descRetryNonIdempotent),
+			attrRetryOnBodyMatch:   providerOptionalString(descRetryOnBodyMatch, false),
 		},
 	}
 }
@@ -235,6 +506,42 @@ func (it *HTTPProvider) ValidateConfig(
 			)
 		}
 	}
+
+	if !model.APIKey.IsNull() && !model.APIKey.IsUnknown() {
+		if in, ok := model.APIKey.Attributes()[attrIn].(types.String); ok {
+			checkAPIKeyLocation(path.Root(attrAPIKey).AtName(attrIn), in, &resp.Diagnostics)
+		}
+	}
+
+	checkTLSArguments(model.tlsArguments, &resp.Diagnostics)
+	checkProxyArguments(model.proxyArguments, &resp.Diagnostics)
+	checkRedirectArguments(model.redirectArguments, &resp.Diagnostics)
+	checkConnectionPoolArguments(model, &resp.Diagnostics)
+	checkThrottleArguments(model, &resp.Diagnostics)
+	checkSessionArguments(model, &resp.Diagnostics)
+	checkEndpointArguments(ctx, model, &resp.Diagnostics)
+	checkRetryArguments(ctx, model.Retry, &resp.Diagnostics)
+}
+
+// checkConnectionPoolArguments reports a negative `max_idle_conns` or `idle_conn_timeout_ms`. Unknown
+// values pass.
+func checkConnectionPoolArguments(model HTTPProviderModel, diagnostics *diag.Diagnostics) {
+	for _, setting := range []struct {
+		name  string
+		value types.Int64
+	}{
+		{attrMaxIdleConns, model.MaxIdleConns},
+		{attrIdleConnTimeoutMs, model.IdleConnTimeoutMs},
+	} {
+		if setting.value.IsNull() || setting.value.IsUnknown() || setting.value.ValueInt64() >= 0 {
+			continue
+		}
+		diagnostics.AddAttributeError(
+			path.Root(setting.name),
+			"Invalid connection pool setting",
+			fmt.Sprintf("`%s` must be zero or more, got %d.", setting.name, setting.value.ValueInt64()),
+		)
+	}
 }
 
 func (it *HTTPProvider) Configure(
@@ -292,6 +599,11 @@ func (it *HTTPProvider) Configure(
 			Password: password,
 		}
 	}
+	internal.Config.BearerAuth = providerBearerAuth(model.BearerAuth)
+	internal.Config.DigestAuth = digestAuthOf(model.DigestAuth)
+	internal.Config.APIKey = providerAPIKey(model.APIKey, &resp.Diagnostics)
+	internal.Config.OAuth2 = providerOAuth2(ctx, model.OAuth2, &resp.Diagnostics)
+	internal.Config.AWSSigV4 = providerAWSSigV4(model.AWSSigV4, &resp.Diagnostics)
 	internal.Config.Headers = stringMapOf(ctx, model.Headers, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -300,9 +612,39 @@ func (it *HTTPProvider) Configure(
 		internal.Config.RequestTimeoutMs = model.RequestTimeoutMs.ValueInt64()
 	}
 	internal.Config.Retry = retryConfigFromObject(model.Retry)
+	internal.Config.Endpoints = providerEndpoints(ctx, model.Endpoints, &resp.Diagnostics)
+
+	// the token request of oauth2 goes through this client too, so it honors the TLS and proxy arguments
+	transport := providerTransport(model, internal.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if transport != nil {
+		internal.Client.Transport = transport
+	}
+	redirect := redirectPolicyOf(entities.DefaultRedirectPolicy(), model.redirectArguments)
+	internal.Config.Redirect = &redirect
+	internal.Client.CheckRedirect = redirect.CheckRedirect
+	if throttle := throttleConfigOf(model); throttle != nil {
+		internal.Config.Throttle = throttle
+		internal.Throttle = entities.NewThrottle(*throttle)
+	}
+	// the login goes through the client configured above, so it honors the TLS, proxy, redirect and
+	// throttling arguments
+	if session := providerSession(ctx, model.Session, url, &resp.Diagnostics); session != nil {
+		internal.Config.Session = session
+		var err error
+		if internal.Session, err = newSession(*session, internal); err != nil {
+			resp.Diagnostics.AddError("Invalid session configuration for HTTP client", err.Error())
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.ResourceData = internal
 	resp.DataSourceData = internal
+	resp.EphemeralResourceData = internal
 
 	tflog.Info(ctx, "Configured HTTP client...", map[string]any{"success": true})
 }
@@ -314,9 +656,21 @@ func (it *HTTPProvider) Resources(context.Context) []func() resource.Resource {
 }
 
 func (it *HTTPProvider) DataSources(context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewHTTPRequestDataSource,
+	}
+}
+
+func (it *HTTPProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewHTTPRequestEphemeralResource,
+	}
 }
 
 func (it *HTTPProvider) Functions(context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewJSONPathFunction,
+		NewJSONPathAllFunction,
+		NewURLFunction,
+	}
 }
@@ -47,8 +47,20 @@ func fullProviderType() tftypes.Object {
 		WithIgnoreTLS().
 		WithUsername().
 		WithPassword().
+		WithBearerAuth().
+		WithDigestAuth().
+		WithAPIKey().
+		WithOAuth2().
+		WithAWSSigV4().
 		WithRequestTimeoutMs().
 		WithRetry().
+		WithTLS().
+		WithProxy().
+		WithRedirects().
+		WithConnectionPool().
+		WithThrottle().
+		WithSession().
+		WithEndpoints().
 		Build()
 }
 
@@ -63,14 +75,25 @@ func nullHeadersValue() tftypes.Value {
 func nullRetryValue() tftypes.Value {
 	return tftypes.NewValue(
 		tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-			"attempts":     tftypes.Number,
-			"min_delay_ms": tftypes.Number,
-			"max_delay_ms": tftypes.Number,
+			"attempts":              tftypes.Number,
+			"min_delay_ms":          tftypes.Number,
+			"max_delay_ms":          tftypes.Number,
+			"retry_on_status_codes": tftypes.Set{ElementType: tftypes.Number},
+			"respect_retry_after":   tftypes.Bool,
+			"jitter":                tftypes.Bool,
+			"retry_non_idempotent":  tftypes.Bool,
+			"retry_on_body_match":   tftypes.String,
 		}},
 		nil,
 	)
 }
 
+// nullProviderAttributeOf returns a null value of the named provider attribute, typed after
+// fullProviderType so the two cannot disagree.
+func nullProviderAttributeOf(name string) tftypes.Value {
+	return tftypes.NewValue(fullProviderType().AttributeTypes[name], nil)
+}
+
 // basicAuthObjectType is the tftypes shape of the `basic_auth` attribute.
 func basicAuthObjectType() tftypes.Object {
 	return tftypes.Object{
@@ -108,14 +131,36 @@ func nullBasicAuthValue() tftypes.Value {
 // attribute, so every one needs a typed value even when null -- and spelling all six out per case is
 // what made these blocks near-identical.
 func fullProviderValues(url, basicAuth tftypes.Value) map[string]tftypes.Value {
-	return map[string]tftypes.Value{
+	values := map[string]tftypes.Value{
 		"url":                url,
 		"basic_auth":         basicAuth,
+		"bearer_auth":        nullProviderAttributeOf("bearer_auth"),
+		"digest_auth":        nullProviderAttributeOf("digest_auth"),
+		"api_key":            nullProviderAttributeOf("api_key"),
+		"oauth2":             nullProviderAttributeOf("oauth2"),
+		"aws_sigv4":          nullProviderAttributeOf("aws_sigv4"),
 		"headers":            nullHeadersValue(),
 		"ignore_tls":         tftypes.NewValue(tftypes.Bool, nil),
 		"request_timeout_ms": tftypes.NewValue(tftypes.Number, nil),
 		"retry":              nullRetryValue(),
 	}
+	for _, name := range []string{
+		"ca_cert_pem", "ca_cert_file", "client_cert_pem", "client_key_pem",
+		"client_cert_file", "client_key_file", "tls_server_name", "min_tls_version",
+	} {
+		values[name] = tftypes.NewValue(tftypes.String, nil)
+	}
+	for _, name := range []string{
+		"proxy_url", "proxy_basic_auth", "no_proxy",
+		"follow_redirects", "max_redirects", "preserve_method_on_redirect", "forward_auth_on_redirect",
+		"max_idle_conns", "idle_conn_timeout_ms", "keep_alive",
+		"rate_limit", "max_concurrent_requests", "throttle_per_host", "session",
+		"endpoint",
+	} {
+		values[name] = nullProviderAttributeOf(name)
+	}
+
+	return values
 }
 
 // validateConfigOf runs ValidateConfig over a raw provider value and returns the diagnostics.
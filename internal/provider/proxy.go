@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+const (
+	attrProxyURL       = "proxy_url"
+	attrProxyBasicAuth = "proxy_basic_auth"
+	attrNoProxy        = "no_proxy"
+)
+
+// Descriptions of the proxy arguments, shared by the provider and the resource schema builders.
+const (
+	descProxyURL = "URL of the proxy requests are sent through, with an `http`, `https`, `socks5` or " +
+		"`socks5h` scheme. When unset, the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment " +
+		"variables apply. Requests to localhost and loopback addresses are never proxied."
+	descProxyBasicAuth = "Credentials sent to the proxy, as `Proxy-Authorization` basic authentication " +
+		"for an HTTP proxy or as the username and password of a SOCKS5 one. Requires `proxy_url`."
+	descNoProxy = "Hosts reached directly rather than through the proxy, in the format of the " +
+		"`NO_PROXY` environment variable: host names, domain suffixes such as `.internal`, IP " +
+		"addresses, CIDR ranges, or `*` for every host."
+)
+
+// proxyArguments are the proxy arguments the provider and the resource share. Both models embed
+// them.
+type proxyArguments struct {
+	ProxyURL       types.String `tfsdk:"proxy_url"        json:"-"`
+	ProxyBasicAuth types.Object `tfsdk:"proxy_basic_auth" json:"-"`
+	NoProxy        types.List   `tfsdk:"no_proxy"         json:"-"`
+}
+
+// proxyBasicAuthObjectAttrTypes returns the attribute types of the `proxy_basic_auth` nested
+// object.
+func proxyBasicAuthObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrUsername: types.StringType,
+		attrPassword: types.StringType,
+	}
+}
+
+// nullProxyArguments returns proxy arguments that are all null, as a model without them holds.
+func nullProxyArguments() proxyArguments {
+	return proxyArguments{
+		ProxyURL:       types.StringNull(),
+		ProxyBasicAuth: types.ObjectNull(proxyBasicAuthObjectAttrTypes()),
+		NoProxy:        types.ListNull(types.StringType),
+	}
+}
+
+// addProxyAttributes adds the proxy arguments to the resource schema. Like the TLS arguments they
+// only change how a request is transported, so none of them forces replacement.
+func addProxyAttributes(attrs map[string]schema.Attribute) {
+	const override = " When specified, this overrides the provider-level value."
+
+	attrs[attrProxyURL] = helpers.StringAttributeNoReplace(false, descProxyURL+override+
+		" Set `no_proxy = [\"*\"]` instead to bypass a provider-level proxy.")
+	attrs[attrProxyBasicAuth] = schema.SingleNestedAttribute{
+		Description:         descProxyBasicAuth + override,
+		MarkdownDescription: descProxyBasicAuth + override,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			attrUsername: schema.StringAttribute{
+				Description:         "The username for the proxy.",
+				MarkdownDescription: "The username for the proxy.",
+				Required:            true,
+			},
+			attrPassword: schema.StringAttribute{
+				Description:         "The password for the proxy.",
+				MarkdownDescription: "The password for the proxy.",
+				Required:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+	attrs[attrNoProxy] = schema.ListAttribute{
+		ElementType:         types.StringType,
+		Description:         descNoProxy + override,
+		MarkdownDescription: descNoProxy + override,
+		Optional:            true,
+	}
+}
+
+// validateProxy checks the proxy arguments of the resource with checkProxyArguments.
+func validateProxy(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	args := nullProxyArguments()
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrProxyURL), &args.ProxyURL)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrProxyBasicAuth), &args.ProxyBasicAuth)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkProxyArguments(args, &resp.Diagnostics)
+}
+
+// checkProxyArguments reports a `proxy_url` the transport cannot dial and `proxy_basic_auth`
+// without a `proxy_url` to send it to. Unknown values pass.
+func checkProxyArguments(args proxyArguments, diagnostics *diag.Diagnostics) {
+	if isKnownString(args.ProxyURL) {
+		if _, err := entities.ParseProxyURL(args.ProxyURL.ValueString()); err != nil {
+			diagnostics.AddAttributeError(path.Root(attrProxyURL), "Invalid proxy URL", err.Error())
+		}
+	}
+
+	if !args.ProxyBasicAuth.IsNull() && args.ProxyURL.IsNull() {
+		diagnostics.AddAttributeError(
+			path.Root(attrProxyBasicAuth),
+			"Incomplete proxy configuration",
+			"`proxy_basic_auth` is only sent to a proxy configured with `proxy_url` next to it.",
+		)
+	}
+}
+
+// proxyConfigOf resolves the proxy arguments. It returns nil when none of them is set.
+func proxyConfigOf(args proxyArguments) *entities.ProxyConfig {
+	cfg := &entities.ProxyConfig{
+		URL:      args.ProxyURL.ValueString(),
+		Username: objectStringOf(args.ProxyBasicAuth, attrUsername),
+		Password: objectStringOf(args.ProxyBasicAuth, attrPassword),
+	}
+	if !args.NoProxy.IsNull() && !args.NoProxy.IsUnknown() {
+		for _, element := range args.NoProxy.Elements() {
+			if host, ok := element.(types.String); ok && isKnownString(host) {
+				cfg.NoProxy = append(cfg.NoProxy, host.ValueString())
+			}
+		}
+	}
+
+	if cfg.IsEmpty() {
+		return nil
+	}
+
+	return cfg
+}
+
+// resolveProxy resolves the effective proxy with the precedence of resolveIgnoreTLS: a resource
+// `proxy_url` replaces the provider proxy along with its credentials, and a resource `no_proxy`
+// replaces the provider list. ownSettings reports whether the resource configures either; nil
+// means neither level does.
+func (it *HTTPRequestResource) resolveProxy(
+	model HTTPRequestResourceModel,
+) (resolved *entities.ProxyConfig, ownSettings bool) {
+	var merged entities.ProxyConfig
+	if config := it.providerConfig(); config != nil && config.Proxy != nil {
+		merged = *config.Proxy
+	}
+
+	own := proxyConfigOf(model.proxyArguments)
+	if own != nil {
+		if own.URL != "" {
+			merged.URL, merged.Username, merged.Password = own.URL, own.Username, own.Password
+		}
+		if !model.NoProxy.IsNull() {
+			merged.NoProxy = own.NoProxy
+		}
+	}
+
+	if merged.IsEmpty() {
+		return nil, false
+	}
+
+	return &merged, own != nil
+}
@@ -0,0 +1,227 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// resourceWithProviderProxy builds a resource whose provider configuration routes requests through
+// the given proxy arguments, the way Configure does.
+func resourceWithProviderProxy(t *testing.T, args proxyArguments) *HTTPRequestResource {
+	t.Helper()
+
+	var diagnostics diag.Diagnostics
+	model := HTTPProviderModel{
+		IgnoreTLS:      types.BoolNull(),
+		tlsArguments:   nullTLSArguments(),
+		proxyArguments: args,
+	}
+	internal := entities.NewInternalContext(false, entities.NewConfiguration("http://api.example.test"))
+	transport := providerTransport(model, internal.Config, &diagnostics)
+	require.False(t, diagnostics.HasError(), "the proxy arguments must be usable")
+	internal.Client.Transport = transport
+
+	return &HTTPRequestResource{internal: internal}
+}
+
+// proxyBasicAuthObject builds a `proxy_basic_auth` value.
+func proxyBasicAuthObject(username, password string) types.Object {
+	return types.ObjectValueMust(proxyBasicAuthObjectAttrTypes(), map[string]attr.Value{
+		attrUsername: types.StringValue(username),
+		attrPassword: types.StringValue(password),
+	})
+}
+
+// noProxyList builds a `no_proxy` value.
+func noProxyList(hosts ...string) types.List {
+	elements := make([]attr.Value, 0, len(hosts))
+	for _, host := range hosts {
+		elements = append(elements, types.StringValue(host))
+	}
+
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// proxyOf returns the proxy the resource would send a request to the URL through, nil meaning a
+// direct connection.
+func proxyOf(t *testing.T, it *HTTPRequestResource, model HTTPRequestResourceModel, target string) *url.URL {
+	t.Helper()
+
+	transport, err := it.resolveTransport(model)
+	require.NoError(t, err)
+	httpTransport, ok := transport.(*http.Transport)
+	require.True(t, ok, "a proxy needs a transport of its own")
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+	require.NoError(t, err)
+
+	proxyURL, err := httpTransport.Proxy(request)
+	require.NoError(t, err)
+
+	return proxyURL
+}
+
+func TestProxySettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send requests through the provider proxy with its credentials", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var requestURI, proxyAuthorization string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestURI = r.RequestURI
+			proxyAuthorization = r.Header.Get("Proxy-Authorization")
+			_, _ = io.WriteString(w, `{"id":"1"}`)
+		}))
+		t.Cleanup(proxy.Close)
+		args := nullProxyArguments()
+		args.ProxyURL = types.StringValue(proxy.URL)
+		args.ProxyBasicAuth = proxyBasicAuthObject("proxy-user", "proxy-pass")
+		it := resourceWithProviderProxy(t, args)
+		client, err := it.getHTTPClient(context.Background(), requestModel(types.MapNull(types.StringType)))
+		require.NoError(t, err)
+
+		// when
+		request, err := http.NewRequestWithContext(
+			context.Background(), http.MethodGet, "http://api.example.test/widgets", nil,
+		)
+		require.NoError(t, err)
+		response, err := client.Do(request)
+
+		// then
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		assert.Equal(t, "http://api.example.test/widgets", requestURI, "the proxy receives the absolute URL")
+		assert.Equal(t,
+			"Basic "+base64.StdEncoding.EncodeToString([]byte("proxy-user:proxy-pass")), proxyAuthorization)
+	})
+
+	t.Run("should reach the hosts in no_proxy directly", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullProxyArguments()
+		args.ProxyURL = types.StringValue("http://proxy.corp.test:3128")
+		args.NoProxy = noProxyList(".internal.test", "10.0.0.0/8")
+		it := resourceWithProviderProxy(t, args)
+		model := requestModel(types.MapNull(types.StringType))
+
+		// when
+		direct := proxyOf(t, it, model, "https://billing.internal.test/invoices")
+		directIP := proxyOf(t, it, model, "http://10.1.2.3/health")
+		proxied := proxyOf(t, it, model, "https://api.example.test/widgets")
+
+		// then
+		assert.Nil(t, direct)
+		assert.Nil(t, directIP)
+		require.NotNil(t, proxied)
+		assert.Equal(t, "proxy.corp.test:3128", proxied.Host)
+	})
+
+	t.Run("should let a resource proxy_url override the provider proxy and its credentials", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullProxyArguments()
+		args.ProxyURL = types.StringValue("http://proxy.corp.test:3128")
+		args.ProxyBasicAuth = proxyBasicAuthObject("provider-user", "provider-pass")
+		it := resourceWithProviderProxy(t, args)
+		model := requestModel(types.MapNull(types.StringType))
+		model.proxyArguments = nullProxyArguments()
+		model.ProxyURL = types.StringValue("socks5://egress.partner.test:1080")
+
+		// when
+		proxied := proxyOf(t, it, model, "https://partner.example.test/orders")
+
+		// then
+		require.NotNil(t, proxied)
+		assert.Equal(t, "socks5", proxied.Scheme)
+		assert.Equal(t, "egress.partner.test:1080", proxied.Host)
+		assert.Nil(t, proxied.User, "the provider credentials belong to the provider proxy")
+	})
+
+	t.Run("should let a resource bypass the provider proxy with no_proxy", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullProxyArguments()
+		args.ProxyURL = types.StringValue("http://proxy.corp.test:3128")
+		it := resourceWithProviderProxy(t, args)
+		model := requestModel(types.MapNull(types.StringType))
+		model.proxyArguments = nullProxyArguments()
+		model.NoProxy = noProxyList("*")
+
+		// when
+		proxied := proxyOf(t, it, model, "https://api.example.test/widgets")
+
+		// then
+		assert.Nil(t, proxied)
+	})
+}
+
+func TestCheckProxyArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a proxy scheme the transport cannot dial", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullProxyArguments()
+		args.ProxyURL = types.StringValue("ftp://proxy.corp.test")
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkProxyArguments(args, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid proxy URL", diagnostics[0].Summary())
+	})
+
+	t.Run("should reject proxy_basic_auth without proxy_url", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullProxyArguments()
+		args.ProxyBasicAuth = proxyBasicAuthObject("user", "pass")
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkProxyArguments(args, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Incomplete proxy configuration", diagnostics[0].Summary())
+	})
+
+	t.Run("should accept a SOCKS5 proxy with credentials", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullProxyArguments()
+		args.ProxyURL = types.StringValue("socks5h://egress.corp.test:1080")
+		args.ProxyBasicAuth = proxyBasicAuthObject("user", "pass")
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkProxyArguments(args, &diagnostics)
+
+		// then
+		assert.Empty(t, diagnostics)
+	})
+}
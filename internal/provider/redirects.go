@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+const (
+	attrFollowRedirects          = "follow_redirects"
+	attrMaxRedirects             = "max_redirects"
+	attrPreserveMethodOnRedirect = "preserve_method_on_redirect"
+	attrForwardAuthOnRedirect    = "forward_auth_on_redirect"
+	attrFinalURL                 = "final_url"
+)
+
+// Descriptions of the redirect arguments, shared by the provider and the resource schema builders.
+const (
+	descFollowRedirects = "Whether redirects are followed. When `false`, the redirect response itself " +
+		"is returned and, being neither successful nor listed in `tolerated_status_codes`, fails the " +
+		"request. Defaults to `true`."
+	descMaxRedirects             = "How many redirects a request follows before it fails. Defaults to `10`."
+	descPreserveMethodOnRedirect = "Whether a `301`, `302` or `303` redirect re-sends the original method " +
+		"and body instead of switching to a `GET` without a body. `307` and `308` always preserve them. " +
+		"Defaults to `false`."
+	descForwardAuthOnRedirect = "Whether the `Authorization` header follows a redirect to another host. " +
+		"By default it only follows redirects to the same host or one of its subdomains."
+)
+
+// redirectArguments are the redirect arguments the provider and the resource share. Both models
+// embed them.
+type redirectArguments struct {
+	FollowRedirects          types.Bool  `tfsdk:"follow_redirects"            json:"-"`
+	MaxRedirects             types.Int64 `tfsdk:"max_redirects"               json:"-"`
+	PreserveMethodOnRedirect types.Bool  `tfsdk:"preserve_method_on_redirect" json:"-"`
+	ForwardAuthOnRedirect    types.Bool  `tfsdk:"forward_auth_on_redirect"    json:"-"`
+}
+
+// nullRedirectArguments returns redirect arguments that are all null, as a model without them
+// holds.
+func nullRedirectArguments() redirectArguments {
+	return redirectArguments{
+		FollowRedirects:          types.BoolNull(),
+		MaxRedirects:             types.Int64Null(),
+		PreserveMethodOnRedirect: types.BoolNull(),
+		ForwardAuthOnRedirect:    types.BoolNull(),
+	}
+}
+
+// addRedirectAttributes adds the redirect arguments and the computed `final_url` to the resource
+// schema. Like `request_timeout_ms` the arguments only change how a request is sent, so none of
+// them forces replacement.
+func addRedirectAttributes(attrs map[string]schema.Attribute) {
+	const override = " When specified, this overrides the provider-level value."
+
+	attrs[attrFollowRedirects] = helpers.BoolAttributeNoReplace(false, descFollowRedirects+override)
+	attrs[attrMaxRedirects] = helpers.Int64AttributeNoReplace(false, descMaxRedirects+override)
+	attrs[attrPreserveMethodOnRedirect] = helpers.BoolAttributeNoReplace(false,
+		descPreserveMethodOnRedirect+override)
+	attrs[attrForwardAuthOnRedirect] = helpers.BoolAttributeNoReplace(false, descForwardAuthOnRedirect+override)
+	attrs[attrFinalURL] = helpers.ComputedStringAttribute(
+		"The URL the response recorded in `response_body` came from, after any redirect. An " +
+			"`api_key` sent in the query string and a password in the URL are left out.")
+}
+
+// validateRedirects checks the redirect arguments of the resource with checkRedirectArguments.
+func validateRedirects(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	args := nullRedirectArguments()
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrMaxRedirects), &args.MaxRedirects)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkRedirectArguments(args, &resp.Diagnostics)
+}
+
+// checkRedirectArguments reports a negative `max_redirects`. An unknown value passes.
+func checkRedirectArguments(args redirectArguments, diagnostics *diag.Diagnostics) {
+	if args.MaxRedirects.IsNull() || args.MaxRedirects.IsUnknown() {
+		return
+	}
+
+	if args.MaxRedirects.ValueInt64() < 0 {
+		diagnostics.AddAttributeError(
+			path.Root(attrMaxRedirects),
+			"Invalid max_redirects",
+			fmt.Sprintf("`max_redirects` must be zero or more, got %d.", args.MaxRedirects.ValueInt64()),
+		)
+	}
+}
+
+// redirectPolicyOf returns base with every redirect argument that is set applied over it.
+func redirectPolicyOf(base entities.RedirectPolicy, args redirectArguments) entities.RedirectPolicy {
+	if !args.FollowRedirects.IsNull() && !args.FollowRedirects.IsUnknown() {
+		base.Follow = args.FollowRedirects.ValueBool()
+	}
+	if !args.MaxRedirects.IsNull() && !args.MaxRedirects.IsUnknown() {
+		base.MaxRedirects = args.MaxRedirects.ValueInt64()
+	}
+	if !args.PreserveMethodOnRedirect.IsNull() && !args.PreserveMethodOnRedirect.IsUnknown() {
+		base.PreserveMethod = args.PreserveMethodOnRedirect.ValueBool()
+	}
+	if !args.ForwardAuthOnRedirect.IsNull() && !args.ForwardAuthOnRedirect.IsUnknown() {
+		base.ForwardAuth = args.ForwardAuthOnRedirect.ValueBool()
+	}
+
+	return base
+}
+
+// resolveRedirectPolicy resolves the effective redirect policy with the precedence of
+// resolveIgnoreTLS: each argument the resource sets wins over the provider-level one.
+func (it *HTTPRequestResource) resolveRedirectPolicy(model HTTPRequestResourceModel) entities.RedirectPolicy {
+	base := entities.DefaultRedirectPolicy()
+	if config := it.providerConfig(); config != nil && config.Redirect != nil {
+		base = *config.Redirect
+	}
+
+	return redirectPolicyOf(base, model.redirectArguments)
+}
+
+// finalURLOf returns the URL a response came from, for `final_url`. The API key is removed when it
+// travels in the query string, and a password in the URL is masked, so neither reaches state.
+func (it *HTTPRequestResource) finalURLOf(model HTTPRequestResourceModel, response *http.Response) string {
+	if response.Request == nil || response.Request.URL == nil {
+		return ""
+	}
+
+	finalURL := *response.Request.URL
+	if apiKey := it.resolveAPIKey(model); apiKey != nil && apiKey.In == entities.APIKeyInQuery {
+		query := finalURL.Query()
+		if query.Has(apiKey.Name) {
+			query.Del(apiKey.Name)
+			finalURL.RawQuery = query.Encode()
+		}
+	}
+
+	return finalURL.Redacted()
+}
@@ -0,0 +1,224 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// redirectingServer answers every path under /old with the given redirect to /new, and /new with
+// the method and body it received, recording the Authorization header that reached it.
+func redirectingServer(t *testing.T, status int, authorization *string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/old"):
+			http.Redirect(w, r, "/new", status)
+		case r.URL.Path == "/new":
+			if authorization != nil {
+				*authorization = r.Header.Get("Authorization")
+			}
+			body, _ := io.ReadAll(r.Body)
+			_, _ = io.WriteString(w, r.Method+" "+string(body))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// redirectModel builds a POST to /old on the server with no redirect argument set.
+func redirectModel(baseURL string) HTTPRequestResourceModel {
+	model := pollingModel(baseURL, types.ObjectNull(waitForObjectAttrTypes()))
+	model.Method = types.StringValue(http.MethodPost)
+	model.Path = types.StringValue("/old")
+	model.RequestBody = types.StringValue(`{"name":"widget"}`)
+	model.redirectArguments = nullRedirectArguments()
+
+	return model
+}
+
+func TestRedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should follow a redirect and record the final URL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := redirectingServer(t, http.StatusFound, nil)
+		it := &HTTPRequestResource{}
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), redirectModel(server.URL), &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, exchange.statusCode)
+		assert.Equal(t, server.URL+"/new", exchange.finalURL)
+		assert.Equal(t, "GET ", string(exchange.body), "a 302 turns the POST into a GET without a body")
+	})
+
+	t.Run("should return the redirect itself and fail when follow_redirects is false", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := redirectingServer(t, http.StatusFound, nil)
+		it := &HTTPRequestResource{}
+		model := redirectModel(server.URL)
+		model.FollowRedirects = types.BoolValue(false)
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+		accepted := ok && it.acceptExchange(context.Background(), model, exchange, &diagnostics)
+
+		// then
+		require.True(t, ok)
+		assert.Equal(t, http.StatusFound, exchange.statusCode)
+		assert.Equal(t, server.URL+"/old", exchange.finalURL)
+		assert.False(t, accepted, "an unexpected redirect fails loudly")
+	})
+
+	t.Run("should not let a retrying client follow a redirect the policy returned", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := redirectingServer(t, http.StatusFound, nil)
+		it := &HTTPRequestResource{}
+		model := redirectModel(server.URL)
+		model.FollowRedirects = types.BoolValue(false)
+		model.Retry = retryObject(types.Int64Value(2), types.Int64Value(1), types.Int64Value(2))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusFound, exchange.statusCode)
+	})
+
+	t.Run("should fail once max_redirects is exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := redirectingServer(t, http.StatusFound, nil)
+		config := entities.NewConfiguration("")
+		policy := entities.DefaultRedirectPolicy()
+		policy.MaxRedirects = 0
+		config.Redirect = &policy
+		it := &HTTPRequestResource{internal: entities.NewInternalContext(false, config)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := it.performRequest(context.Background(), redirectModel(server.URL), &diagnostics)
+
+		// then
+		require.False(t, ok)
+		assert.Contains(t, diagnostics[0].Detail(), "stopped after 0 redirects")
+	})
+
+	t.Run("should re-send the method and body when preserve_method_on_redirect is true", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := redirectingServer(t, http.StatusSeeOther, nil)
+		it := &HTTPRequestResource{}
+		model := redirectModel(server.URL)
+		model.PreserveMethodOnRedirect = types.BoolValue(true)
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, `POST {"name":"widget"}`, string(exchange.body))
+	})
+
+	t.Run("should forward the Authorization header to another host only when asked", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var authorization string
+		target := redirectingServer(t, http.StatusFound, &authorization)
+		// the same server under another host name, which the Go client treats as a foreign host
+		origin := httptest.NewServer(http.RedirectHandler(
+			strings.Replace(target.URL, "127.0.0.1", "localhost", 1)+"/new", http.StatusTemporaryRedirect,
+		))
+		t.Cleanup(origin.Close)
+		it := &HTTPRequestResource{}
+		model := redirectModel(origin.URL)
+		model.BearerAuth = bearerAuthObject("secret")
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, droppedOK := it.performRequest(context.Background(), model, &diagnostics)
+		dropped := authorization
+		model.ForwardAuthOnRedirect = types.BoolValue(true)
+		_, forwardedOK := it.performRequest(context.Background(), model, &diagnostics)
+		forwarded := authorization
+
+		// then
+		require.True(t, droppedOK && forwardedOK, "diagnostics: %v", diagnostics)
+		assert.Empty(t, dropped)
+		assert.Equal(t, "Bearer secret", forwarded)
+	})
+
+	t.Run("should leave a query API key out of the final URL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := redirectingServer(t, http.StatusFound, nil)
+		it := &HTTPRequestResource{}
+		model := redirectModel(server.URL)
+		model.Method = types.StringValue(http.MethodGet)
+		model.Path = types.StringValue("/new?page=2")
+		model.RequestBody = types.StringNull()
+		model.APIKey = apiKeyObject("api_key", "secret", "query")
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, server.URL+"/new?page=2", exchange.finalURL)
+	})
+}
+
+func TestCheckRedirectArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a negative max_redirects", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullRedirectArguments()
+		args.MaxRedirects = types.Int64Value(-1)
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkRedirectArguments(args, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid max_redirects", diagnostics[0].Summary())
+	})
+}
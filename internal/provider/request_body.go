@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+const (
+	attrRequestBodyFile   = "request_body_file"
+	attrRequestBodyBase64 = "request_body_base64"
+	attrContentType       = "content_type"
+	attrRequestBodyHash   = "request_body_hash"
+)
+
+// defaultBinaryContentType is the `Content-Type` of a body whose type nothing else tells.
+const defaultBinaryContentType = "application/octet-stream"
+
+// addRequestBodyAttributes adds the body sources that keep their content out of state, and the
+// hash that stands in for that content when a plan decides whether the request changed.
+//
+// `request_body_file` only stores the path, so a new path with the same content is no change; what
+// the content is comes from `request_body_hash`, which the plan computes from the file and the
+// decoded `request_body_base64` and which replaces the resource, like `request_body`, when it
+// changes.
+func addRequestBodyAttributes(attrs map[string]schema.Attribute) {
+	attrs[attrRequestBodyFile] = helpers.StringAttributeNoReplace(false,
+		"The path of a file whose content is sent as the request body, for a binary artifact or a "+
+			"document too large to inline. The file is read when the request is sent and only its path "+
+			"and `request_body_hash` are stored in state. Conflicts with `request_body` and "+
+			"`request_body_base64`.")
+	attrs[attrRequestBodyBase64] = helpers.StringAttributeWriteOnly(false,
+		"A base64-encoded body sent decoded, for binary content produced within the configuration. "+
+			"The argument is write-only, so neither the encoded nor the decoded bytes are stored in "+
+			"state. Conflicts with `request_body` and `request_body_file`.")
+	attrs[attrContentType] = replaceableStringAttribute(false,
+		"The `Content-Type` of the request body, which wins over one set in `headers`. Without it, the "+
+			"type of a `request_body_file` is guessed from its extension and a binary body is sent as "+
+			"`application/octet-stream`. It does not apply to `delete_request_body`, "+
+			"`refresh_request_body` or `update_request_body`, which take theirs from their own headers.")
+	attrs[attrRequestBodyHash] = schema.StringAttribute{
+		Computed: true,
+		Description: "The SHA-256 of the body read from `request_body_file` or decoded from " +
+			"`request_body_base64`, or of the files of the `multipart` parts in order, in hexadecimal. A " +
+			"change to the content is planned through it, and it is unknown when a file does not exist yet " +
+			"at plan time.",
+		MarkdownDescription: "The SHA-256 of the body read from `request_body_file` or decoded from " +
+			"`request_body_base64`, or of the files of the `multipart` parts in order, in hexadecimal. A " +
+			"change to the content is planned through it, and it is unknown when a file does not exist yet " +
+			"at plan time.",
+		PlanModifiers: []planmodifier.String{requestBodyHashModifier{}},
+	}
+}
+
+// requestBodyHashModifier plans `request_body_hash` from the configured body sources. It runs on
+// create as well, which is what lets the apply tell a file that changed after the plan.
+type requestBodyHashModifier struct{}
+
+func (requestBodyHashModifier) Description(context.Context) string {
+	return "Computes the hash of the request body from `request_body_file`, `request_body_base64` and " +
+		"the files of the `multipart` parts."
+}
+
+func (it requestBodyHashModifier) MarkdownDescription(ctx context.Context) string {
+	return it.Description(ctx)
+}
+
+func (requestBodyHashModifier) PlanModifyString(
+	ctx context.Context,
+	req planmodifier.StringRequest,
+	resp *planmodifier.StringResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var file, encoded types.String
+	var multipart types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrRequestBodyFile), &file)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrRequestBodyBase64), &encoded)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrMultipart), &multipart)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	partFiles, partsKnown := multipartFilesOf(ctx, multipart, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if file.IsUnknown() || encoded.IsUnknown() || !partsKnown {
+		resp.PlanValue = types.StringUnknown()
+	} else {
+		hash, err := hashRequestBody(file.ValueString(), encoded.ValueString(), partFiles)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// another resource may write the file during the apply
+			resp.PlanValue = types.StringUnknown()
+		case err != nil:
+			resp.Diagnostics.AddAttributeError(path.Root(attrRequestBodyFile), "Unreadable request body", err.Error())
+
+			return
+		default:
+			resp.PlanValue = hashValueOf(hash)
+		}
+	}
+
+	if req.State.Raw.IsNull() || resp.PlanValue.Equal(req.StateValue) {
+		return
+	}
+	resp.RequiresReplace = requiresReplacement(
+		ctx, attrRequestBodyHash, req.Config, req.Private, &resp.Diagnostics,
+	)
+}
+
+// hashValueOf returns the `request_body_hash` of a hash, null for a request without a body source.
+func hashValueOf(hash string) types.String {
+	if hash == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(hash)
+}
+
+// hashRequestBody returns the SHA-256 of the file, or of the files of the multipart parts one after
+// the other, streamed so a large artifact is never held in memory, or of the decoded base64 body,
+// and an empty string when none is given.
+func hashRequestBody(file, encoded string, partFiles []string) (string, error) {
+	digest := sha256.New()
+	switch {
+	case file != "":
+		if err := digestFile(digest, file); err != nil {
+			return "", fmt.Errorf("request_body_file: %w", err)
+		}
+	case len(partFiles) > 0:
+		for _, partFile := range partFiles {
+			if err := digestFile(digest, partFile); err != nil {
+				return "", fmt.Errorf("multipart file: %w", err)
+			}
+		}
+	case encoded != "":
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", fmt.Errorf("decoding request_body_base64: %w", err)
+		}
+		digest.Write(decoded)
+	default:
+		return "", nil
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// digestFile streams the content of a file into the digest.
+func digestFile(digest io.Writer, file string) error {
+	content, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", file, err)
+	}
+	defer func() { _ = content.Close() }()
+
+	if _, err = io.Copy(digest, content); err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// requestBodyOf returns the body the model sends, reading the file or decoding the base64 only
+// now so the content exists nowhere else, and whether it looks like JSON. It returns a nil body for
+// a request without one.
+func requestBodyOf(model HTTPRequestResourceModel) ([]byte, bool, error) {
+	switch {
+	case isNonEmptyString(model.RequestBodyFile):
+		content, err := os.ReadFile(model.RequestBodyFile.ValueString())
+		if err != nil {
+			return nil, false, fmt.Errorf("reading request_body_file: %w", err)
+		}
+
+		return content, false, nil
+	case isNonEmptyString(model.RequestBodyBase64):
+		decoded, err := base64.StdEncoding.DecodeString(model.RequestBodyBase64.ValueString())
+		if err != nil {
+			return nil, false, fmt.Errorf("decoding request_body_base64: %w", err)
+		}
+
+		return decoded, false, nil
+	case !model.RequestBody.IsNull():
+		send, looksJSON := coerceBodyString(model.RequestBody.ValueString())
+
+		return []byte(send), looksJSON, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// applyContentType writes `content_type` over the headers, or the type of a binary body when no
+// header gave one. A string body is left to applyDefaultJSONHeaders.
+func applyContentType(h http.Header, model HTTPRequestResourceModel) {
+	if isNonEmptyString(model.ContentType) {
+		h.Set("Content-Type", model.ContentType.ValueString())
+
+		return
+	}
+	if h.Get("Content-Type") != "" {
+		return
+	}
+
+	switch {
+	case isNonEmptyString(model.RequestBodyFile):
+		contentType := mime.TypeByExtension(filepath.Ext(model.RequestBodyFile.ValueString()))
+		if contentType == "" {
+			contentType = defaultBinaryContentType
+		}
+		h.Set("Content-Type", contentType)
+	case isNonEmptyString(model.RequestBodyBase64):
+		h.Set("Content-Type", defaultBinaryContentType)
+	}
+}
+
+// replaceRequestBody gives a request derived from the resource -- destroy, refresh, polling -- its
+// own body in place of the one create sends, including the body sources and the type of that body.
+func replaceRequestBody(model *HTTPRequestResourceModel, body types.String) {
+	model.RequestBody = body
+	model.RequestBodyFile = types.StringNull()
+	model.RequestBodyBase64 = types.StringNull()
+	model.ContentType = types.StringNull()
+	model.Multipart = types.ObjectNull(multipartObjectAttrTypes())
+}
+
+// prepareRequestBody restores the write-only `request_body_base64` from configuration and records
+// the hash of the body about to be sent.
+//
+// The plan computed the hash from the same sources, so a different one means the file changed
+// between the plan and the apply: sending it would apply something nobody reviewed, and recording
+// it would fail the apply as an inconsistent result anyway. An ignored `request_body_hash` keeps
+// the planned value, as every ignored argument does.
+func prepareRequestBody(ctx context.Context, config tfsdk.Config, model *HTTPRequestResourceModel) diag.Diagnostics {
+	var encoded types.String
+	diagnostics := config.GetAttribute(ctx, path.Root(attrRequestBodyBase64), &encoded)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+	model.RequestBodyBase64 = encoded
+
+	partFiles, _ := multipartFilesOf(ctx, model.Multipart, &diagnostics)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	hash, err := hashRequestBody(model.RequestBodyFile.ValueString(), encoded.ValueString(), partFiles)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root(attrRequestBodyFile), "Unreadable request body", err.Error())
+
+		return diagnostics
+	}
+
+	planned := model.RequestBodyHash
+	if !planned.IsUnknown() && isIgnoringRequestBodyHash(ctx, model.IgnoreChanges, &diagnostics) {
+		return diagnostics
+	}
+	model.RequestBodyHash = hashValueOf(hash)
+	if !planned.IsUnknown() && !planned.Equal(model.RequestBodyHash) {
+		diagnostics.AddAttributeError(
+			path.Root(attrRequestBodyFile),
+			"Request body changed after plan",
+			fmt.Sprintf("The files of the body no longer hold the content that was planned (SHA-256 %s, now %s). "+
+				"Plan again to send the new content.", planned.ValueString(), hash),
+		)
+	}
+
+	return diagnostics
+}
+
+// isIgnoringRequestBodyHash reports whether `ignore_changes` lists `request_body_hash`.
+func isIgnoringRequestBodyHash(ctx context.Context, ignoreChanges types.Set, diagnostics *diag.Diagnostics) bool {
+	for _, entry := range parseIgnoreEntries(ctx, ignoreChanges, diagnostics) {
+		if entry.Attribute == attrRequestBodyHash && len(entry.SubPath) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateRequestBody rejects more than one body source, `multipart` included, and a `request_body_base64` that does not
+// decode. Unknown values pass, except that an unknown source still counts as set.
+func validateRequestBody(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var body, file, encoded types.String
+	var multipart types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrRequestBody), &body)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrMultipart), &multipart)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrRequestBodyFile), &file)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrRequestBodyBase64), &encoded)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sources []string
+	for _, source := range []struct {
+		attribute string
+		value     types.String
+	}{
+		{attrRequestBody, body},
+		{attrRequestBodyFile, file},
+		{attrRequestBodyBase64, encoded},
+	} {
+		if !source.value.IsNull() {
+			sources = append(sources, "`"+source.attribute+"`")
+		}
+	}
+	if !multipart.IsNull() {
+		sources = append(sources, "`"+attrMultipart+"`")
+	}
+	if len(sources) > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting request bodies",
+			fmt.Sprintf("A request has a single body, but %s are set.", strings.Join(sources, " and ")),
+		)
+	}
+
+	if encoded.IsNull() || encoded.IsUnknown() {
+		return
+	}
+	if _, err := base64.StdEncoding.DecodeString(encoded.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrRequestBodyBase64),
+			"Invalid request_body_base64",
+			fmt.Sprintf("`request_body_base64` must be standard base64: %s.", err),
+		)
+	}
+}
@@ -0,0 +1,330 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngSignature is the first bytes of every PNG file, which no string body could carry.
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00}
+
+// widgetHash is the SHA-256 of the `{"name":"widget"}` document.
+const widgetHash = "256e2b36195d6c9d25b78bf0df70019cb60421b088cf96ca21e570fbfc34f6b2"
+
+// bodyEcho answers every request with its Content-Type and its body.
+func bodyEcho(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(append([]byte(r.Header.Get("Content-Type")+"|"), body...))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// writeBodyFile writes content to a file of the given name in a temporary directory.
+func writeBodyFile(t *testing.T, name string, content []byte) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, content, 0o600))
+
+	return file
+}
+
+// hashPlanRequest is the plan of `request_body_hash` for a configuration with the given body
+// sources, against a prior state holding stateHash, or for a create when stateHash is nil.
+func hashPlanRequest(t *testing.T, values map[string]tftypes.Value, stateHash *string) planmodifier.StringRequest {
+	t.Helper()
+
+	config := resourceConfigWith(t, values)
+	req := planmodifier.StringRequest{
+		Config:     config,
+		Plan:       tfsdk.Plan{Raw: config.Raw, Schema: config.Schema},
+		State:      tfsdk.State{Raw: tftypes.NewValue(config.Raw.Type(), nil), Schema: config.Schema},
+		StateValue: types.StringNull(),
+	}
+	if stateHash != nil {
+		req.State.Raw = config.Raw
+		req.StateValue = types.StringValue(*stateHash)
+	}
+
+	return req
+}
+
+func TestRequestBody(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send the content of the file with the type of its extension", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := bodyEcho(t)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.RequestBodyFile = types.StringValue(writeBodyFile(t, "logo.png", pngSignature))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := (&HTTPRequestResource{}).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, append([]byte("image/png|"), pngSignature...), exchange.body)
+	})
+
+	t.Run("should send the decoded base64 body with the content type over the headers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := bodyEcho(t)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Headers = resourceHeaderMap(t, map[string]string{"Content-Type": "text/plain"})
+		model.RequestBodyBase64 = types.StringValue(base64.StdEncoding.EncodeToString(pngSignature))
+		model.ContentType = types.StringValue("application/vnd.artifact")
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := (&HTTPRequestResource{}).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, append([]byte("application/vnd.artifact|"), pngSignature...), exchange.body)
+	})
+
+	t.Run("should send a binary body of unknown type as an octet stream", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := bodyEcho(t)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.RequestBodyFile = types.StringValue(writeBodyFile(t, "artifact", pngSignature))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := (&HTTPRequestResource{}).performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, append([]byte("application/octet-stream|"), pngSignature...), exchange.body)
+	})
+
+	t.Run("should leave the body file out of the requests derived from the resource", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := pollingModel("https://example.test", types.ObjectNull(waitForObjectAttrTypes()))
+		model.RequestBodyFile = types.StringValue("artifact.zip")
+		model.ContentType = types.StringValue("application/zip")
+		model.RefreshRequestBody = types.StringNull()
+
+		// when
+		refreshModel := makeRefreshModel(model, "/artifacts/1")
+
+		// then
+		assert.True(t, refreshModel.RequestBodyFile.IsNull())
+		assert.True(t, refreshModel.ContentType.IsNull())
+	})
+}
+
+func TestRequestBodyHashModifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should plan the hash of the file on create", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		file := writeBodyFile(t, "document.json", []byte(`{"name":"widget"}`))
+		req := hashPlanRequest(t, map[string]tftypes.Value{
+			attrRequestBodyFile: tftypes.NewValue(tftypes.String, file),
+		}, nil)
+		resp := &planmodifier.StringResponse{PlanValue: types.StringUnknown()}
+
+		// when
+		requestBodyHashModifier{}.PlanModifyString(context.Background(), req, resp)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		assert.Equal(t, widgetHash, resp.PlanValue.ValueString())
+		assert.False(t, resp.RequiresReplace)
+	})
+
+	t.Run("should replace the resource when the content of the file changed", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		previous := "0000000000000000000000000000000000000000000000000000000000000000"
+		file := writeBodyFile(t, "document.json", []byte(`{"name":"widget"}`))
+		req := hashPlanRequest(t, map[string]tftypes.Value{
+			attrRequestBodyFile: tftypes.NewValue(tftypes.String, file),
+		}, &previous)
+		resp := &planmodifier.StringResponse{PlanValue: req.StateValue}
+
+		// when
+		requestBodyHashModifier{}.PlanModifyString(context.Background(), req, resp)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		assert.NotEqual(t, previous, resp.PlanValue.ValueString())
+		assert.True(t, resp.RequiresReplace)
+	})
+
+	t.Run("should update in place when the content changed and an update method is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		previous := "0000000000000000000000000000000000000000000000000000000000000000"
+		req := hashPlanRequest(t, map[string]tftypes.Value{
+			attrRequestBodyBase64: tftypes.NewValue(tftypes.String, base64.StdEncoding.EncodeToString(pngSignature)),
+			attrUpdateMethod:      tftypes.NewValue(tftypes.String, "PUT"),
+		}, &previous)
+		resp := &planmodifier.StringResponse{PlanValue: req.StateValue}
+
+		// when
+		requestBodyHashModifier{}.PlanModifyString(context.Background(), req, resp)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		assert.NotEqual(t, previous, resp.PlanValue.ValueString())
+		assert.False(t, resp.RequiresReplace)
+	})
+
+	t.Run("should leave the hash unknown while the file does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := hashPlanRequest(t, map[string]tftypes.Value{
+			attrRequestBodyFile: tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "build.zip")),
+		}, nil)
+		resp := &planmodifier.StringResponse{PlanValue: types.StringUnknown()}
+
+		// when
+		requestBodyHashModifier{}.PlanModifyString(context.Background(), req, resp)
+
+		// then
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		assert.True(t, resp.PlanValue.IsUnknown())
+	})
+}
+
+func TestPrepareRequestBody(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should record the hash of the body when the plan could not know it", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		file := writeBodyFile(t, "document.json", []byte(`{"name":"widget"}`))
+		model := HTTPRequestResourceModel{
+			RequestBodyFile: types.StringValue(file),
+			RequestBodyHash: types.StringUnknown(),
+			IgnoreChanges:   types.SetNull(types.StringType),
+		}
+
+		// when
+		diagnostics := prepareRequestBody(context.Background(), resourceConfigWith(t, nil), &model)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.Equal(t, widgetHash, model.RequestBodyHash.ValueString())
+	})
+
+	t.Run("should restore the write-only base64 body from configuration", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		encoded := base64.StdEncoding.EncodeToString(pngSignature)
+		config := resourceConfigWith(t, map[string]tftypes.Value{
+			attrRequestBodyBase64: tftypes.NewValue(tftypes.String, encoded),
+		})
+		model := HTTPRequestResourceModel{
+			RequestBodyBase64: types.StringNull(),
+			RequestBodyHash:   types.StringUnknown(),
+			IgnoreChanges:     types.SetNull(types.StringType),
+		}
+
+		// when
+		diagnostics := prepareRequestBody(context.Background(), config, &model)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.Equal(t, encoded, model.RequestBodyBase64.ValueString())
+		assert.False(t, model.RequestBodyHash.IsNull())
+	})
+
+	t.Run("should fail when the file changed after the plan", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		file := writeBodyFile(t, "document.json", []byte(`{"name":"gadget"}`))
+		model := HTTPRequestResourceModel{
+			RequestBodyFile: types.StringValue(file),
+			RequestBodyHash: types.StringValue(widgetHash),
+			IgnoreChanges:   types.SetNull(types.StringType),
+		}
+
+		// when
+		diagnostics := prepareRequestBody(context.Background(), resourceConfigWith(t, nil), &model)
+
+		// then
+		require.True(t, diagnostics.HasError())
+		assert.Equal(t, "Request body changed after plan", diagnostics[0].Summary())
+	})
+}
+
+func TestValidateRequestBody(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a body file together with a request body", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := resource.ValidateConfigRequest{Config: resourceConfigWith(t, map[string]tftypes.Value{
+			attrRequestBody:     tftypes.NewValue(tftypes.String, `{"name":"widget"}`),
+			attrRequestBodyFile: tftypes.NewValue(tftypes.String, "document.json"),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateRequestBody(context.Background(), req, resp)
+
+		// then
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Conflicting request bodies", resp.Diagnostics[0].Summary())
+	})
+
+	t.Run("should reject a base64 body that does not decode", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		req := resource.ValidateConfigRequest{Config: resourceConfigWith(t, map[string]tftypes.Value{
+			attrRequestBodyBase64: tftypes.NewValue(tftypes.String, "not base64!"),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+
+		// when
+		validateRequestBody(context.Background(), req, resp)
+
+		// then
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Invalid request_body_base64", resp.Diagnostics[0].Summary())
+	})
+}
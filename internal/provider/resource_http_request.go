@@ -3,11 +3,10 @@ package provider
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	gopath "path"
@@ -51,30 +50,53 @@ var (
 // address the schema, the import payload's JSON keys and the adoption bookkeeping, and those three
 // must never drift apart.
 const (
-	attrMethod               = "method"
-	attrPath                 = "path"
-	attrHeaders              = "headers"
-	attrRequestBody          = "request_body"
-	attrIsResponseBodyJSON   = "is_response_body_json"
-	attrResponseBodyIDFilter = "response_body_id_filter"
-	attrQueryParameters      = "query_parameters"
-	attrToleratedStatusCodes = "tolerated_status_codes"
-	attrIgnoreChanges        = "ignore_changes"
-	attrBaseURL              = "base_url"
-	attrIsDeleteEnabled      = "is_delete_enabled"
-	attrDeleteMethod         = "delete_method"
-	attrDeletePath           = "delete_path"
-	attrDeleteHeaders        = "delete_headers"
-	attrDeleteRequestBody    = "delete_request_body"
-	attrDeleteResolvedPath   = "delete_resolved_path"
-	attrIsRefreshEnabled     = "is_refresh_enabled"
-	attrRefreshPath          = "refresh_path"
-	attrID                   = "id"
-	attrImportID             = "import_id"
-	attrResponseCode         = "response_code"
-	attrResponseBody         = "response_body"
-	attrResponseBodyID       = "response_body_id"
-	attrResponseBodyJSON     = "response_body_json"
+	attrMethod                 = "method"
+	attrPath                   = "path"
+	attrHeaders                = "headers"
+	attrRequestBody            = "request_body"
+	attrIsResponseBodyJSON     = "is_response_body_json"
+	attrResponseBodyIDFilter   = "response_body_id_filter"
+	attrQueryParameters        = "query_parameters"
+	attrToleratedStatusCodes   = "tolerated_status_codes"
+	attrIgnoreChanges          = "ignore_changes"
+	attrCaptureResponseHeaders = "capture_response_headers"
+	attrBaseURL                = "base_url"
+	attrIsDeleteEnabled        = "is_delete_enabled"
+	attrDeleteMethod           = "delete_method"
+	attrDeletePath             = "delete_path"
+	attrDeleteHeaders          = "delete_headers"
+	attrDeleteRequestBody      = "delete_request_body"
+	attrDeleteResolvedPath     = "delete_resolved_path"
+	attrIsRefreshEnabled       = "is_refresh_enabled"
+	attrRefreshPath            = "refresh_path"
+	attrRefreshMethod          = "refresh_method"
+	attrRefreshHeaders         = "refresh_headers"
+	attrRefreshRequestBody     = "refresh_request_body"
+	attrRefreshGoneStatusCodes = "refresh_gone_status_codes"
+	attrDriftDetection         = "drift_detection"
+	attrJSONPaths              = "json_paths"
+	attrResponseJSONPaths      = "response_json_paths"
+	attrDeleteWait             = "delete_wait"
+	attrGoneStatusCodes        = "gone_status_codes"
+	attrUpdateMethod           = "update_method"
+	attrUpdatePath             = "update_path"
+	attrUpdateHeaders          = "update_headers"
+	attrUpdateRequestBody      = "update_request_body"
+	attrWaitFor                = "wait_for"
+	attrSuccessJSONPath        = "success_json_path"
+	attrSuccessValue           = "success_value"
+	attrFailureJSONPath        = "failure_json_path"
+	attrFailureValue           = "failure_value"
+	attrIntervalMs             = "interval_ms"
+	attrTimeoutMs              = "timeout_ms"
+	attrCaptureResponse        = "capture_response"
+	attrID                     = "id"
+	attrImportID               = "import_id"
+	attrResponseCode           = "response_code"
+	attrResponseBody           = "response_body"
+	attrResponseBodyID         = "response_body_id"
+	attrResponseBodyJSON       = "response_body_json"
+	attrResponseHeaders        = "response_headers"
 )
 
 // Schema versions of the http_request resource. Version 2 is shape-identical to version 1; the
@@ -104,22 +126,36 @@ type HTTPRequestResource struct {
 // HTTPRequestResourceModel describes the resource data model.
 type HTTPRequestResourceModel struct {
 	// parameters
-	Method               types.String `tfsdk:"method"`
-	Path                 types.String `tfsdk:"path"`
-	Headers              types.Map    `tfsdk:"headers"`
-	RequestBody          types.String `tfsdk:"request_body"`
-	IsResponseBodyJSON   types.Bool   `tfsdk:"is_response_body_json"`
-	ResponseBodyIDFilter types.String `tfsdk:"response_body_id_filter"`
-	QueryParameters      types.Map    `tfsdk:"query_parameters"`
-	ToleratedStatusCodes types.Set    `tfsdk:"tolerated_status_codes"`
-	IgnoreChanges        types.Set    `tfsdk:"ignore_changes"`
+	Method                 types.String `tfsdk:"method"`
+	Path                   types.String `tfsdk:"path"`
+	Headers                types.Map    `tfsdk:"headers"`
+	RequestBody            types.String `tfsdk:"request_body"`
+	RequestBodyFile        types.String `tfsdk:"request_body_file"`
+	RequestBodyBase64      types.String `tfsdk:"request_body_base64"`
+	ContentType            types.String `tfsdk:"content_type"`
+	RequestBodyHash        types.String `tfsdk:"request_body_hash"`
+	Multipart              types.Object `tfsdk:"multipart"`
+	IsResponseBodyJSON     types.Bool   `tfsdk:"is_response_body_json"`
+	ResponseBodyIDFilter   types.String `tfsdk:"response_body_id_filter"`
+	QueryParameters        types.Map    `tfsdk:"query_parameters"`
+	ToleratedStatusCodes   types.Set    `tfsdk:"tolerated_status_codes"`
+	IgnoreChanges          types.Set    `tfsdk:"ignore_changes"`
+	CaptureResponseHeaders types.Set    `tfsdk:"capture_response_headers"`
 
 	// resource-level configuration (alternative to provider-level)
 	BaseURL          types.String `tfsdk:"base_url"`
+	Endpoint         types.String `tfsdk:"endpoint"`
 	BasicAuth        types.Object `tfsdk:"basic_auth"`
+	BearerAuth       types.Object `tfsdk:"bearer_auth"`
+	APIKey           types.Object `tfsdk:"api_key"`
+	DigestAuth       types.Object `tfsdk:"digest_auth"`
+	HMACSignature    types.Object `tfsdk:"hmac_signature"`
 	IgnoreTLS        types.Bool   `tfsdk:"ignore_tls"`
 	RequestTimeoutMs types.Int64  `tfsdk:"request_timeout_ms"`
 	Retry            types.Object `tfsdk:"retry"`
+	tlsArguments
+	proxyArguments
+	redirectArguments
 
 	// destroy controls
 	IsDeleteEnabled    types.Bool   `tfsdk:"is_delete_enabled"`
@@ -128,10 +164,25 @@ type HTTPRequestResourceModel struct {
 	DeleteHeaders      types.Map    `tfsdk:"delete_headers"`
 	DeleteRequestBody  types.String `tfsdk:"delete_request_body"`
 	DeleteResolvedPath types.String `tfsdk:"delete_resolved_path"`
+	DeleteWait         types.Object `tfsdk:"delete_wait"`
 
 	// refresh controls
-	IsRefreshEnabled types.Bool   `tfsdk:"is_refresh_enabled"`
-	RefreshPath      types.String `tfsdk:"refresh_path"`
+	IsRefreshEnabled       types.Bool   `tfsdk:"is_refresh_enabled"`
+	RefreshPath            types.String `tfsdk:"refresh_path"`
+	RefreshMethod          types.String `tfsdk:"refresh_method"`
+	RefreshHeaders         types.Map    `tfsdk:"refresh_headers"`
+	RefreshRequestBody     types.String `tfsdk:"refresh_request_body"`
+	RefreshGoneStatusCodes types.Set    `tfsdk:"refresh_gone_status_codes"`
+	DriftDetection         types.Object `tfsdk:"drift_detection"`
+
+	// update controls
+	UpdateMethod      types.String `tfsdk:"update_method"`
+	UpdatePath        types.String `tfsdk:"update_path"`
+	UpdateHeaders     types.Map    `tfsdk:"update_headers"`
+	UpdateRequestBody types.String `tfsdk:"update_request_body"`
+
+	// operation polling
+	WaitFor types.Object `tfsdk:"wait_for"`
 
 	// state
 	ID               types.String `tfsdk:"id"`
@@ -140,6 +191,8 @@ type HTTPRequestResourceModel struct {
 	ResponseBody     types.String `tfsdk:"response_body"`
 	ResponseBodyID   types.String `tfsdk:"response_body_id"`
 	ResponseBodyJSON types.Map    `tfsdk:"response_body_json"`
+	ResponseHeaders  types.Map    `tfsdk:"response_headers"`
+	FinalURL         types.String `tfsdk:"final_url"`
 }
 
 // Default retry delays, matching the upstream hashicorp/http provider behavior.
@@ -152,11 +205,14 @@ const (
 // It MUST be used wherever a typed null `retry` value is produced, otherwise the
 // framework raises a "missing type" conversion error.
 func retryObjectAttrTypes() map[string]attr.Type {
-	return map[string]attr.Type{
+	attrTypes := map[string]attr.Type{
 		attrAttempts:   types.Int64Type,
 		attrMinDelayMs: types.Int64Type,
 		attrMaxDelayMs: types.Int64Type,
 	}
+	maps.Copy(attrTypes, retryPolicyAttrTypes())
+
+	return attrTypes
 }
 
 // retryConfigFromObject converts a `retry` nested object into the domain
@@ -168,8 +224,9 @@ func retryConfigFromObject(obj types.Object) *entities.RetryConfig {
 	}
 
 	cfg := &entities.RetryConfig{
-		MinDelayMs: defaultRetryMinDelayMs,
-		MaxDelayMs: defaultRetryMaxDelayMs,
+		MinDelayMs:        defaultRetryMinDelayMs,
+		MaxDelayMs:        defaultRetryMaxDelayMs,
+		RespectRetryAfter: true,
 	}
 	attrs := obj.Attributes()
 	if v, ok := attrs[attrAttempts].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
@@ -181,6 +238,7 @@ func retryConfigFromObject(obj types.Object) *entities.RetryConfig {
 	if v, ok := attrs[attrMaxDelayMs].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
 		cfg.MaxDelayMs = v.ValueInt64()
 	}
+	applyRetryPolicy(attrs, cfg)
 
 	// Defensive clamping: keep delays sane regardless of user input.
 	if cfg.MinDelayMs < 0 {
@@ -199,12 +257,21 @@ func NewHTTPRequestResource() resource.Resource {
 func GetHTTPRequestResourceSchema() schema.Schema {
 	attrs := make(map[string]schema.Attribute)
 	addRequestAttributes(attrs)
+	addRequestBodyAttributes(attrs)
 	addResourceConfigAttributes(attrs)
 	addRetryTimeoutAttributes(attrs)
 	addDeleteControlAttributes(attrs)
 	addRefreshControlAttributes(attrs)
+	addUpdateControlAttributes(attrs)
 	addStateAttributes(attrs)
+	addResponseHeaderAttributes(attrs)
 	addImportHelperAttributes(attrs)
+	addAuthenticationAttributes(attrs)
+	addHMACSignatureAttribute(attrs)
+	addDigestAuthAttribute(attrs)
+	addTLSAttributes(attrs)
+	addProxyAttributes(attrs)
+	addRedirectAttributes(attrs)
 
 	return schema.Schema{
 		Version: schemaVersionV3,
@@ -214,7 +281,11 @@ func GetHTTPRequestResourceSchema() schema.Schema {
 			"HTTP request parameters and capturing the response details.",
 		Attributes: attrs,
 		Blocks: map[string]schema.Block{
-			attrRetry: resourceRetryBlock(),
+			attrRetry:          resourceRetryBlock(),
+			attrWaitFor:        resourceWaitForBlock(),
+			attrDriftDetection: resourceDriftDetectionBlock(),
+			attrDeleteWait:     resourceDeleteWaitBlock(),
+			attrMultipart:      resourceMultipartBlock(),
 		},
 	}
 }
@@ -265,6 +336,16 @@ func resourceRetryBlock() schema.SingleNestedBlock {
 			attrAttempts:   helpers.Int64AttributeNoReplace(false, descRetryAttempts),
 			attrMinDelayMs: helpers.Int64AttributeNoReplace(false, descRetryMinDelayMs),
 			attrMaxDelayMs: helpers.Int64AttributeNoReplace(false, descRetryMaxDelayMs),
+			attrRetryOnStatusCodes: schema.SetAttribute{
+				Description:         descRetryOnStatusCodes,
+				MarkdownDescription: descRetryOnStatusCodes,
+				Optional:            true,
+				ElementType:         types.Int32Type,
+			},
+			attrRespectRetryAfter:  helpers.BoolAttributeNoReplace(false, descRespectRetryAfter),
+			attrJitter:             helpers.BoolAttributeNoReplace(false, descJitter),
+			attrRetryNonIdempotent: helpers.BoolAttributeNoReplace(false, descRetryNonIdempotent),
+			attrRetryOnBodyMatch:   helpers.StringAttributeNoReplace(false, descRetryOnBodyMatch),
 		},
 	}
 }
@@ -309,7 +390,9 @@ func addRequestAttributes(attrs map[string]schema.Attribute) {
 func addResourceConfigAttributes(attrs map[string]schema.Attribute) {
 	attrs[attrBaseURL] = replaceableStringAttribute(false,
 		"The base URL for this specific HTTP request. When specified, this overrides the provider-level URL "+
-			"configuration. This allows for different APIs to be used within the same configuration.")
+			"configuration. This allows for different APIs to be used within the same configuration."+
+			descUnixSocketURL)
+	attrs[attrEndpoint] = replaceableStringAttribute(false, descEndpoint)
 	attrs[attrBasicAuth] = schema.SingleNestedAttribute{
 		Description: "Credentials for basic authentication for this specific request. " +
 			"When specified, this overrides the provider-level basic authentication configuration.",
@@ -340,14 +423,34 @@ func addResourceConfigAttributes(attrs map[string]schema.Attribute) {
 // write-only value would be unavailable exactly when refresh needs it.
 func addRefreshControlAttributes(attrs map[string]schema.Attribute) {
 	attrs[attrIsRefreshEnabled] = helpers.BoolAttributeNoReplace(false,
-		"Enables drift detection. When true, every refresh re-issues a GET against `refresh_path` "+
-			"(or `path`) and updates the captured response. A response that is neither successful nor "+
-			"listed in `tolerated_status_codes` removes the resource from state so it is planned for "+
-			"creation again. Defaults to false, which keeps the response captured at create time.")
+		"Enables drift detection. When true, every refresh sends `refresh_method` (a GET by default) to "+
+			"`refresh_path` (or `path`) and updates the captured response. A response listed in "+
+			"`refresh_gone_status_codes` removes the resource from state so it is planned for creation "+
+			"again; any other response that is neither successful nor listed in `tolerated_status_codes` "+
+			"fails the refresh. Defaults to false, which keeps the response captured at create time.")
 	attrs[attrRefreshPath] = helpers.StringAttributeNoReplace(false,
-		"Path to call when refreshing. Defaults to `path`. Supports the same inline JSONPath tokens "+
-			"as `delete_path` (e.g. \"/posts/$.id\"), evaluated against the captured `response_body`, "+
-			"which is what lets a resource created with POST refresh the object it created.")
+		"Path to call when refreshing. Defaults to `path`. Supports the same inline tokens as "+
+			"`delete_path` (e.g. \"/posts/$.id\"), evaluated against the captured `response_body` and "+
+			"`response_headers`, which is what lets a resource created with POST refresh the object it created.")
+	attrs[attrRefreshMethod] = helpers.StringAttributeNoReplace(false,
+		"HTTP method of the refresh request, e.g. POST for an API that reads an object through a search "+
+			"endpoint. Defaults to GET.")
+	attrs[attrRefreshHeaders] = helpers.MapAttributeNoReplace(false, types.StringType,
+		"Headers to send with the refresh request instead of `headers`. The provider `headers` still apply. "+
+			"Stored in state, since refresh receives no configuration; keep credentials in the provider "+
+			"`headers` instead.")
+	attrs[attrRefreshRequestBody] = helpers.StringAttributeNoReplace(false,
+		"Body to send with the refresh request. Defaults to none.")
+	attrs[attrRefreshGoneStatusCodes] = schema.SetAttribute{
+		Description: "HTTP status codes a refresh reads as \"the object was deleted\", which removes the " +
+			"resource from state. Defaults to [404, 410]. Any other unsuccessful status fails the refresh " +
+			"instead, so a transient 500 never drops the resource.",
+		MarkdownDescription: "HTTP status codes a refresh reads as \"the object was deleted\", which removes the " +
+			"resource from state. Defaults to `[404, 410]`. Any other unsuccessful status fails the refresh " +
+			"instead, so a transient `500` never drops the resource.",
+		Optional:    true,
+		ElementType: types.Int32Type,
+	}
 }
 
 func addDeleteControlAttributes(attrs map[string]schema.Attribute) {
@@ -358,7 +461,10 @@ func addDeleteControlAttributes(attrs map[string]schema.Attribute) {
 		"HTTP method to use during deletion (e.g., DELETE, POST). Defaults to DELETE.")
 	attrs[attrDeletePath] = helpers.StringAttributeWriteOnly(false,
 		"Path to call during deletion. Supports inline JSONPath tokens like \"/posts/$.data.id\" "+
-			"evaluated against the `response_body` from create.")
+			"evaluated against the `response_body` from create, and `${header.Name}` tokens (e.g. "+
+			"\"${header.Location}\", written `$${header.Location}` in HCL) evaluated against its headers; "+
+			"a header holding an absolute URL below the base URL contributes its path, and one elsewhere is "+
+			"requested as it is.")
 	attrs[attrDeleteHeaders] = helpers.MapAttributeWriteOnly(false, types.StringType,
 		"Headers to send only during deletion.")
 	attrs[attrDeleteRequestBody] = helpers.StringAttributeWriteOnly(false,
@@ -458,45 +564,85 @@ func (it *HTTPRequestResource) ValidateConfig(
 		return
 	}
 
-	if !isJSON.IsUnknown() && isJSON.ValueBool() &&
-		(filter.IsUnknown() || filter.IsNull() || strings.TrimSpace(filter.ValueString()) == "") {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("response_body_id_filter"),
-			"Since the response is JSON, the filter must be provided.",
-			"When the expected answer is a JSON, the ID must be parsed in the state. "+
-				"Please provide a filter to extract the ID from the JSON response. "+
-				"Refer to the documentation for more information (https://github.com/ohler55/ojg).",
-		)
+	checkResponseBodyIDFilter(isJSON, filter, &resp.Diagnostics)
+	validateStatusCodes(ctx, attrToleratedStatusCodes, req, resp)
+	validateStatusCodes(ctx, attrRefreshGoneStatusCodes, req, resp)
+	validateUpdateControls(ctx, req, resp)
+	validateWaitFor(ctx, req, resp)
+	validateDriftDetection(ctx, req, resp)
+	validateDeleteWait(ctx, req, resp)
+	validateAuthentication(ctx, req, resp)
+	validateHMACSignature(ctx, req, resp)
+	validateTLS(ctx, req, resp)
+	validateProxy(ctx, req, resp)
+	validateRedirects(ctx, req, resp)
+	validateRetry(ctx, req, resp)
+	validateRequestBody(ctx, req, resp)
+	validateMultipart(ctx, req, resp)
+}
+
+// validateRetry checks the `retry` block of the resource with checkRetryArguments.
+func validateRetry(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var retry types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrRetry), &retry)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	validateToleratedStatusCodes(ctx, req, resp)
+	checkRetryArguments(ctx, retry, &resp.Diagnostics)
 }
 
-func validateToleratedStatusCodes(
+// validateStatusCodes checks a set of HTTP status codes configured under the given attribute.
+func validateStatusCodes(
 	ctx context.Context,
+	attribute string,
 	req resource.ValidateConfigRequest,
 	resp *resource.ValidateConfigResponse,
 ) {
 	var codes types.Set
 	resp.Diagnostics.Append(
-		req.Config.GetAttribute(ctx, path.Root("tolerated_status_codes"), &codes)...,
+		req.Config.GetAttribute(ctx, path.Root(attribute), &codes)...,
 	)
-	if resp.Diagnostics.HasError() || codes.IsNull() || codes.IsUnknown() {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkStatusCodes(ctx, attribute, codes, &resp.Diagnostics)
+}
+
+// checkResponseBodyIDFilter requires a filter whenever the response is declared to be JSON. It is
+// shared by every type that processes a response, so they reject the same configurations.
+func checkResponseBodyIDFilter(isJSON types.Bool, filter types.String, diagnostics *diag.Diagnostics) {
+	if !isJSON.IsUnknown() && isJSON.ValueBool() &&
+		(filter.IsUnknown() || filter.IsNull() || strings.TrimSpace(filter.ValueString()) == "") {
+		diagnostics.AddAttributeError(
+			path.Root("response_body_id_filter"),
+			"Since the response is JSON, the filter must be provided.",
+			"When the expected answer is a JSON, the ID must be parsed in the state. "+
+				"Please provide a filter to extract the ID from the JSON response. "+
+				"Refer to the documentation for more information (https://github.com/ohler55/ojg).",
+		)
+	}
+}
+
+// checkStatusCodes rejects codes outside the HTTP status range.
+func checkStatusCodes(ctx context.Context, attribute string, codes types.Set, diagnostics *diag.Diagnostics) {
+	if codes.IsNull() || codes.IsUnknown() {
 		return
 	}
 
 	var values []int32
-	resp.Diagnostics.Append(codes.ElementsAs(ctx, &values, false)...)
-	if resp.Diagnostics.HasError() {
+	diagnostics.Append(codes.ElementsAs(ctx, &values, false)...)
+	if diagnostics.HasError() {
 		return
 	}
 
 	const minHTTPStatus, maxHTTPStatus = 100, 599
 	for _, code := range values {
 		if code < minHTTPStatus || code > maxHTTPStatus {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("tolerated_status_codes"),
-				"Invalid HTTP status code in tolerated_status_codes",
+			diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid HTTP status code in "+attribute,
 				fmt.Sprintf(
 					"Status code %d is outside the valid HTTP range (%d-%d).",
 					code, minHTTPStatus, maxHTTPStatus,
@@ -546,6 +692,7 @@ func (it *HTTPRequestResource) Create(
 
 	// WriteOnly attributes are nullified in the plan artifact. Re-read them from config.
 	resp.Diagnostics.Append(copyWriteOnlyDeleteParams(ctx, req.Config, &model)...)
+	resp.Diagnostics.Append(prepareRequestBody(ctx, req.Config, &model)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -559,7 +706,16 @@ func (it *HTTPRequestResource) Create(
 		return
 	}
 
-	populateResponseState(ctx, &model, exchange, &resp.Diagnostics)
+	// The request has already succeeded, so the remote object exists even when the operation it
+	// started fails. Its state is recorded regardless, which leaves the resource tainted rather
+	// than orphaned, and the polling errors are reported afterwards.
+	var waitDiagnostics diag.Diagnostics
+	captured, ok := it.awaitOperation(ctx, model, exchange, &waitDiagnostics)
+	if !ok {
+		captured = exchange
+	}
+
+	populateResponseState(ctx, &model, captured, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -572,6 +728,7 @@ func (it *HTTPRequestResource) Create(
 
 	// A resource that has just been created carries nothing over from an import.
 	resp.Diagnostics.Append(clearImportAdoptFromPrivate(ctx, resp.Private)...)
+	resp.Diagnostics.Append(waitDiagnostics...)
 
 	tflog.Info(ctx, "Completed HTTP request...", map[string]any{"success": true})
 }
@@ -581,7 +738,14 @@ func (it *HTTPRequestResource) Create(
 type httpExchange struct {
 	statusCode int
 	status     string
+	headers    http.Header
 	body       []byte
+	// endpoint is the URL the request was built for, empty for an exchange replayed from an import
+	// payload.
+	endpoint string
+	// finalURL is where the response came from after any redirect, empty for an exchange replayed
+	// from an import payload.
+	finalURL string
 }
 
 // performRequest issues the request described by the model and returns the drained exchange.
@@ -611,7 +775,14 @@ func (it *HTTPRequestResource) performRequest(
 		return nil, false
 	}
 
-	response, err := it.getHTTPClient(ctx, model).Do(request)
+	client, err := it.getHTTPClient(ctx, model)
+	if err != nil {
+		diagnostics.AddError("Error creating HTTP client...", err.Error())
+
+		return nil, false
+	}
+
+	response, err := it.doAuthenticated(ctx, client, model, endpoint, request)
 	if err != nil {
 		diagnostics.AddError("Error executing request using HTTP client...", err.Error())
 
@@ -634,7 +805,10 @@ func (it *HTTPRequestResource) performRequest(
 	return &httpExchange{
 		statusCode: response.StatusCode,
 		status:     response.Status,
+		headers:    response.Header,
 		body:       body,
+		endpoint:   endpoint,
+		finalURL:   it.finalURLOf(model, response),
 	}, true
 }
 
@@ -753,9 +927,9 @@ func (it *HTTPRequestResource) Read(
 // refreshFromRemote re-reads the resource and updates the captured response in place.
 //
 // It reports false when the caller must stop, either because the read failed or because the
-// resource is gone -- a status that is neither successful nor tolerated is taken as "no longer
-// there", so the resource is dropped from state and planned for creation again rather than left
-// pointing at something that no longer exists.
+// resource is gone -- a status listed in `refresh_gone_status_codes` is taken as "no longer there",
+// so the resource is dropped from state and planned for creation again rather than left pointing at
+// something that no longer exists. Any other unsuccessful, untolerated status is an error.
 func (it *HTTPRequestResource) refreshFromRemote(
 	ctx context.Context,
 	model *HTTPRequestResourceModel,
@@ -766,10 +940,7 @@ func (it *HTTPRequestResource) refreshFromRemote(
 		return false
 	}
 
-	refreshModel := *model
-	refreshModel.Method = types.StringValue(http.MethodGet)
-	refreshModel.Path = types.StringValue(path)
-	refreshModel.RequestBody = types.StringNull()
+	refreshModel := makeRefreshModel(*model, path)
 
 	exchange, ok := it.performRequest(ctx, refreshModel, &resp.Diagnostics)
 	if !ok {
@@ -778,6 +949,17 @@ func (it *HTTPRequestResource) refreshFromRemote(
 
 	if !exchange.isSuccessful() &&
 		!isStatusCodeTolerated(ctx, model.ToleratedStatusCodes, exchange.statusCode, &resp.Diagnostics) {
+		if !isGoneStatusCode(ctx, model.RefreshGoneStatusCodes, exchange.statusCode, &resp.Diagnostics) {
+			resp.Diagnostics.AddError(
+				"Refresh request failed",
+				fmt.Sprintf("%s %s answered %s, which is neither successful, tolerated nor listed in "+
+					"`refresh_gone_status_codes`. Response body: %s",
+					refreshModel.Method.ValueString(), path, exchange.status, string(exchange.body)),
+			)
+
+			return false
+		}
+
 		tflog.Info(ctx, "Refresh reported the resource is gone, removing it from state...",
 			map[string]any{"status": exchange.status, attrPath: path})
 		resp.State.RemoveResource(ctx)
@@ -786,10 +968,42 @@ func (it *HTTPRequestResource) refreshFromRemote(
 	}
 
 	populateResponseState(ctx, model, exchange, &resp.Diagnostics)
+	reconcileRequestBody(ctx, model, exchange.body, &resp.Diagnostics)
 
 	return !resp.Diagnostics.HasError()
 }
 
+// defaultGoneStatusCodes are the statuses that mean "deleted" when neither
+// `refresh_gone_status_codes` nor the `delete_wait` codes are set.
+var defaultGoneStatusCodes = []int{http.StatusNotFound, http.StatusGone}
+
+// makeRefreshModel derives the refresh request from the resource: the refresh method, headers and
+// body when configured, a bodiless GET carrying the create headers otherwise.
+func makeRefreshModel(model HTTPRequestResourceModel, refreshPath string) HTTPRequestResourceModel {
+	refreshModel := model
+	refreshModel.Method = types.StringValue(http.MethodGet)
+	if isNonEmptyString(model.RefreshMethod) {
+		refreshModel.Method = types.StringValue(strings.ToUpper(strings.TrimSpace(model.RefreshMethod.ValueString())))
+	}
+	refreshModel.Path = types.StringValue(refreshPath)
+	replaceRequestBody(&refreshModel, model.RefreshRequestBody)
+	if !model.RefreshHeaders.IsNull() && !model.RefreshHeaders.IsUnknown() {
+		refreshModel.Headers = model.RefreshHeaders
+	}
+
+	return refreshModel
+}
+
+// isGoneStatusCode reports whether a status means the remote object was deleted, according to the
+// given codes or, when they are unset, to defaultGoneStatusCodes.
+func isGoneStatusCode(ctx context.Context, goneCodes types.Set, statusCode int, diagnostics *diag.Diagnostics) bool {
+	if goneCodes.IsNull() || goneCodes.IsUnknown() {
+		return slices.Contains(defaultGoneStatusCodes, statusCode)
+	}
+
+	return isStatusCodeTolerated(ctx, goneCodes, statusCode, diagnostics)
+}
+
 // resolveRefreshPath returns the path to read, defaulting to `path` and resolving any inline
 // JSONPath tokens against the captured response body.
 func resolveRefreshPath(
@@ -800,9 +1014,10 @@ func resolveRefreshPath(
 		return model.Path.ValueString(), true
 	}
 
-	return resolveDeletePathTokens(
+	return resolvePathTokens(
 		model.RefreshPath.ValueString(),
 		model.ResponseBody.ValueString(),
+		headersOf(context.Background(), model.ResponseHeaders, diagnostics),
 		diagnostics,
 	)
 }
@@ -863,6 +1078,19 @@ func (it *HTTPRequestResource) Update(
 		return
 	}
 
+	// A dedicated update request changes the object create produced instead of re-issuing the
+	// create request, which for a POST would produce a second object.
+	resp.Diagnostics.Append(copyWriteOnlyUpdateParams(ctx, req.Config, &planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isUpdateRequestConfigured(planModel) {
+		it.updateInPlace(ctx, req, resp, planModel, stateModel)
+
+		return
+	}
+
 	it.Create(ctx, resource.CreateRequest{
 		Config:       req.Config,
 		Plan:         req.Plan,
@@ -881,6 +1109,7 @@ func (it *HTTPRequestResource) adoptConfiguration(
 	planModel HTTPRequestResourceModel,
 ) {
 	resp.Diagnostics.Append(copyWriteOnlyDeleteParams(ctx, req.Config, &planModel)...)
+	resp.Diagnostics.Append(prepareRequestBody(ctx, req.Config, &planModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -903,9 +1132,17 @@ func RequestAttributesChanged(plan, state HTTPRequestResourceModel) bool {
 		!plan.Path.Equal(state.Path) ||
 		!plan.Headers.Equal(state.Headers) ||
 		!plan.RequestBody.Equal(state.RequestBody) ||
+		!plan.RequestBodyHash.Equal(state.RequestBodyHash) ||
+		!plan.ContentType.Equal(state.ContentType) ||
+		!plan.Multipart.Equal(state.Multipart) ||
 		!plan.QueryParameters.Equal(state.QueryParameters) ||
 		!plan.BaseURL.Equal(state.BaseURL) ||
+		!plan.Endpoint.Equal(state.Endpoint) ||
 		!plan.BasicAuth.Equal(state.BasicAuth) ||
+		!plan.BearerAuth.Equal(state.BearerAuth) ||
+		!plan.APIKey.Equal(state.APIKey) ||
+		!plan.HMACSignature.Equal(state.HMACSignature) ||
+		!plan.DigestAuth.Equal(state.DigestAuth) ||
 		!plan.IgnoreTLS.Equal(state.IgnoreTLS)
 }
 
@@ -969,14 +1206,19 @@ func (it *HTTPRequestResource) ModifyPlan(
 	// response is accepted. delete_resolved_path is included because it is recomputed from
 	// the response in populateResponseState and is likewise UseStateForUnknown. A change
 	// limited to client-side attributes leaves the recorded response untouched, so the
-	// pinned values stay correct.
+	// pinned values stay correct. A configured update request changes the object create
+	// produced rather than producing a new one, so `id` stays pinned in that case.
 	if RequestAttributesChanged(planModel, stateModel) {
-		planModel.ID = types.StringUnknown()
+		if !updateRequestConfiguredIn(ctx, req.Config, &resp.Diagnostics) {
+			planModel.ID = types.StringUnknown()
+		}
 		planModel.ImportID = types.StringUnknown()
 		planModel.ResponseCode = types.Int32Unknown()
 		planModel.ResponseBody = types.StringUnknown()
 		planModel.ResponseBodyID = types.StringUnknown()
 		planModel.ResponseBodyJSON = types.MapUnknown(types.StringType)
+		planModel.ResponseHeaders = types.MapUnknown(types.StringType)
+		planModel.FinalURL = types.StringUnknown()
 		planModel.DeleteResolvedPath = types.StringUnknown()
 	}
 
@@ -1037,13 +1279,19 @@ func populateResponseState(
 	}
 
 	model.ResponseBody = types.StringValue(string(exchange.body))
+	model.ResponseHeaders = capturedResponseHeaders(ctx, model.CaptureResponseHeaders, exchange.headers, diagnostics)
+	if exchange.finalURL != "" {
+		model.FinalURL = types.StringValue(exchange.finalURL)
+	} else if model.FinalURL.IsUnknown() {
+		model.FinalURL = types.StringNull()
+	}
 	updateResponseBody(model, diagnostics)
 	updateResponseBodyID(model, []byte(model.ResponseBody.ValueString()), diagnostics)
 	updateResponseBodyJSON(model, []byte(model.ResponseBody.ValueString()), diagnostics)
 
 	if !model.DeletePath.IsNull() && model.DeletePath.ValueString() != "" {
-		resolved, ok := resolveDeletePathTokens(
-			model.DeletePath.ValueString(), model.ResponseBody.ValueString(), diagnostics,
+		resolved, ok := resolvePathTokens(
+			model.DeletePath.ValueString(), model.ResponseBody.ValueString(), exchange.headers, diagnostics,
 		)
 		if ok {
 			model.DeleteResolvedPath = types.StringValue(resolved)
@@ -1095,9 +1343,10 @@ func resolveDeleteTargetPath(
 		return "", false
 	}
 
-	resolved, ok := resolveDeletePathTokens(
+	resolved, ok := resolvePathTokens(
 		m.DeletePath.ValueString(),
 		m.ResponseBody.ValueString(),
+		headersOf(context.Background(), m.ResponseHeaders, diagnostics),
 		diagnostics,
 	)
 	if !ok {
@@ -1117,9 +1366,9 @@ func makeDeleteModel(
 
 	// Body only if provided for delete
 	if isNonEmptyString(base.DeleteRequestBody) {
-		dm.RequestBody = types.StringValue(base.DeleteRequestBody.ValueString())
+		replaceRequestBody(&dm, types.StringValue(base.DeleteRequestBody.ValueString()))
 	} else {
-		dm.RequestBody = types.StringNull()
+		replaceRequestBody(&dm, types.StringNull())
 	}
 
 	// Headers only if provided for delete
@@ -1177,8 +1426,12 @@ func (it *HTTPRequestResource) Delete(
 		return
 	}
 
-	client := it.getHTTPClient(ctx, model)
-	response, err := client.Do(request)
+	client, err := it.getHTTPClient(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating HTTP client for DELETE request", err.Error())
+		return
+	}
+	response, err := it.doAuthenticated(ctx, client, delModel, endpoint, request)
 	if err != nil {
 		resp.Diagnostics.AddError("Error executing DELETE HTTP request", err.Error())
 		return
@@ -1211,6 +1464,20 @@ func (it *HTTPRequestResource) Delete(
 		return
 	}
 
+	// A failed or unfinished deletion keeps the resource in state, so the next destroy retries it.
+	trigger := &httpExchange{
+		statusCode: response.StatusCode,
+		status:     response.Status,
+		headers:    response.Header,
+		body:       responseBody,
+	}
+	if _, ok := it.awaitOperation(ctx, model, trigger, &resp.Diagnostics); !ok {
+		return
+	}
+	if !it.awaitDeletion(ctx, model, targetPath, &resp.Diagnostics) {
+		return
+	}
+
 	resp.State.RemoveResource(ctx)
 }
 
@@ -1348,6 +1615,7 @@ func (it *HTTPRequestResource) captureImportedResponse(
 	if isNonEmptyString(model.ResponseBody) {
 		populateResponseState(ctx, model, &httpExchange{
 			statusCode: int(model.ResponseCode.ValueInt32()),
+			headers:    headersOf(ctx, model.ResponseHeaders, diagnostics),
 			body:       []byte(model.ResponseBody.ValueString()),
 		}, diagnostics)
 
@@ -1364,7 +1632,7 @@ func (it *HTTPRequestResource) captureImportedResponse(
 	readModel := *model
 	readModel.Method = types.StringValue(http.MethodGet)
 	readModel.Path = types.StringValue(readPath)
-	readModel.RequestBody = types.StringNull()
+	replaceRequestBody(&readModel, types.StringNull())
 
 	exchange, ok := it.performRequest(ctx, readModel, diagnostics)
 	if !ok {
@@ -1506,9 +1774,40 @@ func upgradeStateFromV0(
 		DeleteRequestBody: types.StringNull(),
 
 		// Introduced in schema v3, and typed nulls for the same reason as above.
-		IsRefreshEnabled: types.BoolNull(),
-		RefreshPath:      types.StringNull(),
-		ImportID:         types.StringNull(),
+		IsRefreshEnabled:       types.BoolNull(),
+		RefreshPath:            types.StringNull(),
+		RefreshMethod:          types.StringNull(),
+		RefreshHeaders:         types.MapNull(types.StringType),
+		RefreshRequestBody:     types.StringNull(),
+		RefreshGoneStatusCodes: types.SetNull(types.Int32Type),
+		DriftDetection:         types.ObjectNull(driftDetectionObjectAttrTypes()),
+		DeleteWait:             types.ObjectNull(deleteWaitObjectAttrTypes()),
+		BearerAuth:             types.ObjectNull(bearerAuthObjectAttrTypes()),
+		APIKey:                 types.ObjectNull(apiKeyObjectAttrTypes()),
+		HMACSignature:          types.ObjectNull(hmacSignatureObjectAttrTypes()),
+		Endpoint:               types.StringNull(),
+		DigestAuth:             types.ObjectNull(digestAuthObjectAttrTypes()),
+		RequestBodyFile:        types.StringNull(),
+		RequestBodyBase64:      types.StringNull(),
+		ContentType:            types.StringNull(),
+		RequestBodyHash:        types.StringNull(),
+		Multipart:              types.ObjectNull(multipartObjectAttrTypes()),
+		tlsArguments:           nullTLSArguments(),
+		proxyArguments:         nullProxyArguments(),
+		redirectArguments:      nullRedirectArguments(),
+		ImportID:               types.StringNull(),
+
+		// The update controls are write-only, so null is the only value state ever holds.
+		UpdateMethod:      types.StringNull(),
+		UpdatePath:        types.StringNull(),
+		UpdateHeaders:     types.MapNull(types.StringType),
+		UpdateRequestBody: types.StringNull(),
+
+		WaitFor: types.ObjectNull(waitForObjectAttrTypes()),
+
+		CaptureResponseHeaders: types.SetNull(types.StringType),
+		ResponseHeaders:        types.MapNull(types.StringType),
+		FinalURL:               types.StringNull(),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, newModel)...)
@@ -1727,9 +2026,39 @@ func (m httpRequestResourceModelPreV3) toCurrent() HTTPRequestResourceModel {
 		ResponseBodyID:       m.ResponseBodyID,
 		ResponseBodyJSON:     m.ResponseBodyJSON,
 
-		IsRefreshEnabled: types.BoolNull(),
-		RefreshPath:      types.StringNull(),
-		ImportID:         types.StringNull(),
+		IsRefreshEnabled:       types.BoolNull(),
+		RefreshPath:            types.StringNull(),
+		RefreshMethod:          types.StringNull(),
+		RefreshHeaders:         types.MapNull(types.StringType),
+		RefreshRequestBody:     types.StringNull(),
+		RefreshGoneStatusCodes: types.SetNull(types.Int32Type),
+		DriftDetection:         types.ObjectNull(driftDetectionObjectAttrTypes()),
+		DeleteWait:             types.ObjectNull(deleteWaitObjectAttrTypes()),
+		BearerAuth:             types.ObjectNull(bearerAuthObjectAttrTypes()),
+		APIKey:                 types.ObjectNull(apiKeyObjectAttrTypes()),
+		HMACSignature:          types.ObjectNull(hmacSignatureObjectAttrTypes()),
+		Endpoint:               types.StringNull(),
+		DigestAuth:             types.ObjectNull(digestAuthObjectAttrTypes()),
+		RequestBodyFile:        types.StringNull(),
+		RequestBodyBase64:      types.StringNull(),
+		ContentType:            types.StringNull(),
+		RequestBodyHash:        types.StringNull(),
+		Multipart:              types.ObjectNull(multipartObjectAttrTypes()),
+		tlsArguments:           nullTLSArguments(),
+		proxyArguments:         nullProxyArguments(),
+		redirectArguments:      nullRedirectArguments(),
+		ImportID:               types.StringNull(),
+
+		UpdateMethod:      types.StringNull(),
+		UpdatePath:        types.StringNull(),
+		UpdateHeaders:     types.MapNull(types.StringType),
+		UpdateRequestBody: types.StringNull(),
+
+		WaitFor: types.ObjectNull(waitForObjectAttrTypes()),
+
+		CaptureResponseHeaders: types.SetNull(types.StringType),
+		ResponseHeaders:        types.MapNull(types.StringType),
+		FinalURL:               types.StringNull(),
 	}
 }
 
@@ -1853,9 +2182,16 @@ func (it *HTTPRequestResource) buildRequest(
 	var body io.Reader
 	looksJSON := false
 
-	if !model.RequestBody.IsNull() {
-		send, isJSON := coerceBodyString(model.RequestBody.ValueString())
-		body = bytes.NewBufferString(send)
+	form, err := multipartBodyOf(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	payload, isJSON, err := requestBodyOf(model)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		body = bytes.NewReader(payload)
 		looksJSON = isJSON
 	}
 
@@ -1871,33 +2207,47 @@ func (it *HTTPRequestResource) buildRequest(
 
 	// Provider-level headers go on FIRST so the resource's own can override them, and so a
 	// provider-level `Content-Type` still suppresses the JSON default below.
-	it.applyProviderHeaders(req.Header)
+	it.applyProviderHeaders(req.Header, model)
 
 	if applyErr := applyHeadersFromMapAttr(ctx, req.Header, model.Headers); applyErr != nil {
 		return nil, applyErr
 	}
 
+	applyContentType(req.Header, model)
+	if form != nil {
+		// attached after the headers, as no header may replace the boundary it announces
+		form.Attach(req)
+	}
 	applyDefaultJSONHeaders(req.Header, isBoolTrue(model.IsResponseBodyJSON), looksJSON)
 
 	// Apply authentication - resource-level takes precedence over provider-level
-	if !model.BasicAuth.IsNull() {
-		// Use resource-level basic auth
-		authAttrs := model.BasicAuth.Attributes()
-		username, ok := authAttrs[attrUsername].(types.String)
-		if !ok {
-			return nil, errors.New("failed to get username from basic_auth")
+	if authErr := it.applyAuthentication(ctx, req, model); authErr != nil {
+		return nil, authErr
+	}
+
+	if credentials := it.resolveDigestAuth(model); credentials != nil {
+		// the client answers the challenge of the server, as it alone sees the response
+		req = req.WithContext(entities.WithDigestAuth(req.Context(), credentials))
+	}
+
+	// the signatures cover the headers, the query and the body, so nothing may change after them;
+	// the AWS one comes last as it also covers the header the HMAC is written to
+	sign := func(request *http.Request) error {
+		if signErr := signHMAC(request, model); signErr != nil {
+			return signErr
 		}
-		password, ok := authAttrs[attrPassword].(types.String)
-		if !ok {
-			return nil, errors.New("failed to get password from basic_auth")
+		if it.usesAWSSigV4(model) {
+			return it.signAWSSigV4(request)
 		}
-		req.SetBasicAuth(username.ValueString(), password.ValueString())
-	} else if config := it.providerConfig(); config.HasAuthentication() {
-		// Fall back to provider-level basic auth
-		req.SetBasicAuth(
-			config.BasicAuth.Username,
-			config.BasicAuth.Password,
-		)
+
+		return nil
+	}
+	if signErr := sign(req); signErr != nil {
+		return nil, signErr
+	}
+	if !model.HMACSignature.IsNull() || it.usesAWSSigV4(model) {
+		// every retry is signed again, with its own timestamp and nonce
+		req = req.WithContext(withRequestSigner(req.Context(), sign))
 	}
 
 	return req, nil
@@ -1941,7 +2291,8 @@ func applyHeadersFromMapAttr(ctx context.Context, h http.Header, m types.Map) er
 	return nil
 }
 
-// applyProviderHeaders writes the provider-level headers onto a request.
+// applyProviderHeaders writes the provider-level headers onto a request, then those of the endpoint
+// it selects.
 //
 // Every request the resource issues is built here, so this one call covers create, read, refresh,
 // destroy AND the read an import performs -- which is the case that cannot be served any other way.
@@ -1953,7 +2304,7 @@ func applyHeadersFromMapAttr(ctx context.Context, h http.Header, m types.Map) er
 // `http.Header.Set` canonicalises the name, so a resource header applied afterwards overrides the
 // provider's regardless of the casing either side used -- which is the behaviour RFC 9110 requires
 // of header names.
-func (it *HTTPRequestResource) applyProviderHeaders(h http.Header) {
+func (it *HTTPRequestResource) applyProviderHeaders(h http.Header, model HTTPRequestResourceModel) {
 	config := it.providerConfig()
 	if config == nil {
 		return
@@ -1962,6 +2313,11 @@ func (it *HTTPRequestResource) applyProviderHeaders(h http.Header) {
 	for name, value := range config.Headers {
 		h.Set(name, value)
 	}
+	if endpoint := it.endpoint(model); endpoint != nil {
+		for name, value := range endpoint.Headers {
+			h.Set(name, value)
+		}
+	}
 }
 
 // providerConfig returns the provider-level configuration, or nil when there is none to read.
@@ -2114,19 +2470,35 @@ func resolveDeletePathTokens(rawPath, responseBody string, diagnostics *diag.Dia
 	return resolved, true
 }
 
+// resolveBaseURL returns the base URL of a request: the resource's `base_url`, or else the `url`
+// of the endpoint it selects, or else the provider's `url`. It returns "" when none is set.
+func (it *HTTPRequestResource) resolveBaseURL(model HTTPRequestResourceModel) string {
+	if !model.BaseURL.IsNull() && model.BaseURL.ValueString() != "" {
+		return model.BaseURL.ValueString()
+	}
+	if endpoint := it.endpoint(model); endpoint != nil && endpoint.URL != "" {
+		return endpoint.URL
+	}
+	if it.internal != nil && it.internal.Config != nil {
+		return it.internal.Config.URL
+	}
+
+	return ""
+}
+
 func (it *HTTPRequestResource) buildFullURL(
 	ctx context.Context,
 	model HTTPRequestResourceModel,
 ) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	var baseURLString string
-	switch {
-	case !model.BaseURL.IsNull() && model.BaseURL.ValueString() != "":
-		baseURLString = model.BaseURL.ValueString()
-	case it.internal != nil && it.internal.Config != nil && it.internal.Config.URL != "":
-		baseURLString = it.internal.Config.URL
-	default:
+	it.checkEndpoint(model, &diags)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	baseURLString := it.resolveBaseURL(model)
+	if baseURLString == "" {
 		diags.AddError(
 			"No base URL configured",
 			"A base URL must be configured either at the provider level (using the 'url' attribute) "+
@@ -2141,20 +2513,16 @@ func (it *HTTPRequestResource) buildFullURL(
 		diags.AddError("Error parsing base URL", err.Error())
 		return "", diags
 	}
-
-	relativePath := model.Path.ValueString()
-	if !strings.HasPrefix(relativePath, "/") {
-		relativePath = "/" + relativePath
-	}
-	userURL, err := url.Parse(relativePath)
+	socket, err := entities.UnixSocketOf(baseURL)
 	if err != nil {
-		diags.AddError("Error parsing user URL", err.Error())
+		diags.AddError("Error parsing base URL", err.Error())
 		return "", diags
 	}
+	if socket != nil {
+		// the socket is dialed by the transport, so the request itself is addressed to its host
+		baseURL = socket.URL()
+	}
 
-	baseURL.Path = gopath.Join(baseURL.Path, userURL.Path)
-
-	query := userURL.Query()
 	var queryParams map[string]string
 	if !model.QueryParameters.IsNull() && model.QueryParameters.Elements() != nil {
 		d := model.QueryParameters.ElementsAs(ctx, &queryParams, false)
@@ -2162,41 +2530,148 @@ func (it *HTTPRequestResource) buildFullURL(
 		if diags.HasError() {
 			return "", diags
 		}
-		for k, v := range queryParams {
-			query.Add(k, v)
-		}
 	}
-	baseURL.RawQuery = query.Encode()
 
-	baseURL.Fragment = userURL.Fragment
+	requestPath := model.Path.ValueString()
+	if target, parseErr := url.Parse(requestPath); parseErr == nil && target.IsAbs() {
+		relative, underBase := pathUnderBase(baseURL, target)
+		if !underBase {
+			return withQueryParameters(target, queryParams), diags
+		}
+		requestPath = relative
+	}
 
-	finalURL := baseURL.String()
+	finalURL, err := joinURL(baseURL, requestPath, queryParams)
+	if err != nil {
+		diags.AddError("Error parsing user URL", err.Error())
+		return "", diags
+	}
 	return finalURL, diags
 }
 
+// pathUnderBase returns the part of an absolute URL below the base URL, with its query and
+// fragment, when both share a scheme and host and the URL's path starts with the base path. A path
+// resolved from a `Location` header is such a URL, and stripping the base path keeps joinURL from
+// repeating it.
+func pathUnderBase(baseURL, target *url.URL) (string, bool) {
+	if !strings.EqualFold(baseURL.Scheme, target.Scheme) || !strings.EqualFold(baseURL.Host, target.Host) {
+		return "", false
+	}
+
+	basePath := strings.TrimSuffix(baseURL.Path, "/")
+	if target.Path != basePath && !strings.HasPrefix(target.Path, basePath+"/") {
+		return "", false
+	}
+
+	relative := *target
+	relative.Scheme, relative.Host, relative.User = "", "", nil
+	relative.Path = strings.TrimPrefix(target.Path, basePath)
+	relative.RawPath = ""
+
+	return relative.String(), true
+}
+
+// withQueryParameters adds the query parameters to an absolute URL that is sent as it is, because
+// it lies outside the base URL -- an operation or storage host a `Location` header points to.
+func withQueryParameters(target *url.URL, queryParams map[string]string) string {
+	query := target.Query()
+	for k, v := range queryParams {
+		query.Add(k, v)
+	}
+	target.RawQuery = query.Encode()
+
+	return target.String()
+}
+
+// joinURL appends a relative path, and the query and fragment it may carry, to a base URL, then
+// adds the query parameters. It is the joining rule of every request the provider sends, and the
+// one `provider::http::url` exposes to configurations.
+func joinURL(baseURL *url.URL, relativePath string, queryParams map[string]string) (string, error) {
+	if !strings.HasPrefix(relativePath, "/") {
+		relativePath = "/" + relativePath
+	}
+	userURL, err := url.Parse(relativePath)
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	joined := *baseURL
+	joined.Path = gopath.Join(baseURL.Path, userURL.Path)
+
+	query := userURL.Query()
+	for k, v := range queryParams {
+		query.Add(k, v)
+	}
+	joined.RawQuery = query.Encode()
+
+	joined.Fragment = userURL.Fragment
+
+	return joined.String(), nil
+}
+
 // getHTTPClient returns the HTTP client to use for this request. It resolves the
-// effective TLS, timeout, and retry settings -- resource-level values take
+// effective TLS, proxy, redirect, timeout, and retry settings -- resource-level values take
 // precedence over the provider-level configuration -- and builds a client
 // accordingly. When retries are configured the returned client transparently
 // retries on connection errors and 5xx (except 501) responses, applying an
 // exponential backoff bounded by the configured min/max delays. The per-request
 // timeout (when set) bounds each individual attempt; an unset/zero timeout
 // preserves the historical behavior of waiting indefinitely.
+//
+// Clients are cached in the provider context by those settings, so every request
+// resolving the same ones shares a client and its connection pool instead of paying
+// a fresh TCP and TLS handshake.
 func (it *HTTPRequestResource) getHTTPClient(
 	_ context.Context,
 	model HTTPRequestResourceModel,
-) *http.Client {
-	ignoreTLS := it.resolveIgnoreTLS(model)
-	timeout := it.resolveTimeout(model)
-	retryCfg := it.resolveRetry(model)
+) (*http.Client, error) {
+	settings, err := it.resolveClientSettings(model)
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
+	}
 
-	base := &http.Client{Timeout: timeout}
-	if ignoreTLS {
-		base.Transport = it.resolveInsecureTransport()
+	build := func() (*http.Client, error) {
+		return it.buildHTTPClient(model, settings)
+	}
+	if it.internal == nil {
+		return build()
 	}
 
+	return it.internal.CachedClient(settings, build)
+}
+
+// buildHTTPClient builds the client getHTTPClient caches for the resolved settings.
+func (it *HTTPRequestResource) buildHTTPClient(
+	model HTTPRequestResourceModel,
+	settings entities.ClientSettings,
+) (*http.Client, error) {
+	transport, err := it.resolveTransport(model)
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
+	}
+	var digest *entities.DigestChallenges
+	if it.internal != nil {
+		// every client shares the throttle of the provider, so its limits hold across all requests
+		transport = it.internal.Throttle.Wrap(transport)
+		digest = it.internal.Digest
+	}
+	if settings.Digest {
+		// outside the throttle, so the request answering a challenge is throttled too
+		transport = digest.Wrap(transport)
+	}
+	base := &http.Client{
+		Timeout:       settings.Timeout,
+		Transport:     transport,
+		CheckRedirect: settings.Redirect.CheckRedirect,
+	}
+	if session := it.session(); session != nil {
+		// every request sends the cookies of the session, and stores those it receives as a browser would
+		base.Jar = session.Jar
+	}
+
+	retryCfg := settings.Retry
 	if retryCfg == nil || retryCfg.Attempts <= 0 {
-		return base
+		return base, nil
 	}
 
 	retryClient := retryablehttp.NewClient()
@@ -2206,16 +2681,30 @@ func (it *HTTPRequestResource) getHTTPClient(
 	retryClient.RetryMax = int(retryCfg.Attempts)
 	retryClient.RetryWaitMin = time.Duration(retryCfg.MinDelayMs) * time.Millisecond
 	retryClient.RetryWaitMax = time.Duration(retryCfg.MaxDelayMs) * time.Millisecond
-	return retryClient.StandardClient()
+	policy, err := newRetryPolicy(*retryCfg)
+	if err != nil {
+		return nil, err
+	}
+	policy.configure(retryClient)
+	// The wrapped client follows redirects; the wrapper applies the same policy so a redirect
+	// response the policy returned as is is not followed by the wrapper's defaults instead.
+	client := retryClient.StandardClient()
+	client.Transport = retryTransport{&retryablehttp.RoundTripper{Client: retryClient}}
+	client.CheckRedirect = settings.Redirect.CheckRedirect
+
+	return client, nil
 }
 
 // resolveIgnoreTLS resolves the effective ignore_tls setting: a resource-level
-// value wins; otherwise the provider-level value (detected from the configured
-// client transport) is used.
+// value wins, then the one of the endpoint the resource selects; otherwise the
+// provider-level value (detected from the configured client transport) is used.
 func (it *HTTPRequestResource) resolveIgnoreTLS(model HTTPRequestResourceModel) bool {
 	if !model.IgnoreTLS.IsNull() {
 		return model.IgnoreTLS.ValueBool()
 	}
+	if endpoint := it.endpoint(model); endpoint != nil && endpoint.IgnoreTLS != nil {
+		return *endpoint.IgnoreTLS
+	}
 	if it.internal != nil && it.internal.Client != nil && it.internal.Client.Transport != nil {
 		if transport, ok := it.internal.Client.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
 			return transport.TLSClientConfig.InsecureSkipVerify
@@ -2229,32 +2718,28 @@ func (it *HTTPRequestResource) resolveIgnoreTLS(model HTTPRequestResourceModel)
 // underlying connection pool is shared across requests; a fresh transport is
 // allocated only when there is none to reuse (for example, a resource-level
 // ignore_tls override turning verification off where the provider left it on).
-func (it *HTTPRequestResource) resolveInsecureTransport() http.RoundTripper {
+func (it *HTTPRequestResource) resolveInsecureTransport() (http.RoundTripper, error) {
 	if it.internal != nil && it.internal.Client != nil {
 		if transport, ok := it.internal.Client.Transport.(*http.Transport); ok &&
 			transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
-			return transport
+			return transport, nil
 		}
 	}
-	return &http.Transport{
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS13,
-			// InsecureSkipVerify is intentionally set to true when ignore_tls is enabled.
-			// This is a user-controlled feature for testing and self-signed certificates.
-			//nolint:gosec // purposefully ignore TLS verification according to user configuration
-			InsecureSkipVerify: true,
-		},
-	}
+
+	return entities.NewTransport(entities.InsecureTLSConfig(), nil, it.providerConnectionPool())
 }
 
 // resolveTimeout resolves the effective per-request timeout: a resource-level
-// value wins; otherwise the provider-level value is used. A non-positive value
-// means no timeout.
+// value wins, then the one of the endpoint the resource selects; otherwise the
+// provider-level value is used. A non-positive value means no timeout.
 func (it *HTTPRequestResource) resolveTimeout(model HTTPRequestResourceModel) time.Duration {
 	var ms int64
 	if it.internal != nil && it.internal.Config != nil {
 		ms = it.internal.Config.RequestTimeoutMs
 	}
+	if endpoint := it.endpoint(model); endpoint != nil && endpoint.RequestTimeoutMs != nil {
+		ms = *endpoint.RequestTimeoutMs
+	}
 	if !model.RequestTimeoutMs.IsNull() && !model.RequestTimeoutMs.IsUnknown() {
 		ms = model.RequestTimeoutMs.ValueInt64()
 	}
@@ -2265,11 +2750,15 @@ func (it *HTTPRequestResource) resolveTimeout(model HTTPRequestResourceModel) ti
 }
 
 // resolveRetry resolves the effective retry configuration: a resource-level
-// `retry` block wins; otherwise the provider-level configuration is used.
+// `retry` block wins, then the one of the endpoint the resource selects;
+// otherwise the provider-level configuration is used.
 func (it *HTTPRequestResource) resolveRetry(model HTTPRequestResourceModel) *entities.RetryConfig {
 	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
 		return retryConfigFromObject(model.Retry)
 	}
+	if endpoint := it.endpoint(model); endpoint != nil && endpoint.Retry != nil {
+		return endpoint.Retry
+	}
 	if it.internal != nil && it.internal.Config != nil {
 		return it.internal.Config.Retry
 	}
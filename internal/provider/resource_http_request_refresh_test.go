@@ -0,0 +1,187 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refreshResponse returns a read response whose state holds a resource, so a test can tell whether
+// refresh removed it.
+func refreshResponse(t *testing.T) *resource.ReadResponse {
+	t.Helper()
+
+	config := resourceConfigWith(t, nil)
+
+	return &resource.ReadResponse{State: tfsdk.State{Raw: config.Raw, Schema: config.Schema}}
+}
+
+// statusServer answers every request with the given status, recording the last one it received.
+func statusServer(t *testing.T, status int, received *http.Request, body *string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if received != nil {
+			*received = *r.Clone(context.Background())
+		}
+		if body != nil {
+			raw, _ := io.ReadAll(r.Body)
+			*body = string(raw)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"name":"current"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestMakeRefreshModel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should read with a bodiless GET carrying the create headers by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		headers := resourceHeaderMap(t, map[string]string{"X-Tenant": "a"})
+		model := requestModel(headers)
+		model.Method = types.StringValue(http.MethodPost)
+		model.RequestBody = types.StringValue(`{"name":"created"}`)
+
+		// when
+		refreshModel := makeRefreshModel(model, "/widgets/1")
+
+		// then
+		assert.Equal(t, http.MethodGet, refreshModel.Method.ValueString())
+		assert.Equal(t, "/widgets/1", refreshModel.Path.ValueString())
+		assert.True(t, refreshModel.RequestBody.IsNull())
+		assert.Equal(t, headers, refreshModel.Headers)
+	})
+
+	t.Run("should use the refresh method, headers and body when configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := requestModel(resourceHeaderMap(t, map[string]string{"X-Tenant": "a"}))
+		model.RefreshMethod = types.StringValue("post")
+		model.RefreshHeaders = resourceHeaderMap(t, map[string]string{"X-Read": "1"})
+		model.RefreshRequestBody = types.StringValue(`{"id":1}`)
+
+		// when
+		refreshModel := makeRefreshModel(model, "/search")
+
+		// then
+		assert.Equal(t, http.MethodPost, refreshModel.Method.ValueString())
+		assert.Equal(t, `{"id":1}`, refreshModel.RequestBody.ValueString())
+		assert.Equal(t, model.RefreshHeaders, refreshModel.Headers)
+	})
+}
+
+func TestIsGoneStatusCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should treat only 404 and 410 as gone by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+		unset := types.SetNull(types.Int32Type)
+
+		// when / then
+		for status, gone := range map[int]bool{
+			http.StatusNotFound:            true,
+			http.StatusGone:                true,
+			http.StatusForbidden:           false,
+			http.StatusInternalServerError: false,
+		} {
+			assert.Equal(t, gone, isGoneStatusCode(context.Background(), unset, status, &diagnostics), "status %d", status)
+		}
+	})
+
+	t.Run("should honor the configured codes only", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		codes := types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(http.StatusGone)})
+		var diagnostics diag.Diagnostics
+
+		// when / then
+		assert.True(t, isGoneStatusCode(context.Background(), codes, http.StatusGone, &diagnostics))
+		assert.False(t, isGoneStatusCode(context.Background(), codes, http.StatusNotFound, &diagnostics))
+	})
+}
+
+func TestRefreshFromRemote(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send the configured search request and capture its response", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var received http.Request
+		var body string
+		server := statusServer(t, http.StatusOK, &received, &body)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		model.RefreshMethod = types.StringValue(http.MethodPost)
+		model.RefreshPath = types.StringValue("/search")
+		model.RefreshRequestBody = types.StringValue(`{"id":1}`)
+		resp := refreshResponse(t)
+
+		// when
+		ok := (&HTTPRequestResource{}).refreshFromRemote(context.Background(), &model, resp)
+
+		// then
+		require.True(t, ok, "the refresh succeeds: %v", resp.Diagnostics)
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.Equal(t, "/search", received.URL.Path)
+		assert.JSONEq(t, `{"id":1}`, body)
+		assert.JSONEq(t, `{"name":"current"}`, model.ResponseBody.ValueString())
+	})
+
+	t.Run("should remove the resource when the status means gone", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := statusServer(t, http.StatusGone, nil, nil)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		resp := refreshResponse(t)
+
+		// when
+		ok := (&HTTPRequestResource{}).refreshFromRemote(context.Background(), &model, resp)
+
+		// then
+		assert.False(t, ok)
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.True(t, resp.State.Raw.IsNull(), "a gone resource is removed from state")
+	})
+
+	t.Run("should fail instead of removing the resource on any other failure", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := statusServer(t, http.StatusInternalServerError, nil, nil)
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		resp := refreshResponse(t)
+
+		// when
+		ok := (&HTTPRequestResource{}).refreshFromRemote(context.Background(), &model, resp)
+
+		// then
+		assert.False(t, ok)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Refresh request failed", resp.Diagnostics[0].Summary())
+		assert.False(t, resp.State.Raw.IsNull(), "a failed refresh keeps the resource")
+	})
+}
@@ -20,9 +20,14 @@ import (
 
 func retryObject(attempts, minDelayMs, maxDelayMs types.Int64) types.Object {
 	return types.ObjectValueMust(retryObjectAttrTypes(), map[string]attr.Value{
-		attrAttempts:   attempts,
-		attrMinDelayMs: minDelayMs,
-		attrMaxDelayMs: maxDelayMs,
+		attrAttempts:           attempts,
+		attrMinDelayMs:         minDelayMs,
+		attrMaxDelayMs:         maxDelayMs,
+		attrRetryOnStatusCodes: types.SetNull(types.Int32Type),
+		attrRespectRetryAfter:  types.BoolNull(),
+		attrJitter:             types.BoolNull(),
+		attrRetryNonIdempotent: types.BoolNull(),
+		attrRetryOnBodyMatch:   types.StringNull(),
 	})
 }
 
@@ -114,7 +119,8 @@ func TestGetHTTPClientRetry(t *testing.T) {
 			RequestTimeoutMs: types.Int64Null(),
 			Retry:            retryObject(types.Int64Value(5), types.Int64Value(1), types.Int64Value(2)),
 		}
-		client := it.getHTTPClient(context.Background(), model)
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
 
 		// when
 		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
@@ -145,7 +151,8 @@ func TestGetHTTPClientRetry(t *testing.T) {
 			RequestTimeoutMs: types.Int64Null(),
 			Retry:            types.ObjectNull(retryObjectAttrTypes()),
 		}
-		client := it.getHTTPClient(context.Background(), model)
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
 
 		// when
 		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
@@ -179,7 +186,8 @@ func TestGetHTTPClientTimeout(t *testing.T) {
 			RequestTimeoutMs: types.Int64Value(50),
 			Retry:            types.ObjectNull(retryObjectAttrTypes()),
 		}
-		client := it.getHTTPClient(context.Background(), model)
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
 
 		// when
 		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
@@ -205,7 +213,8 @@ func TestGetHTTPClientTimeout(t *testing.T) {
 		}
 
 		// when
-		client := it.getHTTPClient(context.Background(), model)
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
 
 		// then
 		assert.Equal(t, time.Duration(0), client.Timeout,
@@ -231,7 +240,8 @@ func TestGetHTTPClientTransportReuse(t *testing.T) {
 		}
 
 		// when
-		client := it.getHTTPClient(context.Background(), model)
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
 
 		// then
 		assert.Same(t, providerTransport, client.Transport,
@@ -251,7 +261,8 @@ func TestGetHTTPClientTransportReuse(t *testing.T) {
 		}
 
 		// when
-		client := it.getHTTPClient(context.Background(), model)
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
 
 		// then
 		transport, ok := client.Transport.(*http.Transport)
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+// headerTokenRe matches a `${header.Name}` reference inside a path; the first group is the header
+// name, limited to the token characters RFC 9110 allows in one.
+var headerTokenRe = regexp.MustCompile(`\$\{header\.([A-Za-z0-9!#$%&'*+.^_|~-]+)\}`)
+
+// addResponseHeaderAttributes adds the opt-in capture of response headers. Capturing is an
+// allow-list rather than a deny-list because a header such as `Set-Cookie` carries credentials, and
+// state is the last place a credential should end up by accident.
+func addResponseHeaderAttributes(attrs map[string]schema.Attribute) {
+	attrs[attrCaptureResponseHeaders] = schema.SetAttribute{
+		Description: "Names of the response headers to record in `response_headers` (e.g. [\"Location\", \"ETag\"]). " +
+			"Matching is case-insensitive. Nothing is recorded when unset. A change applies to the next " +
+			"response the resource captures.",
+		MarkdownDescription: "Names of the response headers to record in `response_headers` (e.g. `[\"Location\", \"ETag\"]`). " +
+			"Matching is case-insensitive. Nothing is recorded when unset. A change applies to the next " +
+			"response the resource captures.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attrs[attrResponseHeaders] = helpers.ComputedMapAttribute(types.StringType,
+		"The response headers listed in `capture_response_headers`, keyed by their canonical name "+
+			"(e.g. \"Location\"). A header sent more than once is recorded as its values joined by \", \".")
+}
+
+// flattenHeaders renders HTTP headers as the flat map `response_headers` holds.
+func flattenHeaders(headers http.Header) map[string]string {
+	flattened := make(map[string]string, len(headers))
+	for name, values := range headers {
+		flattened[http.CanonicalHeaderKey(name)] = strings.Join(values, ", ")
+	}
+
+	return flattened
+}
+
+// capturedResponseHeaders returns the `response_headers` value for a response: the allow-listed
+// headers it carried, or a typed null when no allow-list is configured.
+func capturedResponseHeaders(
+	ctx context.Context,
+	allowList types.Set,
+	headers http.Header,
+	diagnostics *diag.Diagnostics,
+) types.Map {
+	if allowList.IsNull() || allowList.IsUnknown() {
+		return types.MapNull(types.StringType)
+	}
+
+	names := stringSliceOf(ctx, allowList, diagnostics)
+	if diagnostics.HasError() {
+		return types.MapNull(types.StringType)
+	}
+
+	flattened := flattenHeaders(headers)
+	captured := make(map[string]string, len(names))
+	for _, name := range names {
+		canonical := http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if value, ok := flattened[canonical]; ok {
+			captured[canonical] = value
+		}
+	}
+
+	value, diags := types.MapValueFrom(ctx, types.StringType, captured)
+	diagnostics.Append(diags...)
+
+	return value
+}
+
+// headersOf converts the captured `response_headers` back into HTTP headers, so a response read
+// from state resolves header tokens the same way a live one does.
+func headersOf(ctx context.Context, captured types.Map, diagnostics *diag.Diagnostics) http.Header {
+	headers := make(http.Header)
+	for name, value := range stringMapOf(ctx, captured, diagnostics) {
+		headers.Set(name, value)
+	}
+
+	return headers
+}
+
+// resolvePathTokens resolves both kinds of inline token a path may carry: `${header.Name}`
+// references against the response headers first, then `$.` JSONPath tokens against the response
+// body.
+func resolvePathTokens(
+	rawPath string,
+	responseBody string,
+	headers http.Header,
+	diagnostics *diag.Diagnostics,
+) (string, bool) {
+	resolved, ok := resolveHeaderTokens(rawPath, headers, diagnostics)
+	if !ok {
+		return "", false
+	}
+
+	return resolveDeletePathTokens(resolved, responseBody, diagnostics)
+}
+
+// resolveHeaderTokens substitutes every `${header.Name}` reference. A header holding an absolute
+// URL -- `Location` usually does -- is substituted whole: buildFullURL strips the base URL from it
+// when it lies below that URL, and sends it as it is when it points to another host.
+func resolveHeaderTokens(rawPath string, headers http.Header, diagnostics *diag.Diagnostics) (string, bool) {
+	resolved := rawPath
+	for _, match := range headerTokenRe.FindAllStringSubmatch(rawPath, -1) {
+		value := headers.Get(match[1])
+		if value == "" {
+			diagnostics.AddError("Header token not found in the response",
+				fmt.Sprintf("token: %q did not resolve: the response carried no %q header. A header read "+
+					"back from state must be listed in `capture_response_headers`.", match[0], match[1]))
+
+			return "", false
+		}
+
+		resolved = strings.ReplaceAll(resolved, match[0], value)
+	}
+
+	return resolved, true
+}
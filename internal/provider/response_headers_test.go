@@ -0,0 +1,200 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createdHeaders is what a typical `201 Created` carries, including a credential that must never
+// reach state unless asked for.
+func createdHeaders() http.Header {
+	headers := make(http.Header)
+	headers.Set("Location", "https://api.example.test/widgets/42?expand=owner")
+	headers.Set("Etag", `"v1"`)
+	headers.Add("Set-Cookie", "session=secret")
+
+	return headers
+}
+
+func TestCapturedResponseHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should record nothing when no allow-list is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured := capturedResponseHeaders(
+			context.Background(), types.SetNull(types.StringType), createdHeaders(), &diagnostics,
+		)
+
+		// then
+		require.False(t, diagnostics.HasError())
+		assert.True(t, captured.IsNull(), "capturing is opt-in, so Set-Cookie cannot be stored by accident")
+	})
+
+	t.Run("should record only the listed headers, matched case-insensitively", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		allowList := types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("location"),
+			types.StringValue("ETAG"),
+			types.StringValue("X-Request-Id"),
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured := capturedResponseHeaders(context.Background(), allowList, createdHeaders(), &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError())
+		assert.Equal(t, map[string]attr.Value{
+			"Location": types.StringValue("https://api.example.test/widgets/42?expand=owner"),
+			"Etag":     types.StringValue(`"v1"`),
+		}, captured.Elements(), "absent headers are skipped and keys use the canonical spelling")
+	})
+}
+
+func TestResolvePathTokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should substitute an absolute Location header whole", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+
+		// when
+		resolved, ok := resolvePathTokens("${header.Location}", "", createdHeaders(), &diagnostics)
+
+		// then
+		require.True(t, ok)
+		assert.Equal(t, "https://api.example.test/widgets/42?expand=owner", resolved)
+	})
+
+	t.Run("should combine header and JSONPath tokens", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		headers := make(http.Header)
+		headers.Set("X-Tenant", "acme")
+		var diagnostics diag.Diagnostics
+
+		// when
+		resolved, ok := resolvePathTokens("/tenants/${header.x-tenant}/widgets/$.id", `{"id":7}`, headers, &diagnostics)
+
+		// then
+		require.True(t, ok, diagnostics)
+		assert.Equal(t, "/tenants/acme/widgets/7", resolved)
+	})
+
+	t.Run("should report a header the response did not carry", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := resolvePathTokens("${header.Location}", "", make(http.Header), &diagnostics)
+
+		// then
+		require.False(t, ok)
+		assert.Equal(t, "Header token not found in the response", diagnostics[0].Summary())
+	})
+}
+
+func TestBuildFullURLFromLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		baseURL  string
+		location string
+		want     string
+	}{
+		{
+			name:     "should not repeat the base path of a Location below the base URL",
+			baseURL:  "https://api.example.test/v1",
+			location: "https://api.example.test/v1/widgets/42?expand=owner",
+			want:     "https://api.example.test/v1/widgets/42?expand=owner",
+		},
+		{
+			name:     "should join a Location below a base URL without a path",
+			baseURL:  "https://api.example.test",
+			location: "https://api.example.test/widgets/42",
+			want:     "https://api.example.test/widgets/42",
+		},
+		{
+			name:     "should send a Location on another host as it is",
+			baseURL:  "https://api.example.test/v1",
+			location: "https://operations.example.test/v1/operations/7",
+			want:     "https://operations.example.test/v1/operations/7",
+		},
+		{
+			name:     "should send a Location outside the base path as it is",
+			baseURL:  "https://api.example.test/v1",
+			location: "https://api.example.test/v10/widgets/42",
+			want:     "https://api.example.test/v10/widgets/42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			headers := make(http.Header)
+			headers.Set("Location", tt.location)
+			var diagnostics diag.Diagnostics
+			resolved, ok := resolvePathTokens("${header.Location}", "", headers, &diagnostics)
+			require.True(t, ok, diagnostics)
+			model := pollingModel(tt.baseURL, types.ObjectNull(waitForObjectAttrTypes()))
+			model.Path = types.StringValue(resolved)
+
+			// when
+			endpoint, diags := resourceWithProviderAuth(nil, nil, nil).buildFullURL(context.Background(), model)
+
+			// then
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tt.want, endpoint)
+		})
+	}
+}
+
+func TestPopulateResponseStateHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should resolve delete_path from the live headers even when they are not captured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := requestModel(types.MapNull(types.StringType))
+		model.CaptureResponseHeaders = types.SetNull(types.StringType)
+		model.DeletePath = types.StringValue("${header.Location}")
+		var diagnostics diag.Diagnostics
+
+		// when
+		populateResponseState(context.Background(), &model, &httpExchange{
+			statusCode: http.StatusCreated,
+			headers:    createdHeaders(),
+			body:       []byte(`{}`),
+		}, &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), diagnostics)
+		assert.Equal(t, "https://api.example.test/widgets/42?expand=owner", model.DeleteResolvedPath.ValueString())
+		assert.True(t, model.ResponseHeaders.IsNull())
+	})
+}
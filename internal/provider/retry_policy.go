@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ohler55/ojg/jp"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+const (
+	attrRetryOnStatusCodes = "retry_on_status_codes"
+	attrRespectRetryAfter  = "respect_retry_after"
+	attrJitter             = "jitter"
+	attrRetryNonIdempotent = "retry_non_idempotent"
+	attrRetryOnBodyMatch   = "retry_on_body_match"
+)
+
+// Descriptions of the retry policy arguments, shared by the `retry` block of the provider, the
+// resource, the data source and the ephemeral resource.
+const (
+	descRetryOnStatusCodes = "The response statuses that are retried, replacing the default of `429` " +
+		"and every `5xx` except `501`. Connection errors are retried either way."
+	descRespectRetryAfter = "Whether a `Retry-After` response header sets the delay before the next " +
+		"attempt, up to `max_delay_ms`, instead of the exponential backoff. Defaults to `true`."
+	descJitter = "Whether each delay is picked at random between `min_delay_ms` and its exponential " +
+		"value, so concurrent requests do not retry in lockstep. Defaults to `false`."
+	descRetryNonIdempotent = "Whether requests with a method that is not idempotent, such as `POST` " +
+		"and `PATCH`, are retried like the others. By default they are only retried when the connection " +
+		"could not be established or the server answered `429`, so a request the server may have " +
+		"processed is not sent twice."
+	descRetryOnBodyMatch = "A JSONPath expression evaluated against a JSON response body; the request " +
+		"is retried when it selects at least one value, for APIs that report a transient failure in a " +
+		"successful response, for example `$.errors[?(@.code == 'RATE_LIMITED')]`. It applies to every method."
+)
+
+// retryPolicyAttrTypes returns the attribute types the retry policy arguments add to the `retry`
+// nested object.
+func retryPolicyAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrRetryOnStatusCodes: types.SetType{ElemType: types.Int32Type},
+		attrRespectRetryAfter:  types.BoolType,
+		attrJitter:             types.BoolType,
+		attrRetryNonIdempotent: types.BoolType,
+		attrRetryOnBodyMatch:   types.StringType,
+	}
+}
+
+// applyRetryPolicy copies the retry policy arguments of a `retry` object into cfg, leaving the
+// defaults of the unset ones.
+func applyRetryPolicy(attrs map[string]attr.Value, cfg *entities.RetryConfig) {
+	if codes, ok := attrs[attrRetryOnStatusCodes].(types.Set); ok && !codes.IsNull() && !codes.IsUnknown() {
+		cfg.RetryOnStatusCodes = []int{}
+		for _, element := range codes.Elements() {
+			if code, isInt := element.(types.Int32); isInt && !code.IsNull() && !code.IsUnknown() {
+				cfg.RetryOnStatusCodes = append(cfg.RetryOnStatusCodes, int(code.ValueInt32()))
+			}
+		}
+	}
+	for name, target := range map[string]*bool{
+		attrRespectRetryAfter:  &cfg.RespectRetryAfter,
+		attrJitter:             &cfg.Jitter,
+		attrRetryNonIdempotent: &cfg.RetryNonIdempotent,
+	} {
+		if value, ok := attrs[name].(types.Bool); ok && !value.IsNull() && !value.IsUnknown() {
+			*target = value.ValueBool()
+		}
+	}
+	if value, ok := attrs[attrRetryOnBodyMatch].(types.String); ok && isKnownString(value) {
+		cfg.RetryOnBodyMatch = value.ValueString()
+	}
+}
+
+// checkRetryArguments reports status codes outside the HTTP range and a `retry_on_body_match` that
+// does not parse. Unknown values pass.
+func checkRetryArguments(ctx context.Context, retry types.Object, diagnostics *diag.Diagnostics) {
+	checkRetryArgumentsAt(ctx, path.Root(attrRetry), retry, diagnostics)
+}
+
+// checkRetryArgumentsAt checks a `retry` block found at the given path, such as the one of an
+// `endpoint`.
+func checkRetryArgumentsAt(ctx context.Context, at path.Path, retry types.Object, diagnostics *diag.Diagnostics) {
+	if retry.IsNull() || retry.IsUnknown() {
+		return
+	}
+	attrs := retry.Attributes()
+
+	if codes, ok := attrs[attrRetryOnStatusCodes].(types.Set); ok {
+		var inner diag.Diagnostics
+		checkStatusCodes(ctx, attrRetryOnStatusCodes, codes, &inner)
+		for _, diagnostic := range inner {
+			diagnostics.AddAttributeError(
+				at.AtName(attrRetryOnStatusCodes), diagnostic.Summary(), diagnostic.Detail(),
+			)
+		}
+	}
+
+	if expression, ok := attrs[attrRetryOnBodyMatch].(types.String); ok && isKnownString(expression) {
+		if _, err := jp.ParseString(expression.ValueString()); err != nil {
+			diagnostics.AddAttributeError(
+				at.AtName(attrRetryOnBodyMatch),
+				"Invalid JSONPath expression",
+				fmt.Sprintf("%q could not be parsed: %v", expression.ValueString(), err),
+			)
+		}
+	}
+}
+
+// retryPolicy decides whether the retrying client of a request tries an attempt again and how long
+// it waits before doing so.
+type retryPolicy struct {
+	config    entities.RetryConfig
+	bodyMatch jp.Expr
+}
+
+// newRetryPolicy compiles the retry configuration of a client.
+func newRetryPolicy(config entities.RetryConfig) (*retryPolicy, error) {
+	policy := &retryPolicy{config: config}
+	if config.RetryOnBodyMatch != "" {
+		expr, err := jp.ParseString(config.RetryOnBodyMatch)
+		if err != nil {
+			return nil, fmt.Errorf("parsing retry_on_body_match %q: %w", config.RetryOnBodyMatch, err)
+		}
+		policy.bodyMatch = expr
+	}
+
+	return policy, nil
+}
+
+// configure installs the policy on a retrying client.
+func (it *retryPolicy) configure(client *retryablehttp.Client) {
+	client.CheckRetry = it.checkRetry
+	client.Backoff = it.backoff
+	client.RequestLogHook = it.logAttempt
+	client.PrepareRetry = resignRequest
+}
+
+// retryTransport is the retryablehttp.RoundTripper of a retrying client, passing
+// CloseIdleConnections on to the client it retries with, which the library does not.
+type retryTransport struct {
+	*retryablehttp.RoundTripper
+}
+
+// CloseIdleConnections closes the idle connections of the client the requests are retried with.
+func (it retryTransport) CloseIdleConnections() {
+	it.Client.HTTPClient.CloseIdleConnections()
+}
+
+// checkRetry is the retryablehttp.CheckRetry of the policy. It logs every retry it decides on.
+func (it *retryPolicy) checkRetry(ctx context.Context, response *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	retry, reason := it.shouldRetry(response, err)
+	if retry {
+		fields := map[string]any{"reason": reason}
+		if response != nil {
+			fields["status_code"] = response.StatusCode
+		}
+		tflog.Warn(ctx, "HTTP request failed and will be retried", fields)
+	}
+
+	return retry, nil
+}
+
+// shouldRetry applies the policy to the outcome of an attempt and explains a retry.
+func (it *retryPolicy) shouldRetry(response *http.Response, err error) (bool, string) {
+	if err != nil {
+		// the library knows which errors no retry can fix: a bad scheme, an untrusted certificate...
+		if retry, _ := retryablehttp.DefaultRetryPolicy(context.Background(), nil, err); !retry {
+			return false, ""
+		}
+		if !it.config.RetryNonIdempotent && !isIdempotentMethod(methodOfError(err)) && !isDialError(err) {
+			return false, ""
+		}
+
+		return true, err.Error()
+	}
+
+	if it.retriesStatus(response.StatusCode) {
+		if !it.config.RetryNonIdempotent && !isIdempotentMethod(response.Request.Method) &&
+			response.StatusCode != http.StatusTooManyRequests {
+			return false, ""
+		}
+
+		return true, "status " + response.Status
+	}
+
+	if it.bodyMatch != nil && it.bodyMatches(response) {
+		return true, "the response body matches retry_on_body_match"
+	}
+
+	return false, ""
+}
+
+// retriesStatus reports whether a response status is retried.
+func (it *retryPolicy) retriesStatus(status int) bool {
+	if it.config.RetryOnStatusCodes != nil {
+		return slices.Contains(it.config.RetryOnStatusCodes, status)
+	}
+
+	return status == http.StatusTooManyRequests || status == 0 ||
+		(status >= http.StatusInternalServerError && status != http.StatusNotImplemented)
+}
+
+// bodyMatches reports whether `retry_on_body_match` selects a value in the response body, which is
+// buffered and put back so the caller still reads the final one.
+func (it *retryPolicy) bodyMatches(response *http.Response) bool {
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	var document any
+	if json.Unmarshal(body, &document) != nil {
+		return false
+	}
+
+	return len(it.bodyMatch.Get(document)) > 0
+}
+
+// backoff is the retryablehttp.Backoff of the policy: the delay a `Retry-After` header asks for when
+// it is respected, otherwise an exponential one, both capped at maxDelay.
+func (it *retryPolicy) backoff(minDelay, maxDelay time.Duration, attempt int, response *http.Response) time.Duration {
+	if it.config.RespectRetryAfter && response != nil {
+		if delay, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			return min(delay, maxDelay)
+		}
+	}
+
+	delay := maxDelay
+	if exponential := math.Pow(2, float64(attempt)) * float64(minDelay); exponential < float64(maxDelay) {
+		delay = time.Duration(exponential)
+	}
+	if it.config.Jitter && delay > minDelay {
+		//nolint:gosec // the jitter spreads retries out, it needs no cryptographic randomness
+		delay = minDelay + rand.N(delay-minDelay+1)
+	}
+
+	return delay
+}
+
+// logAttempt is the retryablehttp.RequestLogHook of the policy, logging every attempt after the
+// first.
+func (it *retryPolicy) logAttempt(_ retryablehttp.Logger, request *http.Request, attempt int) {
+	if attempt == 0 {
+		return
+	}
+
+	tflog.Info(request.Context(), "Retrying HTTP request", map[string]any{
+		"attempt":      attempt,
+		"max_attempts": it.config.Attempts,
+		"method":       request.Method,
+		"url":          request.URL.Redacted(),
+	})
+}
+
+// parseRetryAfter parses a `Retry-After` header, either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// isIdempotentMethod reports whether sending the method twice has the effect of sending it once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// methodOfError returns the method of the request an http.Client error is about, which the client
+// records in the url.Error it returns.
+func methodOfError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return strings.ToUpper(urlErr.Op)
+	}
+
+	return ""
+}
+
+// isDialError reports whether an error happened before the connection was established, in which
+// case the request never reached the server.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
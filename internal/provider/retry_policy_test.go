@@ -0,0 +1,293 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// retryPolicyObject builds a `retry` block of two quick retries with the given policy arguments
+// set and the others null.
+func retryPolicyObject(policy map[string]attr.Value) types.Object {
+	values := map[string]attr.Value{
+		attrAttempts:           types.Int64Value(2),
+		attrMinDelayMs:         types.Int64Value(1),
+		attrMaxDelayMs:         types.Int64Value(2),
+		attrRetryOnStatusCodes: types.SetNull(types.Int32Type),
+		attrRespectRetryAfter:  types.BoolNull(),
+		attrJitter:             types.BoolNull(),
+		attrRetryNonIdempotent: types.BoolNull(),
+		attrRetryOnBodyMatch:   types.StringNull(),
+	}
+	for name, value := range policy {
+		values[name] = value
+	}
+
+	return types.ObjectValueMust(retryObjectAttrTypes(), values)
+}
+
+// sequenceServer answers the requests with the given statuses and bodies in turn, repeating the
+// last one, and counts the requests it received.
+func sequenceServer(t *testing.T, hits *int64, statuses []int, bodies []string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		index := min(int(atomic.AddInt64(hits, 1))-1, len(statuses)-1)
+		w.WriteHeader(statuses[index])
+		_, _ = io.WriteString(w, bodies[index])
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// retryModel builds a request with the method and the `retry` block given against the server.
+func retryModel(baseURL, method string, retry types.Object) HTTPRequestResourceModel {
+	model := pollingModel(baseURL, types.ObjectNull(waitForObjectAttrTypes()))
+	model.Method = types.StringValue(method)
+	model.Retry = retry
+
+	return model
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should not retry a POST the server may have processed", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var hits int64
+		server := sequenceServer(t, &hits, []int{http.StatusBadGateway, http.StatusCreated}, []string{"", `{}`})
+		it := &HTTPRequestResource{}
+		model := retryModel(server.URL, http.MethodPost, retryPolicyObject(nil))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusBadGateway, exchange.statusCode)
+		assert.Equal(t, int64(1), atomic.LoadInt64(&hits))
+	})
+
+	t.Run("should retry a POST the server answered with 429", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var hits int64
+		server := sequenceServer(t, &hits, []int{http.StatusTooManyRequests, http.StatusCreated}, []string{"", `{}`})
+		it := &HTTPRequestResource{}
+		model := retryModel(server.URL, http.MethodPost, retryPolicyObject(nil))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusCreated, exchange.statusCode)
+		assert.Equal(t, int64(2), atomic.LoadInt64(&hits))
+	})
+
+	t.Run("should retry a POST like any request when retry_non_idempotent is true", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var hits int64
+		server := sequenceServer(t, &hits, []int{http.StatusBadGateway, http.StatusCreated}, []string{"", `{}`})
+		it := &HTTPRequestResource{}
+		model := retryModel(server.URL, http.MethodPost, retryPolicyObject(map[string]attr.Value{
+			attrRetryNonIdempotent: types.BoolValue(true),
+		}))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusCreated, exchange.statusCode)
+	})
+
+	t.Run("should retry only the statuses in retry_on_status_codes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var conflictHits, errorHits int64
+		conflict := sequenceServer(t, &conflictHits, []int{http.StatusConflict, http.StatusOK}, []string{"", `{}`})
+		failure := sequenceServer(t, &errorHits, []int{http.StatusInternalServerError, http.StatusOK}, []string{"", `{}`})
+		retry := retryPolicyObject(map[string]attr.Value{
+			attrRetryOnStatusCodes: types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(409)}),
+		})
+		it := &HTTPRequestResource{}
+		var diagnostics diag.Diagnostics
+
+		// when
+		retried, retriedOK := it.performRequest(context.Background(),
+			retryModel(conflict.URL, http.MethodGet, retry), &diagnostics)
+		kept, keptOK := it.performRequest(context.Background(),
+			retryModel(failure.URL, http.MethodGet, retry), &diagnostics)
+
+		// then
+		require.True(t, retriedOK && keptOK, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, retried.statusCode)
+		assert.Equal(t, http.StatusInternalServerError, kept.statusCode)
+		assert.Equal(t, int64(1), atomic.LoadInt64(&errorHits))
+	})
+
+	t.Run("should retry a response whose body matches retry_on_body_match", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var hits int64
+		server := sequenceServer(t, &hits, []int{http.StatusOK, http.StatusOK},
+			[]string{`{"errors":[{"code":"RATE_LIMITED"}]}`, `{"id":"1"}`})
+		it := &HTTPRequestResource{}
+		model := retryModel(server.URL, http.MethodPost, retryPolicyObject(map[string]attr.Value{
+			attrRetryOnBodyMatch: types.StringValue("$.errors[?(@.code == 'RATE_LIMITED')]"),
+		}))
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.JSONEq(t, `{"id":"1"}`, string(exchange.body))
+		assert.Equal(t, int64(2), atomic.LoadInt64(&hits))
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {value}}}
+	}
+
+	t.Run("should wait for the Retry-After delay up to the maximum delay", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		policy, err := newRetryPolicy(entities.RetryConfig{RespectRetryAfter: true})
+		require.NoError(t, err)
+
+		// when
+		asked := policy.backoff(10*time.Millisecond, time.Minute, 0, retryAfter("3"))
+		capped := policy.backoff(10*time.Millisecond, 2*time.Second, 0, retryAfter("3"))
+
+		// then
+		assert.Equal(t, 3*time.Second, asked)
+		assert.Equal(t, 2*time.Second, capped)
+	})
+
+	t.Run("should ignore Retry-After when respect_retry_after is false", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		policy, err := newRetryPolicy(entities.RetryConfig{})
+		require.NoError(t, err)
+
+		// when
+		delay := policy.backoff(10*time.Millisecond, time.Minute, 2, retryAfter("3"))
+
+		// then
+		assert.Equal(t, 40*time.Millisecond, delay, "the exponential backoff of the third attempt")
+	})
+
+	t.Run("should keep a jittered delay between the minimum and the exponential one", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		policy, err := newRetryPolicy(entities.RetryConfig{Jitter: true})
+		require.NoError(t, err)
+
+		// when
+		delays := make(map[time.Duration]struct{})
+		for range 50 {
+			delays[policy.backoff(time.Millisecond, time.Second, 4, nil)] = struct{}{}
+		}
+
+		// then
+		assert.Greater(t, len(delays), 1, "the delays are spread out")
+		for delay := range delays {
+			assert.GreaterOrEqual(t, delay, time.Millisecond)
+			assert.LessOrEqual(t, delay, 16*time.Millisecond)
+		}
+	})
+}
+
+func TestCheckRetryArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a retry_on_body_match that does not parse", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		retry := retryPolicyObject(map[string]attr.Value{attrRetryOnBodyMatch: types.StringValue("$.errors[")})
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkRetryArguments(context.Background(), retry, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid JSONPath expression", diagnostics[0].Summary())
+	})
+
+	t.Run("should reject a status code outside the HTTP range", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		retry := retryPolicyObject(map[string]attr.Value{
+			attrRetryOnStatusCodes: types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(42)}),
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkRetryArguments(context.Background(), retry, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Contains(t, diagnostics[0].Summary(), attrRetryOnStatusCodes)
+	})
+}
+
+func TestRetryNativeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should carry the retry policy through the import identifier", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		retry := retryPolicyObject(map[string]attr.Value{
+			attrRetryOnStatusCodes: types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(409)}),
+			attrJitter:             types.BoolValue(true),
+			attrRetryOnBodyMatch:   types.StringValue("$.pending"),
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		restored := retryObjectFromNative(retryNativeFromObject(retry), &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.True(t, retry.Equal(restored))
+	})
+}
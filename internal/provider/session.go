@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+const (
+	attrSession            = "session"
+	attrLoginPath          = "login_path"
+	attrSuccessStatusCodes = "success_status_codes"
+	attrSuccessCookie      = "success_cookie"
+	attrLogoutPath         = "logout_path"
+	attrLogoutMethod       = "logout_method"
+)
+
+// Descriptions of the `session` arguments, which only the provider has: the session is shared by
+// every request it makes.
+const (
+	descSession = "A login that authenticates every request made by this provider with the cookies it " +
+		"sets, for consoles that expect a form POST from a browser. The provider logs in before its first " +
+		"request, keeps the cookies in a jar every request -- create, refresh, destroy and the read an " +
+		"import issues -- sends and updates, and logs in again once when a request is rejected with `401` " +
+		"or `403`."
+	descLoginPath      = "The path of the login request, relative to the provider `url`, or an absolute URL."
+	descSessionMethod  = "The HTTP method of the login request. Defaults to `POST`."
+	descSessionHeaders = "Headers sent with the login request, such as a `Content-Type` of " +
+		"`application/x-www-form-urlencoded` for a form."
+	descSessionRequestBody = "The body of the login request, which usually carries the credentials."
+	descSuccessStatusCodes = "The statuses of a successful login, once redirects are followed. By default " +
+		"any `2xx` status is."
+	descSuccessCookie = "The name of a cookie the login must set, for consoles that answer a failed login " +
+		"with the login form and a `200`."
+	descLogoutPath = "The path of a request, relative to the provider `url` or absolute, sent when the " +
+		"provider shuts down to end the session. The logout is best-effort: Terraform may stop the " +
+		"provider before it is sent, and a failed one is only logged, so the console must still let " +
+		"sessions expire. By default the session is left to expire."
+	descLogoutMethod = "The HTTP method of the logout request. Defaults to `POST`."
+)
+
+// sessionLogoutTimeout bounds the logout sent at shutdown, which Terraform does not wait long for.
+const sessionLogoutTimeout = 2 * time.Second
+
+// openSessions are the sessions of the providers this process configured, which Shutdown ends.
+//
+//nolint:gochecknoglobals // Terraform gives providers no hook to run when it is done with them
+var openSessions struct {
+	mutex    sync.Mutex
+	sessions []*entities.Session
+}
+
+// sessionObjectAttrTypes returns the attribute types of the `session` nested object.
+func sessionObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrLoginPath:          types.StringType,
+		attrMethod:             types.StringType,
+		attrHeaders:            types.MapType{ElemType: types.StringType},
+		attrRequestBody:        types.StringType,
+		attrSuccessStatusCodes: types.ListType{ElemType: types.Int64Type},
+		attrSuccessCookie:      types.StringType,
+		attrLogoutPath:         types.StringType,
+		attrLogoutMethod:       types.StringType,
+	}
+}
+
+// sessionBlock builds the `session` block of the provider schema.
+func sessionBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description:         descSession,
+		MarkdownDescription: descSession,
+		Attributes: map[string]schema.Attribute{
+			attrLoginPath: providerOptionalString(descLoginPath, false),
+			attrMethod:    providerOptionalString(descSessionMethod, false),
+			attrHeaders: schema.MapAttribute{
+				Description:         descSessionHeaders,
+				MarkdownDescription: descSessionHeaders,
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			attrRequestBody: providerOptionalString(descSessionRequestBody, true),
+			attrSuccessStatusCodes: schema.ListAttribute{
+				Description:         descSuccessStatusCodes,
+				MarkdownDescription: descSuccessStatusCodes,
+				Optional:            true,
+				ElementType:         types.Int64Type,
+			},
+			attrSuccessCookie: providerOptionalString(descSuccessCookie, false),
+			attrLogoutPath:    providerOptionalString(descLogoutPath, false),
+			attrLogoutMethod:  providerOptionalString(descLogoutMethod, false),
+		},
+	}
+}
+
+// checkSessionArguments reports a `session` block without a `login_path`. Unknown values pass.
+func checkSessionArguments(model HTTPProviderModel, diagnostics *diag.Diagnostics) {
+	if model.Session.IsNull() || model.Session.IsUnknown() {
+		return
+	}
+
+	if loginPath, _ := model.Session.Attributes()[attrLoginPath].(types.String); loginPath.IsNull() {
+		diagnostics.AddAttributeError(
+			path.Root(attrSession).AtName(attrLoginPath),
+			"Invalid session",
+			"`login_path` must be set to the path of the login request.",
+		)
+	}
+}
+
+// providerSession converts the `session` block, resolving its paths against the provider URL. It
+// returns nil when the block is not set, and reports a relative path without a provider URL.
+func providerSession(
+	ctx context.Context, obj types.Object, baseURL string, diagnostics *diag.Diagnostics,
+) *entities.SessionConfig {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	cfg := &entities.SessionConfig{
+		LoginMethod:   objectStringOf(obj, attrMethod),
+		Body:          objectStringOf(obj, attrRequestBody),
+		SuccessCookie: objectStringOf(obj, attrSuccessCookie),
+		LogoutMethod:  objectStringOf(obj, attrLogoutMethod),
+	}
+	if headers, ok := obj.Attributes()[attrHeaders].(types.Map); ok {
+		cfg.Headers = stringMapOf(ctx, headers, diagnostics)
+	}
+	if codes, ok := obj.Attributes()[attrSuccessStatusCodes].(types.List); ok && !codes.IsNull() && !codes.IsUnknown() {
+		diagnostics.Append(codes.ElementsAs(ctx, &cfg.SuccessStatusCodes, false)...)
+	}
+
+	for _, target := range []struct {
+		name string
+		url  *string
+	}{
+		{attrLoginPath, &cfg.LoginURL},
+		{attrLogoutPath, &cfg.LogoutURL},
+	} {
+		relative := objectStringOf(obj, target.name)
+		if relative == "" {
+			continue
+		}
+		resolved, err := sessionURL(baseURL, relative)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root(attrSession).AtName(target.name),
+				"Invalid session",
+				fmt.Sprintf("`%s` cannot be resolved: %s.", target.name, err),
+			)
+
+			continue
+		}
+		*target.url = resolved
+	}
+
+	return cfg
+}
+
+// sessionURL resolves a login or logout path: an absolute URL is used as it is, and a path is
+// joined to the provider URL as the path of a resource is.
+func sessionURL(baseURL, relative string) (string, error) {
+	if parsed, err := url.Parse(relative); err == nil && parsed.IsAbs() {
+		return relative, nil
+	}
+	if baseURL == "" {
+		return "", fmt.Errorf("%q is a path but the provider `url` is not set", relative)
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing the provider url: %w", err)
+	}
+	if socket, _ := entities.UnixSocketOf(base); socket != nil {
+		base = socket.URL()
+	}
+
+	return joinURL(base, relative, nil)
+}
+
+// newSession builds the session of a provider on its client, dialing the socket of a `unix://`
+// provider URL, and registers it to be ended when the provider shuts down.
+func newSession(config entities.SessionConfig, internal *entities.InternalContext) (*entities.Session, error) {
+	client := *internal.Client
+	if internal.Config.RequestTimeoutMs > 0 {
+		client.Timeout = time.Duration(internal.Config.RequestTimeoutMs) * time.Millisecond
+	}
+	if base, err := url.Parse(internal.Config.URL); err == nil {
+		socket, socketErr := entities.UnixSocketOf(base)
+		if socketErr != nil {
+			return nil, socketErr
+		}
+		if socket != nil {
+			transport, transportErr := entities.NewUnixSocketTransport(socket.Path, internal.Config.ConnectionPool)
+			if transportErr != nil {
+				return nil, transportErr
+			}
+			client.Transport = transport
+		}
+	}
+	client.Transport = internal.Throttle.Wrap(client.Transport)
+
+	session := entities.NewSession(config, &client)
+	openSessions.mutex.Lock()
+	openSessions.sessions = append(openSessions.sessions, session)
+	openSessions.mutex.Unlock()
+
+	return session, nil
+}
+
+// Shutdown ends the sessions the providers of this process logged in to, sending the logout
+// request of each. It is called once Terraform is done with the provider, and is best-effort:
+// a provider Terraform kills never reaches it, and a logout that fails or outlasts
+// sessionLogoutTimeout is only logged.
+func Shutdown(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, sessionLogoutTimeout)
+	defer cancel()
+
+	openSessions.mutex.Lock()
+	sessions := openSessions.sessions
+	openSessions.sessions = nil
+	openSessions.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, session := range sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := session.Logout(ctx); err != nil {
+				tflog.Warn(ctx, "Ending the session failed", map[string]any{"error": err.Error()})
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// session returns the session of the provider, or nil when none is configured.
+func (it *HTTPRequestResource) session() *entities.Session {
+	if it.internal == nil {
+		return nil
+	}
+
+	return it.internal.Session
+}
+
+// isSessionRejection reports whether a response rejects the session it was sent with.
+func isSessionRejection(response *http.Response) bool {
+	return response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,268 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// consoleServer is an admin console that logs in with a form and redirects to its dashboard,
+// answering its API only with the cookie of the current session.
+type consoleServer struct {
+	mutex   sync.Mutex
+	logins  int
+	logouts int
+	current string
+}
+
+func newConsoleServer(t *testing.T) (*httptest.Server, *consoleServer) {
+	t.Helper()
+
+	console := &consoleServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		console.mutex.Lock()
+		defer console.mutex.Unlock()
+
+		if string(body) != "user=admin&password=secret" {
+			w.WriteHeader(http.StatusOK) // the login form again, as consoles do
+			return
+		}
+		console.logins++
+		console.current = "session-" + strconv.Itoa(console.logins)
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: console.current, Path: "/"})
+		http.Redirect(w, r, "/dashboard", http.StatusFound)
+	})
+	mux.HandleFunc("GET /dashboard", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("POST /logout", func(w http.ResponseWriter, r *http.Request) {
+		console.mutex.Lock()
+		defer console.mutex.Unlock()
+
+		if cookie, err := r.Cookie("JSESSIONID"); err == nil && cookie.Value == console.current {
+			console.logouts++
+			console.current = ""
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		console.mutex.Lock()
+		defer console.mutex.Unlock()
+
+		if cookie, err := r.Cookie("JSESSIONID"); err != nil || cookie.Value != console.current {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, console
+}
+
+// expire ends the current session on the server side, as a session timeout does.
+func (it *consoleServer) expire() {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	it.current = ""
+}
+
+func (it *consoleServer) counts() (int, int) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	return it.logins, it.logouts
+}
+
+// sessionObject builds a provider-level `session` value logging in to the console, with the given
+// optional arguments set and the others null.
+func sessionObject(optional map[string]attr.Value) types.Object {
+	values := map[string]attr.Value{
+		attrLoginPath: types.StringValue("/login"),
+		attrHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Content-Type": types.StringValue("application/x-www-form-urlencoded"),
+		}),
+		attrRequestBody: types.StringValue("user=admin&password=secret"),
+	}
+	for name, attrType := range sessionObjectAttrTypes() {
+		if _, set := values[name]; !set {
+			values[name] = newNullValue(attrType)
+		}
+	}
+	for name, value := range optional {
+		values[name] = value
+	}
+
+	return types.ObjectValueMust(sessionObjectAttrTypes(), values)
+}
+
+// newNullValue returns the null value of an attribute type.
+func newNullValue(attrType attr.Type) attr.Value {
+	ctx := context.Background()
+	value, _ := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+
+	return value
+}
+
+// resourceWithSession returns a resource whose provider logs in to the console with the session
+// given.
+func resourceWithSession(t *testing.T, serverURL string, session types.Object) *HTTPRequestResource {
+	t.Helper()
+
+	internal := entities.NewInternalContext(false, entities.NewConfiguration(serverURL))
+	var diagnostics diag.Diagnostics
+	config := providerSession(context.Background(), session, serverURL, &diagnostics)
+	require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+	internal.Config.Session = config
+	var err error
+	internal.Session, err = newSession(*config, internal)
+	require.NoError(t, err)
+
+	return &HTTPRequestResource{internal: internal}
+}
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should log in once and send the session cookie with every request", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, console := newConsoleServer(t)
+		it := resourceWithSession(t, server.URL, sessionObject(nil))
+		model := pollingModel("", types.ObjectNull(waitForObjectAttrTypes()))
+		model.BaseURL = types.StringNull()
+		var diagnostics diag.Diagnostics
+
+		// when
+		for range 3 {
+			exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+			require.True(t, ok, "diagnostics: %v", diagnostics)
+			require.Equal(t, http.StatusOK, exchange.statusCode)
+		}
+
+		// then
+		logins, _ := console.counts()
+		assert.Equal(t, 1, logins)
+	})
+
+	t.Run("should log in again when the session is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, console := newConsoleServer(t)
+		it := resourceWithSession(t, server.URL, sessionObject(nil))
+		model := pollingModel("", types.ObjectNull(waitForObjectAttrTypes()))
+		model.BaseURL = types.StringNull()
+		var diagnostics diag.Diagnostics
+		_, ok := it.performRequest(context.Background(), model, &diagnostics)
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		console.expire()
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, exchange.statusCode)
+		logins, _ := console.counts()
+		assert.Equal(t, 2, logins)
+	})
+
+	t.Run("should fail when the login does not set the expected cookie", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, _ := newConsoleServer(t)
+		it := resourceWithSession(t, server.URL, sessionObject(map[string]attr.Value{
+			attrRequestBody:   types.StringValue("user=admin&password=wrong"),
+			attrSuccessCookie: types.StringValue("JSESSIONID"),
+		}))
+		model := pollingModel("", types.ObjectNull(waitForObjectAttrTypes()))
+		model.BaseURL = types.StringNull()
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.False(t, ok)
+		assert.Contains(t, diagnostics[0].Detail(), `without setting the "JSESSIONID" cookie`)
+	})
+
+	t.Run("should log out when the provider shuts down", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, console := newConsoleServer(t)
+		it := resourceWithSession(t, server.URL, sessionObject(map[string]attr.Value{
+			attrLogoutPath: types.StringValue("/logout"),
+		}))
+		model := pollingModel("", types.ObjectNull(waitForObjectAttrTypes()))
+		model.BaseURL = types.StringNull()
+		var diagnostics diag.Diagnostics
+		_, ok := it.performRequest(context.Background(), model, &diagnostics)
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+
+		// when
+		Shutdown(context.Background())
+
+		// then
+		_, logouts := console.counts()
+		assert.Equal(t, 1, logouts)
+	})
+}
+
+func TestProviderSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a login path when the provider has no url", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+
+		// when
+		providerSession(context.Background(), sessionObject(nil), "", &diagnostics)
+
+		// then
+		require.True(t, diagnostics.HasError())
+		assert.Equal(t, "Invalid session", diagnostics[0].Summary())
+	})
+
+	t.Run("should keep an absolute login URL as it is", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+		session := sessionObject(map[string]attr.Value{
+			attrLoginPath: types.StringValue("https://sso.example.test/login"),
+		})
+
+		// when
+		config := providerSession(context.Background(), session, "https://console.example.test/api", &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.Equal(t, "https://sso.example.test/login", config.LoginURL)
+	})
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+const (
+	attrRateLimit             = "rate_limit"
+	attrRequestsPerSecond     = "requests_per_second"
+	attrBurst                 = "burst"
+	attrMaxConcurrentRequests = "max_concurrent_requests"
+	attrThrottlePerHost       = "throttle_per_host"
+)
+
+// Descriptions of the throttling arguments, which only the provider has: a limit is only useful
+// when every request counts against it.
+const (
+	descRateLimit = "Caps the rate every request made by this provider starts at, so a large apply " +
+		"stays under the throttling of the API instead of failing with `429`. Each retry attempt " +
+		"counts as a request. By default there is no limit."
+	descRequestsPerSecond = "The sustained number of requests started per second. Fractions are " +
+		"allowed, `0.5` meaning one request every two seconds."
+	descBurst = "How many requests may start at once before `requests_per_second` applies. " +
+		"Defaults to `1`."
+	descMaxConcurrentRequests = "The maximum number of requests in flight at once, whatever the " +
+		"`-parallelism` of Terraform. By default there is no limit."
+	descThrottlePerHost = "Whether `rate_limit` and `max_concurrent_requests` apply to each host " +
+		"separately instead of to all requests together. Defaults to `false`."
+)
+
+// rateLimitObjectAttrTypes returns the attribute types of the `rate_limit` nested object.
+func rateLimitObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrRequestsPerSecond: types.Float64Type,
+		attrBurst:             types.Int64Type,
+	}
+}
+
+// rateLimitBlock builds the `rate_limit` block of the provider schema.
+func rateLimitBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description:         descRateLimit,
+		MarkdownDescription: descRateLimit,
+		Attributes: map[string]schema.Attribute{
+			attrRequestsPerSecond: schema.Float64Attribute{
+				Description:         descRequestsPerSecond,
+				MarkdownDescription: descRequestsPerSecond,
+				Optional:            true,
+			},
+			attrBurst: providerOptionalInt64(descBurst),
+		},
+	}
+}
+
+// addProviderThrottleAttributes adds `max_concurrent_requests` and `throttle_per_host` to the
+// provider schema; the `rate_limit` block is added with the other blocks.
+func addProviderThrottleAttributes(attrs map[string]schema.Attribute) {
+	attrs[attrMaxConcurrentRequests] = providerOptionalInt64(descMaxConcurrentRequests)
+	attrs[attrThrottlePerHost] = schema.BoolAttribute{
+		Description:         descThrottlePerHost,
+		MarkdownDescription: descThrottlePerHost,
+		Optional:            true,
+	}
+}
+
+// checkThrottleArguments reports a `rate_limit` block without a positive `requests_per_second`, a
+// `burst` below one and a `max_concurrent_requests` below one. Unknown values pass.
+func checkThrottleArguments(model HTTPProviderModel, diagnostics *diag.Diagnostics) {
+	if !model.RateLimit.IsNull() && !model.RateLimit.IsUnknown() {
+		attrs := model.RateLimit.Attributes()
+		rate, _ := attrs[attrRequestsPerSecond].(types.Float64)
+		if !rate.IsUnknown() && (rate.IsNull() || rate.ValueFloat64() <= 0) {
+			diagnostics.AddAttributeError(
+				path.Root(attrRateLimit).AtName(attrRequestsPerSecond),
+				"Invalid rate limit",
+				"`requests_per_second` must be set to a number greater than zero.",
+			)
+		}
+		if burst, ok := attrs[attrBurst].(types.Int64); ok && !burst.IsNull() && !burst.IsUnknown() &&
+			burst.ValueInt64() < 1 {
+			diagnostics.AddAttributeError(
+				path.Root(attrRateLimit).AtName(attrBurst),
+				"Invalid rate limit",
+				fmt.Sprintf("`burst` must be one or more, got %d.", burst.ValueInt64()),
+			)
+		}
+	}
+
+	if value := model.MaxConcurrentRequests; !value.IsNull() && !value.IsUnknown() && value.ValueInt64() < 1 {
+		diagnostics.AddAttributeError(
+			path.Root(attrMaxConcurrentRequests),
+			"Invalid max_concurrent_requests",
+			fmt.Sprintf("`max_concurrent_requests` must be one or more, got %d.", value.ValueInt64()),
+		)
+	}
+}
+
+// throttleConfigOf converts the throttling arguments, returning nil when they set no limit.
+func throttleConfigOf(model HTTPProviderModel) *entities.ThrottleConfig {
+	cfg := &entities.ThrottleConfig{
+		PerHost: model.ThrottlePerHost.ValueBool(),
+	}
+	if !model.RateLimit.IsNull() && !model.RateLimit.IsUnknown() {
+		attrs := model.RateLimit.Attributes()
+		if rate, ok := attrs[attrRequestsPerSecond].(types.Float64); ok {
+			cfg.RequestsPerSecond = rate.ValueFloat64()
+		}
+		if burst, ok := attrs[attrBurst].(types.Int64); ok {
+			cfg.Burst = int(burst.ValueInt64())
+		}
+	}
+	if !model.MaxConcurrentRequests.IsUnknown() {
+		cfg.MaxConcurrentRequests = int(model.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if cfg.IsEmpty() {
+		return nil
+	}
+
+	return cfg
+}
@@ -0,0 +1,239 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// resourceWithThrottle builds a resource whose provider enforces the given limits, the way
+// Configure does.
+func resourceWithThrottle(config entities.ThrottleConfig) *HTTPRequestResource {
+	internal := entities.NewInternalContext(false, entities.NewConfiguration(""))
+	internal.Config.Throttle = &config
+	internal.Throttle = entities.NewThrottle(config)
+
+	return &HTTPRequestResource{internal: internal}
+}
+
+// inFlightServer answers every request after a short delay, recording the most requests it was
+// serving at once.
+func inFlightServer(t *testing.T, peak *int64) *httptest.Server {
+	t.Helper()
+
+	var inFlight int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		current := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			previous := atomic.LoadInt64(peak)
+			if current <= previous || atomic.CompareAndSwapInt64(peak, previous, current) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// rateLimitObject builds a `rate_limit` value.
+func rateLimitObject(requestsPerSecond float64, burst types.Int64) types.Object {
+	return types.ObjectValueMust(rateLimitObjectAttrTypes(), map[string]attr.Value{
+		attrRequestsPerSecond: types.Float64Value(requestsPerSecond),
+		attrBurst:             burst,
+	})
+}
+
+// throttleProviderModel returns a provider model with no throttling argument set.
+func throttleProviderModel() HTTPProviderModel {
+	return HTTPProviderModel{
+		RateLimit:             types.ObjectNull(rateLimitObjectAttrTypes()),
+		MaxConcurrentRequests: types.Int64Null(),
+		ThrottlePerHost:       types.BoolNull(),
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should space requests out to the configured rate", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var peak int64
+		server := inFlightServer(t, &peak)
+		it := resourceWithThrottle(entities.ThrottleConfig{RequestsPerSecond: 40, Burst: 1})
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		var diagnostics diag.Diagnostics
+		started := time.Now()
+
+		// when
+		for range 5 {
+			_, ok := it.performRequest(context.Background(), model, &diagnostics)
+			require.True(t, ok, "diagnostics: %v", diagnostics)
+		}
+
+		// then
+		assert.GreaterOrEqual(t, time.Since(started), 100*time.Millisecond,
+			"four requests after the first wait a fortieth of a second each")
+	})
+
+	t.Run("should cap the requests in flight across concurrent operations", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var peak int64
+		server := inFlightServer(t, &peak)
+		it := resourceWithThrottle(entities.ThrottleConfig{MaxConcurrentRequests: 2})
+		model := pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes()))
+		var failures int64
+		var wg sync.WaitGroup
+
+		// when
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var diagnostics diag.Diagnostics
+				if _, ok := it.performRequest(context.Background(), model, &diagnostics); !ok {
+					atomic.AddInt64(&failures, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// then
+		assert.Zero(t, atomic.LoadInt64(&failures))
+		assert.Equal(t, int64(2), atomic.LoadInt64(&peak))
+	})
+
+	t.Run("should give each host a limit of its own when scoped per host", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var peak int64
+		server := inFlightServer(t, &peak)
+		// the same server under another host name counts as another host
+		otherHost := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+		it := resourceWithThrottle(entities.ThrottleConfig{RequestsPerSecond: 1, Burst: 1, PerHost: true})
+		var diagnostics diag.Diagnostics
+		started := time.Now()
+
+		// when
+		_, firstOK := it.performRequest(context.Background(),
+			pollingModel(server.URL, types.ObjectNull(waitForObjectAttrTypes())), &diagnostics)
+		_, secondOK := it.performRequest(context.Background(),
+			pollingModel(otherHost, types.ObjectNull(waitForObjectAttrTypes())), &diagnostics)
+
+		// then
+		require.True(t, firstOK && secondOK, "diagnostics: %v", diagnostics)
+		assert.Less(t, time.Since(started), 500*time.Millisecond,
+			"the second host does not wait for the one request per second of the first")
+	})
+
+	t.Run("should stop waiting for a slot when the request is cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		throttle := entities.NewThrottle(entities.ThrottleConfig{MaxConcurrentRequests: 1})
+		blocked := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			<-blocked
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(blocked) })
+		client := &http.Client{Transport: throttle.Wrap(nil)}
+		go func() {
+			request, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			if response, err := client.Do(request); err == nil {
+				_ = response.Body.Close()
+			}
+		}()
+		time.Sleep(50 * time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// when
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		_, err = client.Do(request) //nolint:bodyclose // the request never gets a response
+
+		// then
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestCheckThrottleArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a rate_limit without a positive requests_per_second", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := throttleProviderModel()
+		model.RateLimit = rateLimitObject(0, types.Int64Null())
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkThrottleArguments(model, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid rate limit", diagnostics[0].Summary())
+	})
+
+	t.Run("should reject a max_concurrent_requests below one", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := throttleProviderModel()
+		model.MaxConcurrentRequests = types.Int64Value(0)
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkThrottleArguments(model, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid max_concurrent_requests", diagnostics[0].Summary())
+	})
+
+	t.Run("should convert the arguments into the throttle configuration", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := throttleProviderModel()
+		model.RateLimit = rateLimitObject(10, types.Int64Value(5))
+		model.MaxConcurrentRequests = types.Int64Value(4)
+		model.ThrottlePerHost = types.BoolValue(true)
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkThrottleArguments(model, &diagnostics)
+		config := throttleConfigOf(model)
+
+		// then
+		assert.Empty(t, diagnostics)
+		assert.Equal(t, &entities.ThrottleConfig{
+			RequestsPerSecond: 10, Burst: 5, MaxConcurrentRequests: 4, PerHost: true,
+		}, config)
+	})
+}
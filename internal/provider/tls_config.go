@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+const (
+	attrCACertPEM      = "ca_cert_pem"
+	attrCACertFile     = "ca_cert_file"
+	attrClientCertPEM  = "client_cert_pem"
+	attrClientKeyPEM   = "client_key_pem"
+	attrClientCertFile = "client_cert_file"
+	attrClientKeyFile  = "client_key_file"
+	attrTLSServerName  = "tls_server_name"
+	attrMinTLSVersion  = "min_tls_version"
+)
+
+// Descriptions of the TLS arguments, shared by the provider and the resource schema builders.
+const (
+	descCACertPEM = "PEM-encoded CA certificates trusted, in addition to the system pool, to verify " +
+		"the server certificate. Conflicts with `ca_cert_file`."
+	descCACertFile = "Path to a file holding PEM-encoded CA certificates, trusted like `ca_cert_pem`. " +
+		"Conflicts with `ca_cert_pem`."
+	descClientCertPEM = "PEM-encoded client certificate presented for mutual TLS. Requires " +
+		"`client_key_pem` or `client_key_file`."
+	descClientKeyPEM   = "PEM-encoded private key of the client certificate."
+	descClientCertFile = "Path to a file holding the PEM-encoded client certificate. Conflicts with " +
+		"`client_cert_pem`."
+	descClientKeyFile = "Path to a file holding the PEM-encoded private key of the client certificate. " +
+		"Conflicts with `client_key_pem`."
+	descTLSServerName = "Name the server certificate is verified against, also sent as SNI, when it " +
+		"differs from the host of the URL."
+	descMinTLSVersion = "Lowest TLS version accepted: `1.0`, `1.1`, `1.2` (the default) or `1.3`."
+)
+
+// tlsArguments are the TLS arguments the provider and the resource share. Both models embed them.
+type tlsArguments struct {
+	CACertPEM      types.String `tfsdk:"ca_cert_pem"      json:"-"`
+	CACertFile     types.String `tfsdk:"ca_cert_file"     json:"-"`
+	ClientCertPEM  types.String `tfsdk:"client_cert_pem"  json:"-"`
+	ClientKeyPEM   types.String `tfsdk:"client_key_pem"   json:"-"`
+	ClientCertFile types.String `tfsdk:"client_cert_file" json:"-"`
+	ClientKeyFile  types.String `tfsdk:"client_key_file"  json:"-"`
+	TLSServerName  types.String `tfsdk:"tls_server_name"  json:"-"`
+	MinTLSVersion  types.String `tfsdk:"min_tls_version"  json:"-"`
+}
+
+// nullTLSArguments returns TLS arguments that are all null, as a model without them holds.
+func nullTLSArguments() tlsArguments {
+	return tlsArguments{
+		CACertPEM:      types.StringNull(),
+		CACertFile:     types.StringNull(),
+		ClientCertPEM:  types.StringNull(),
+		ClientKeyPEM:   types.StringNull(),
+		ClientCertFile: types.StringNull(),
+		ClientKeyFile:  types.StringNull(),
+		TLSServerName:  types.StringNull(),
+		MinTLSVersion:  types.StringNull(),
+	}
+}
+
+// addTLSAttributes adds the TLS arguments to the resource schema. Like `request_timeout_ms` they
+// only change how a request is transported, so none of them forces replacement.
+func addTLSAttributes(attrs map[string]schema.Attribute) {
+	override := func(description string) string {
+		return description + " When specified, this overrides the provider-level value."
+	}
+
+	attrs[attrCACertPEM] = helpers.StringAttributeNoReplace(false, override(descCACertPEM))
+	attrs[attrCACertFile] = helpers.StringAttributeNoReplace(false, override(descCACertFile))
+	attrs[attrClientCertPEM] = helpers.StringAttributeNoReplace(false, override(descClientCertPEM))
+	clientKey := helpers.StringAttributeNoReplace(false, override(descClientKeyPEM))
+	clientKey.Sensitive = true
+	attrs[attrClientKeyPEM] = clientKey
+	attrs[attrClientCertFile] = helpers.StringAttributeNoReplace(false, override(descClientCertFile))
+	attrs[attrClientKeyFile] = helpers.StringAttributeNoReplace(false, override(descClientKeyFile))
+	attrs[attrTLSServerName] = helpers.StringAttributeNoReplace(false, override(descTLSServerName))
+	attrs[attrMinTLSVersion] = helpers.StringAttributeNoReplace(false, override(descMinTLSVersion))
+}
+
+// validateTLS checks the TLS arguments of the resource with checkTLSArguments.
+func validateTLS(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	args := nullTLSArguments()
+	for name, target := range map[string]*types.String{
+		attrCACertPEM:      &args.CACertPEM,
+		attrCACertFile:     &args.CACertFile,
+		attrClientCertPEM:  &args.ClientCertPEM,
+		attrClientKeyPEM:   &args.ClientKeyPEM,
+		attrClientCertFile: &args.ClientCertFile,
+		attrClientKeyFile:  &args.ClientKeyFile,
+		attrTLSServerName:  &args.TLSServerName,
+		attrMinTLSVersion:  &args.MinTLSVersion,
+	} {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), target)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkTLSArguments(args, &resp.Diagnostics)
+}
+
+// checkTLSArguments reports arguments that conflict with their file or PEM counterpart, a client
+// certificate without its key or the reverse, and an unknown `min_tls_version`. Unknown values pass.
+func checkTLSArguments(args tlsArguments, diagnostics *diag.Diagnostics) {
+	conflicts := []struct {
+		pem, file         types.String
+		pemName, fileName string
+	}{
+		{args.CACertPEM, args.CACertFile, attrCACertPEM, attrCACertFile},
+		{args.ClientCertPEM, args.ClientCertFile, attrClientCertPEM, attrClientCertFile},
+		{args.ClientKeyPEM, args.ClientKeyFile, attrClientKeyPEM, attrClientKeyFile},
+	}
+	for _, conflict := range conflicts {
+		if !conflict.pem.IsNull() && !conflict.file.IsNull() {
+			diagnostics.AddAttributeError(
+				path.Root(conflict.fileName),
+				"Conflicting TLS arguments",
+				fmt.Sprintf("Only one of `%s` and `%s` can be set.", conflict.pemName, conflict.fileName),
+			)
+		}
+	}
+
+	hasCert := !args.ClientCertPEM.IsNull() || !args.ClientCertFile.IsNull()
+	hasKey := !args.ClientKeyPEM.IsNull() || !args.ClientKeyFile.IsNull()
+	if hasCert != hasKey {
+		diagnostics.AddAttributeError(
+			path.Root(attrClientCertPEM),
+			"Incomplete client certificate",
+			"A client certificate needs both the certificate (`client_cert_pem` or `client_cert_file`) "+
+				"and its private key (`client_key_pem` or `client_key_file`).",
+		)
+	}
+
+	if isKnownString(args.MinTLSVersion) {
+		if _, ok := entities.TLSVersion(args.MinTLSVersion.ValueString()); !ok {
+			diagnostics.AddAttributeError(
+				path.Root(attrMinTLSVersion),
+				"Invalid minimum TLS version",
+				fmt.Sprintf("%q is not one of 1.0, 1.1, 1.2 or 1.3.", args.MinTLSVersion.ValueString()),
+			)
+		}
+	}
+}
+
+// isKnownString reports whether a string is neither null nor unknown.
+func isKnownString(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// tlsConfigOf resolves the TLS arguments, reading the files they name. It returns nil when none of
+// them is set.
+func tlsConfigOf(args tlsArguments) (*entities.TLSConfig, error) {
+	readPEM := func(pem, file types.String) (string, error) {
+		if isKnownString(pem) {
+			return pem.ValueString(), nil
+		}
+		if !isKnownString(file) {
+			return "", nil
+		}
+
+		contents, err := os.ReadFile(file.ValueString())
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", file.ValueString(), err)
+		}
+
+		return string(contents), nil
+	}
+
+	var cfg entities.TLSConfig
+	var err error
+	if cfg.CACertPEM, err = readPEM(args.CACertPEM, args.CACertFile); err != nil {
+		return nil, err
+	}
+	if cfg.ClientCertPEM, err = readPEM(args.ClientCertPEM, args.ClientCertFile); err != nil {
+		return nil, err
+	}
+	if cfg.ClientKeyPEM, err = readPEM(args.ClientKeyPEM, args.ClientKeyFile); err != nil {
+		return nil, err
+	}
+	cfg.ServerName = args.TLSServerName.ValueString()
+	cfg.MinVersion = args.MinTLSVersion.ValueString()
+
+	if cfg.IsEmpty() {
+		return nil, nil //nolint:nilnil // nil means "nothing configured"
+	}
+
+	return &cfg, nil
+}
+
+// resolveTLS resolves the effective TLS configuration with the precedence of resolveIgnoreTLS: each
+// setting the resource configures wins over the provider-level one. The CA bundle and the client
+// certificate with its key are each taken as a whole. ownSettings reports whether the resource
+// configures any of them; nil means neither level does.
+func (it *HTTPRequestResource) resolveTLS(
+	model HTTPRequestResourceModel,
+) (resolved *entities.TLSConfig, ownSettings bool, err error) {
+	var merged entities.TLSConfig
+	if config := it.providerConfig(); config != nil && config.TLS != nil {
+		merged = *config.TLS
+	}
+
+	own, err := tlsConfigOf(model.tlsArguments)
+	if err != nil {
+		return nil, false, err
+	}
+	if own != nil {
+		if own.CACertPEM != "" {
+			merged.CACertPEM = own.CACertPEM
+		}
+		if own.ClientCertPEM != "" || own.ClientKeyPEM != "" {
+			merged.ClientCertPEM, merged.ClientKeyPEM = own.ClientCertPEM, own.ClientKeyPEM
+		}
+		if own.ServerName != "" {
+			merged.ServerName = own.ServerName
+		}
+		if own.MinVersion != "" {
+			merged.MinVersion = own.MinVersion
+		}
+	}
+
+	if merged.IsEmpty() {
+		return nil, false, nil
+	}
+
+	return &merged, own != nil, nil
+}
@@ -0,0 +1,361 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// testPKI is a private CA with a server certificate for 127.0.0.1 and a client certificate, all
+// generated per test so no key material lives in the repository.
+type testPKI struct {
+	caPEM         string
+	pool          *x509.CertPool
+	server        tls.Certificate
+	clientCertPEM string
+	clientKeyPEM  string
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	issue := func(serial int64, template *x509.Certificate) (string, string) {
+		key, keyErr := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, keyErr)
+		template.SerialNumber = big.NewInt(serial)
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(time.Hour)
+		der, certErr := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, certErr)
+		keyDER, keyErr := x509.MarshalECPrivateKey(key)
+		require.NoError(t, keyErr)
+
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+			string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	}
+
+	serverCertPEM, serverKeyPEM := issue(2, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "api.internal.test"},
+		DNSNames:    []string{"api.internal.test"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	serverCert, err := tls.X509KeyPair([]byte(serverCertPEM), []byte(serverKeyPEM))
+	require.NoError(t, err)
+
+	clientCertPEM, clientKeyPEM := issue(3, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "terraform"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	return testPKI{
+		caPEM:         string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})),
+		pool:          pool,
+		server:        serverCert,
+		clientCertPEM: clientCertPEM,
+		clientKeyPEM:  clientKeyPEM,
+	}
+}
+
+// tlsServer starts an HTTPS server with the server certificate of the PKI. With mutualTLS it only
+// accepts clients presenting a certificate the CA issued.
+func tlsServer(t *testing.T, pki testPKI, mutualTLS bool, maxVersion uint16) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   maxVersion,
+		Certificates: []tls.Certificate{pki.server},
+	}
+	if mutualTLS {
+		server.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		server.TLS.ClientCAs = pki.pool
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// getWithTLS sends a GET through the client the resource builds for the model.
+func getWithTLS(it *HTTPRequestResource, model HTTPRequestResourceModel, url string) error {
+	client, err := it.getHTTPClient(context.Background(), model)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func TestTLSSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a server certificate from an unknown CA by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		pki := newTestPKI(t)
+		server := tlsServer(t, pki, false, 0)
+		it := &HTTPRequestResource{}
+
+		// when
+		err := getWithTLS(it, requestModel(types.MapNull(types.StringType)), server.URL)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should trust a server certificate issued by the resource ca_cert_pem", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		pki := newTestPKI(t)
+		server := tlsServer(t, pki, false, 0)
+		it := &HTTPRequestResource{}
+		model := requestModel(types.MapNull(types.StringType))
+		model.CACertPEM = types.StringValue(pki.caPEM)
+
+		// when
+		err := getWithTLS(it, model, server.URL)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should read the CA bundle and the client certificate from files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		pki := newTestPKI(t)
+		server := tlsServer(t, pki, true, 0)
+		dir := t.TempDir()
+		write := func(name, contents string) types.String {
+			file := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(file, []byte(contents), 0o600))
+
+			return types.StringValue(file)
+		}
+		it := &HTTPRequestResource{}
+		model := requestModel(types.MapNull(types.StringType))
+		model.CACertFile = write("ca.pem", pki.caPEM)
+		model.ClientCertFile = write("client.pem", pki.clientCertPEM)
+		model.ClientKeyFile = write("client-key.pem", pki.clientKeyPEM)
+
+		// when
+		err := getWithTLS(it, model, server.URL)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should combine the provider CA with a resource client certificate for mutual TLS", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		pki := newTestPKI(t)
+		server := tlsServer(t, pki, true, 0)
+		config := entities.NewConfiguration("")
+		config.TLS = &entities.TLSConfig{CACertPEM: pki.caPEM}
+		it := &HTTPRequestResource{internal: entities.NewInternalContext(false, config)}
+		withoutCert := requestModel(types.MapNull(types.StringType))
+		withCert := requestModel(types.MapNull(types.StringType))
+		withCert.ClientCertPEM = types.StringValue(pki.clientCertPEM)
+		withCert.ClientKeyPEM = types.StringValue(pki.clientKeyPEM)
+
+		// when
+		errWithout := getWithTLS(it, withoutCert, server.URL)
+		errWith := getWithTLS(it, withCert, server.URL)
+
+		// then
+		require.Error(t, errWithout, "the server requires a client certificate")
+		require.NoError(t, errWith)
+	})
+
+	t.Run("should verify the certificate against tls_server_name", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		pki := newTestPKI(t)
+		server := tlsServer(t, pki, false, 0)
+		it := &HTTPRequestResource{}
+		model := requestModel(types.MapNull(types.StringType))
+		model.CACertPEM = types.StringValue(pki.caPEM)
+		model.TLSServerName = types.StringValue("other.internal.test")
+
+		// when
+		err := getWithTLS(it, model, server.URL)
+
+		// then
+		require.Error(t, err, "the certificate is not valid for the overriding name")
+		assert.Contains(t, err.Error(), "other.internal.test")
+	})
+
+	t.Run("should refuse a server below min_tls_version", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		pki := newTestPKI(t)
+		server := tlsServer(t, pki, false, tls.VersionTLS12)
+		it := &HTTPRequestResource{}
+		model := requestModel(types.MapNull(types.StringType))
+		model.CACertPEM = types.StringValue(pki.caPEM)
+		model.MinTLSVersion = types.StringValue("1.3")
+
+		// when
+		err := getWithTLS(it, model, server.URL)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should reuse the provider transport when the resource overrides nothing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		pki := newTestPKI(t)
+		var diagnostics diag.Diagnostics
+		model := HTTPProviderModel{
+			IgnoreTLS:      types.BoolNull(),
+			tlsArguments:   nullTLSArguments(),
+			proxyArguments: nullProxyArguments(),
+		}
+		model.CACertPEM = types.StringValue(pki.caPEM)
+		internal := entities.NewInternalContext(false, entities.NewConfiguration(""))
+		transport := providerTransport(model, internal.Config, &diagnostics)
+		require.False(t, diagnostics.HasError())
+		require.NotNil(t, internal.Config.TLS)
+		internal.Client.Transport = transport
+		it := &HTTPRequestResource{internal: internal}
+
+		// when
+		resolved, err := it.resolveTransport(requestModel(types.MapNull(types.StringType)))
+
+		// then
+		require.NoError(t, err)
+		assert.Same(t, transport, resolved)
+	})
+}
+
+func TestCheckTLSArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a PEM value together with its file counterpart", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullTLSArguments()
+		args.CACertPEM = types.StringValue("pem")
+		args.CACertFile = types.StringValue("/etc/ssl/ca.pem")
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkTLSArguments(args, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Conflicting TLS arguments", diagnostics[0].Summary())
+	})
+
+	t.Run("should reject a client certificate without its key", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullTLSArguments()
+		args.ClientCertFile = types.StringValue("/etc/ssl/client.pem")
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkTLSArguments(args, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Incomplete client certificate", diagnostics[0].Summary())
+	})
+
+	t.Run("should reject an unknown min_tls_version", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullTLSArguments()
+		args.MinTLSVersion = types.StringValue("TLS1.2")
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkTLSArguments(args, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid minimum TLS version", diagnostics[0].Summary())
+	})
+
+	t.Run("should accept a complete configuration", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		args := nullTLSArguments()
+		args.CACertFile = types.StringValue("/etc/ssl/ca.pem")
+		args.ClientCertPEM = types.StringValue("cert")
+		args.ClientKeyFile = types.StringValue("/etc/ssl/client-key.pem")
+		args.MinTLSVersion = types.StringValue("1.3")
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkTLSArguments(args, &diagnostics)
+
+		// then
+		assert.Empty(t, diagnostics)
+	})
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// resolveTransport returns the transport of a request, or nil for http.DefaultTransport. The
+// provider-level transport, which already carries the provider-level TLS and proxy settings, is
+// reused while the resource overrides none of them so the connection pool stays shared.
+func (it *HTTPRequestResource) resolveTransport(model HTTPRequestResourceModel) (http.RoundTripper, error) {
+	socket, err := it.resolveUnixSocket(model)
+	if err != nil {
+		return nil, err
+	}
+	if socket != nil {
+		return entities.NewUnixSocketTransport(socket.Path, it.providerConnectionPool())
+	}
+
+	ignoreTLS := it.resolveIgnoreTLS(model)
+
+	tlsSettings, ownTLS, err := it.resolveTLS(model)
+	if err != nil {
+		return nil, err
+	}
+	proxy, ownProxy := it.resolveProxy(model)
+
+	if !ownTLS && !ownProxy && !it.overridesIgnoreTLS(model) && it.internal != nil && it.internal.Client != nil &&
+		it.internal.Client.Transport != nil {
+		return it.internal.Client.Transport, nil
+	}
+
+	if tlsSettings == nil && proxy == nil {
+		if ignoreTLS {
+			return it.resolveInsecureTransport()
+		}
+
+		return nil, nil //nolint:nilnil // nil selects http.DefaultTransport
+	}
+
+	clientConfig, err := transportTLSConfig(tlsSettings, ignoreTLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewTransport(clientConfig, proxy, it.providerConnectionPool())
+}
+
+// overridesIgnoreTLS reports whether the resource, or the endpoint it selects, sets `ignore_tls` in
+// place of the provider-level value the provider-level transport was built with.
+func (it *HTTPRequestResource) overridesIgnoreTLS(model HTTPRequestResourceModel) bool {
+	if !model.IgnoreTLS.IsNull() {
+		return true
+	}
+	endpoint := it.endpoint(model)
+
+	return endpoint != nil && endpoint.IgnoreTLS != nil
+}
+
+// descUnixSocketURL completes the descriptions of the provider `url` and of every `base_url`.
+const descUnixSocketURL = " A `unix://` URL such as `unix:///var/run/docker.sock` sends the requests over " +
+	"that Unix domain socket, for daemons that listen on nothing else; its optional host, as in " +
+	"`unix://docker/var/run/docker.sock`, is the HTTP host they are addressed to, `localhost` by default."
+
+// resolveUnixSocket returns the Unix socket the base URL of a request names, or nil when the request
+// is sent over TCP.
+func (it *HTTPRequestResource) resolveUnixSocket(model HTTPRequestResourceModel) (*entities.UnixSocket, error) {
+	baseURL, err := url.Parse(it.resolveBaseURL(model))
+	if err != nil {
+		return nil, fmt.Errorf("parsing the base URL: %w", err)
+	}
+
+	return entities.UnixSocketOf(baseURL)
+}
+
+// transportTLSConfig returns the TLS configuration of a transport, nil keeping the default.
+func transportTLSConfig(tlsSettings *entities.TLSConfig, ignoreTLS bool) (*tls.Config, error) {
+	if tlsSettings == nil {
+		if ignoreTLS {
+			return entities.InsecureTLSConfig(), nil
+		}
+
+		return nil, nil //nolint:nilnil // nil keeps the default
+	}
+
+	return tlsSettings.ClientConfig(ignoreTLS)
+}
+
+// providerConnectionPool returns the provider-level connection pool settings, nil when there are
+// none.
+func (it *HTTPRequestResource) providerConnectionPool() *entities.ConnectionPool {
+	if config := it.providerConfig(); config != nil {
+		return config.ConnectionPool
+	}
+
+	return nil
+}
+
+// resolveClientSettings resolves everything the HTTP client of a request is built from, reading the
+// TLS files the resource names so a renewed certificate gets a client of its own.
+func (it *HTTPRequestResource) resolveClientSettings(model HTTPRequestResourceModel) (entities.ClientSettings, error) {
+	tlsSettings, _, err := it.resolveTLS(model)
+	if err != nil {
+		return entities.ClientSettings{}, err
+	}
+	proxy, _ := it.resolveProxy(model)
+	socket, err := it.resolveUnixSocket(model)
+	if err != nil {
+		return entities.ClientSettings{}, err
+	}
+
+	return entities.ClientSettings{
+		TLS:       tlsSettings,
+		IgnoreTLS: it.resolveIgnoreTLS(model),
+		Proxy:     proxy,
+		Redirect:  it.resolveRedirectPolicy(model),
+		Timeout:   it.resolveTimeout(model),
+		Retry:     it.resolveRetry(model),
+		Digest:    it.resolveDigestAuth(model) != nil,
+		Socket:    socket,
+	}, nil
+}
+
+// providerTransport resolves the provider-level TLS, proxy and connection pool arguments into the
+// configuration and builds the transport they need, recording an error for a file that cannot be
+// read, a certificate that does not load or a proxy that cannot be used. It returns nil when none
+// of them is set, leaving the transport NewInternalContext chose.
+func providerTransport(
+	model HTTPProviderModel, config *entities.Configuration, diagnostics *diag.Diagnostics,
+) *http.Transport {
+	tlsSettings, err := tlsConfigOf(model.tlsArguments)
+	if err != nil {
+		diagnostics.AddError("Invalid TLS configuration for HTTP client", err.Error())
+
+		return nil
+	}
+	proxy := proxyConfigOf(model.proxyArguments)
+	pool := connectionPoolOf(model)
+	if tlsSettings == nil && proxy == nil && pool == nil {
+		return nil
+	}
+
+	clientConfig, err := transportTLSConfig(tlsSettings, model.IgnoreTLS.ValueBool())
+	if err != nil {
+		diagnostics.AddError("Invalid TLS configuration for HTTP client", err.Error())
+
+		return nil
+	}
+
+	transport, err := entities.NewTransport(clientConfig, proxy, pool)
+	if err != nil {
+		diagnostics.AddError("Invalid proxy configuration for HTTP client", err.Error())
+
+		return nil
+	}
+	config.TLS = tlsSettings
+	config.Proxy = proxy
+	config.ConnectionPool = pool
+
+	return transport
+}
+
+// connectionPoolOf converts the provider-level connection pool arguments, returning nil when none
+// is set.
+func connectionPoolOf(model HTTPProviderModel) *entities.ConnectionPool {
+	if model.MaxIdleConns.IsNull() && model.IdleConnTimeoutMs.IsNull() && model.KeepAlive.IsNull() {
+		return nil
+	}
+
+	return &entities.ConnectionPool{
+		MaxIdleConns:      int(model.MaxIdleConns.ValueInt64()),
+		IdleConnTimeout:   time.Duration(model.IdleConnTimeoutMs.ValueInt64()) * time.Millisecond,
+		DisableKeepAlives: !model.KeepAlive.IsNull() && !model.KeepAlive.ValueBool(),
+	}
+}
@@ -0,0 +1,296 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/domain/entities"
+)
+
+// resourceWithProviderPool builds a resource whose provider configuration sets the given connection
+// pool arguments, the way Configure does.
+func resourceWithProviderPool(
+	t *testing.T, maxIdleConns, idleConnTimeoutMs types.Int64, keepAlive types.Bool,
+) *HTTPRequestResource {
+	t.Helper()
+
+	var diagnostics diag.Diagnostics
+	model := HTTPProviderModel{
+		IgnoreTLS:         types.BoolNull(),
+		MaxIdleConns:      maxIdleConns,
+		IdleConnTimeoutMs: idleConnTimeoutMs,
+		KeepAlive:         keepAlive,
+		tlsArguments:      nullTLSArguments(),
+		proxyArguments:    nullProxyArguments(),
+	}
+	internal := entities.NewInternalContext(false, entities.NewConfiguration(""))
+	if transport := providerTransport(model, internal.Config, &diagnostics); transport != nil {
+		internal.Client.Transport = transport
+	}
+	require.False(t, diagnostics.HasError(), "the connection pool arguments must be usable")
+
+	return &HTTPRequestResource{internal: internal}
+}
+
+func TestClientCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should hand requests with the same settings the same client", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := &HTTPRequestResource{internal: entities.NewInternalContext(false, entities.NewConfiguration(""))}
+		first := requestModel(types.MapNull(types.StringType))
+		second := requestModel(resourceHeaderMap(t, map[string]string{"X-Trace": "1"}))
+		second.Path = types.StringValue("/other")
+
+		// when
+		firstClient, firstErr := it.getHTTPClient(context.Background(), first)
+		secondClient, secondErr := it.getHTTPClient(context.Background(), second)
+
+		// then
+		require.NoError(t, firstErr)
+		require.NoError(t, secondErr)
+		assert.Same(t, firstClient, secondClient, "what is requested does not change how it is sent")
+	})
+
+	t.Run("should build another client when the effective settings differ", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := &HTTPRequestResource{internal: entities.NewInternalContext(false, entities.NewConfiguration(""))}
+		model := requestModel(types.MapNull(types.StringType))
+		timedOut := requestModel(types.MapNull(types.StringType))
+		timedOut.RequestTimeoutMs = types.Int64Value(1500)
+
+		// when
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
+		timedOutClient, err := it.getHTTPClient(context.Background(), timedOut)
+		require.NoError(t, err)
+
+		// then
+		assert.NotSame(t, client, timedOutClient)
+		assert.Equal(t, 1500*time.Millisecond, timedOutClient.Timeout)
+	})
+
+	t.Run("should reuse the client of a resource-level ignore_tls override", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := &HTTPRequestResource{internal: entities.NewInternalContext(false, entities.NewConfiguration(""))}
+		model := requestModel(types.MapNull(types.StringType))
+		model.IgnoreTLS = types.BoolValue(true)
+
+		// when
+		first, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
+		second, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
+
+		// then
+		assert.Same(t, first, second, "the override no longer allocates a transport per request")
+	})
+
+	t.Run("should build a single client for concurrent requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := &HTTPRequestResource{internal: entities.NewInternalContext(false, entities.NewConfiguration(""))}
+		model := requestModel(types.MapNull(types.StringType))
+		model.RequestTimeoutMs = types.Int64Value(2000)
+		const workers = 16
+		clients := make([]*http.Client, workers)
+		var wg sync.WaitGroup
+
+		// when
+		for i := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				clients[i], _ = it.getHTTPClient(context.Background(), model)
+			}()
+		}
+		wg.Wait()
+
+		// then
+		require.NotNil(t, clients[0])
+		for _, client := range clients {
+			assert.Same(t, clients[0], client)
+		}
+	})
+}
+
+// idleCloser is a transport that counts the calls to CloseIdleConnections.
+type idleCloser struct {
+	http.RoundTripper
+	closed atomic.Int64
+}
+
+func (it *idleCloser) CloseIdleConnections() {
+	it.closed.Add(1)
+}
+
+func TestClientCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	// cachedWith returns the client cached for a timeout, building one over transport when there is none.
+	cachedWith := func(
+		t *testing.T, internal *entities.InternalContext, timeout int, transport http.RoundTripper,
+	) *http.Client {
+		t.Helper()
+
+		client, err := internal.CachedClient(
+			entities.ClientSettings{Timeout: time.Duration(timeout) * time.Millisecond},
+			func() (*http.Client, error) { return &http.Client{Transport: transport}, nil },
+		)
+		require.NoError(t, err)
+
+		return client
+	}
+
+	t.Run("should drop the least recently used client past the bound", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		internal := entities.NewInternalContext(false, entities.NewConfiguration(""))
+		first := cachedWith(t, internal, 0, nil)
+		second := cachedWith(t, internal, 1, nil)
+		for timeout := 2; timeout < entities.MaxCachedClients; timeout++ {
+			cachedWith(t, internal, timeout, nil)
+		}
+		cachedWith(t, internal, 0, nil)
+
+		// when
+		cachedWith(t, internal, entities.MaxCachedClients, nil)
+
+		// then
+		assert.Same(t, first, cachedWith(t, internal, 0, nil), "a client in use is kept")
+		assert.NotSame(t, second, cachedWith(t, internal, 1, nil), "the least recently used client is dropped")
+	})
+
+	t.Run("should close the idle connections of a dropped client", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		internal := entities.NewInternalContext(false, entities.NewConfiguration(""))
+		dropped := &idleCloser{RoundTripper: http.DefaultTransport}
+		cachedWith(t, internal, 0, dropped)
+
+		// when
+		for timeout := 1; timeout <= entities.MaxCachedClients; timeout++ {
+			cachedWith(t, internal, timeout, nil)
+		}
+
+		// then
+		assert.Equal(t, int64(1), dropped.closed.Load())
+	})
+
+	t.Run("should reach the transport of a retrying client when closing its idle connections", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		transport := &idleCloser{RoundTripper: http.DefaultTransport}
+		it := &HTTPRequestResource{internal: entities.NewInternalContext(false, entities.NewConfiguration(""))}
+		it.internal.Client.Transport = transport
+		it.internal.Throttle = entities.NewThrottle(entities.ThrottleConfig{MaxConcurrentRequests: 2})
+		model := requestModel(types.MapNull(types.StringType))
+		model.Retry = retryObject(types.Int64Value(2), types.Int64Value(1), types.Int64Value(1))
+		client, err := it.getHTTPClient(context.Background(), model)
+		require.NoError(t, err)
+
+		// when
+		client.CloseIdleConnections()
+
+		// then
+		assert.Equal(t, int64(1), transport.closed.Load())
+	})
+}
+
+func TestConnectionPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should apply the provider connection pool to the shared transport", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderPool(t, types.Int64Value(8), types.Int64Value(5000), types.BoolValue(false))
+
+		// when
+		client, err := it.getHTTPClient(context.Background(), requestModel(types.MapNull(types.StringType)))
+
+		// then
+		require.NoError(t, err)
+		transport, ok := client.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, 8, transport.MaxIdleConns)
+		assert.Equal(t, 8, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, 5*time.Second, transport.IdleConnTimeout)
+		assert.True(t, transport.DisableKeepAlives)
+	})
+
+	t.Run("should carry the provider connection pool into a resource-level transport", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderPool(t, types.Int64Value(4), types.Int64Null(), types.BoolNull())
+		model := requestModel(types.MapNull(types.StringType))
+		model.IgnoreTLS = types.BoolValue(true)
+
+		// when
+		client, err := it.getHTTPClient(context.Background(), model)
+
+		// then
+		require.NoError(t, err)
+		transport, ok := client.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, 4, transport.MaxIdleConns)
+		assert.False(t, transport.DisableKeepAlives, "keep-alive stays on unless it is turned off")
+	})
+
+	t.Run("should let every host use the whole pool by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderPool(t, types.Int64Null(), types.Int64Null(), types.BoolValue(true))
+
+		// when
+		client, err := it.getHTTPClient(context.Background(), requestModel(types.MapNull(types.StringType)))
+
+		// then
+		require.NoError(t, err)
+		transport, ok := client.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	})
+}
+
+func TestCheckConnectionPoolArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a negative idle_conn_timeout_ms", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := HTTPProviderModel{MaxIdleConns: types.Int64Value(10), IdleConnTimeoutMs: types.Int64Value(-1)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		checkConnectionPoolArguments(model, &diagnostics)
+
+		// then
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Invalid connection pool setting", diagnostics[0].Summary())
+	})
+}
@@ -0,0 +1,80 @@
+//go:build integration
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/rios0rios0/terraform-provider-http/test/infrastructure/builders"
+)
+
+func TestHTTPRequestResource_UnixSocket(t *testing.T) {
+	t.Run("should create, refresh and delete an object through a Unix socket", func(t *testing.T) {
+		// given
+		var mu sync.Mutex
+		var requests []string
+		socketPath := unixSocketServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			switch r.Method {
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"Id":"c0ffee","State":"running"}`))
+			}
+		}))
+		config := builders.NewProviderTFBuilder().Build() +
+			builders.NewResourceTFBuilder().
+				WithName("container").
+				WithBaseURL("unix://"+socketPath).
+				WithMethod("POST").
+				WithPath("/containers/create").
+				WithRequestBody(`jsonencode({ Image = "nginx" })`).
+				WithIsResponseBodyJSON(true).
+				WithResponseBodyIDFilter("$.Id").
+				WithIsRefreshEnabled(true).
+				WithRefreshPath("/containers/$.Id/json").
+				WithIsDeleteEnabled(true).
+				WithDeleteMethod("DELETE").
+				WithDeletePath("/containers/$.Id").
+				Build()
+		deleted := func(_ *terraform.State) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !slices.Contains(requests, "DELETE /containers/c0ffee") {
+				return fmt.Errorf("expected the container to be deleted through the socket, got %v", requests)
+			}
+
+			return nil
+		}
+
+		// when
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			CheckDestroy:             deleted,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("http_request.container", "response_body_id", "c0ffee"),
+						resource.TestCheckResourceAttr("http_request.container", "response_body_json.State", "running"),
+					),
+				},
+			},
+		})
+
+		// then
+		// CheckDestroy saw the DELETE reach the socket.
+	})
+}
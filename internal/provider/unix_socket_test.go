@@ -0,0 +1,144 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unixSocketServer serves the handler on a Unix socket of its own and returns the socket file. The
+// directory is kept short because socket paths are limited to about a hundred bytes.
+func unixSocketServer(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "uds")
+	require.NoError(t, err)
+	socketPath := filepath.Join(dir, "api.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: handler} //nolint:gosec // a test server on a local socket
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	return socketPath
+}
+
+// hostEcho answers every request with the host and the path it was addressed to.
+func hostEcho() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host + r.URL.RequestURI()))
+	})
+}
+
+func TestUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send the request over the socket of the base URL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		socketPath := unixSocketServer(t, hostEcho())
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := pollingModel("unix://"+socketPath, types.ObjectNull(waitForObjectAttrTypes()))
+		model.Path = types.StringValue("/containers/json")
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "localhost/containers/json", string(exchange.body))
+	})
+
+	t.Run("should address the requests to the host the URL names", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		socketPath := unixSocketServer(t, hostEcho())
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := pollingModel("unix://docker"+socketPath, types.ObjectNull(waitForObjectAttrTypes()))
+		model.QueryParameters = resourceHeaderMap(t, map[string]string{"all": "true"})
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "docker/resource?all=true", string(exchange.body))
+	})
+
+	t.Run("should take the socket from the provider url", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		socketPath := unixSocketServer(t, hostEcho())
+		it := resourceWithProviderAuth(nil, nil, nil)
+		it.internal.Config.URL = "unix://" + socketPath
+		model := pollingModel("", types.ObjectNull(waitForObjectAttrTypes()))
+		model.BaseURL = types.StringNull()
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), model, &diagnostics)
+
+		// then
+		require.True(t, ok, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "localhost/resource", string(exchange.body))
+	})
+
+	t.Run("should keep one client per socket", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		first := unixSocketServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("first"))
+		}))
+		second := unixSocketServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("second"))
+		}))
+		it := resourceWithProviderAuth(nil, nil, nil)
+		var diagnostics diag.Diagnostics
+
+		// when
+		firstExchange, firstOK := it.performRequest(context.Background(),
+			pollingModel("unix://"+first, types.ObjectNull(waitForObjectAttrTypes())), &diagnostics)
+		secondExchange, secondOK := it.performRequest(context.Background(),
+			pollingModel("unix://"+second, types.ObjectNull(waitForObjectAttrTypes())), &diagnostics)
+
+		// then
+		require.True(t, firstOK && secondOK, "diagnostics: %v", diagnostics)
+		assert.Equal(t, "first", string(firstExchange.body))
+		assert.Equal(t, "second", string(secondExchange.body))
+	})
+
+	t.Run("should reject a unix URL that names no socket", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		it := resourceWithProviderAuth(nil, nil, nil)
+		model := pollingModel("unix://docker", types.ObjectNull(waitForObjectAttrTypes()))
+
+		// when
+		_, diagnostics := it.buildFullURL(context.Background(), model)
+
+		// then
+		require.True(t, diagnostics.HasError())
+		assert.Contains(t, diagnostics[0].Detail(), "must name the socket file")
+	})
+}
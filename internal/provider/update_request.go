@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+// addUpdateControlAttributes adds the opt-in dedicated update request. They are write-only, like the
+// destroy controls, because only Update and the plan modifiers need them and both receive the
+// configuration.
+func addUpdateControlAttributes(attrs map[string]schema.Attribute) {
+	attrs[attrUpdateMethod] = helpers.StringAttributeWriteOnly(false,
+		"HTTP method of the dedicated update request (e.g., PATCH, PUT). When set, a change to "+
+			"`method`, `path`, `headers`, `request_body`, `content_type`, `request_body_hash`, "+
+			"`multipart` or `query_parameters` updates the resource in "+
+			"place by sending this request instead of re-issuing the create request, which for a POST "+
+			"would create a second remote object. When unset, such a change replaces the resource.")
+	attrs[attrUpdatePath] = helpers.StringAttributeWriteOnly(false,
+		"Path of the update request. Defaults to `path`. Supports the same inline JSONPath tokens as "+
+			"`delete_path` (e.g. \"/widgets/$.id\"), evaluated against the `response_body` captured "+
+			"before the update. Requires `update_method`.")
+	attrs[attrUpdateHeaders] = helpers.MapAttributeWriteOnly(false, types.StringType,
+		"Headers to send only with the update request. Defaults to `headers`. Requires `update_method`.")
+	attrs[attrUpdateRequestBody] = helpers.StringAttributeWriteOnly(false,
+		"Body to send only with the update request. Defaults to `request_body`. Requires `update_method`.")
+}
+
+// updateInPlaceAttributes returns the request arguments a configured update request changes in
+// place. `base_url` and `ignore_tls` are absent on purpose: they point the request at another
+// server, where there is no object for the update to change.
+func updateInPlaceAttributes() map[string]struct{} {
+	return map[string]struct{}{
+		attrMethod:          {},
+		attrPath:            {},
+		attrHeaders:         {},
+		attrRequestBody:     {},
+		attrRequestBodyHash: {},
+		attrContentType:     {},
+		attrMultipart:       {},
+		attrQueryParameters: {},
+	}
+}
+
+// isUpdateRequestConfigured reports whether the model carries an update method. The update
+// controls are write-only, so the model must have been filled from configuration.
+func isUpdateRequestConfigured(model HTTPRequestResourceModel) bool {
+	return isNonEmptyString(model.UpdateMethod)
+}
+
+// updateRequestConfiguredIn reports whether the configuration declares an update request.
+func updateRequestConfiguredIn(
+	ctx context.Context,
+	config tfsdk.Config,
+	diagnostics *diag.Diagnostics,
+) bool {
+	if config.Raw.IsNull() {
+		return false
+	}
+
+	var method types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root(attrUpdateMethod), &method)...)
+
+	// An unknown method is still a configured one: it will hold a value by apply time.
+	return method.IsUnknown() || isNonEmptyString(method)
+}
+
+// isUpdatedInPlace reports whether a change to the named attribute is carried out by the update
+// request rather than by replacing the resource.
+func isUpdatedInPlace(
+	ctx context.Context,
+	attribute string,
+	config tfsdk.Config,
+	diagnostics *diag.Diagnostics,
+) bool {
+	if _, ok := updateInPlaceAttributes()[attribute]; !ok {
+		return false
+	}
+
+	return updateRequestConfiguredIn(ctx, config, diagnostics)
+}
+
+// copyWriteOnlyUpdateParams restores the write-only update controls from configuration, for the
+// same reason copyWriteOnlyDeleteParams exists.
+func copyWriteOnlyUpdateParams(
+	ctx context.Context,
+	config tfsdk.Config,
+	model *HTTPRequestResourceModel,
+) diag.Diagnostics {
+	var configModel HTTPRequestResourceModel
+
+	diagnostics := config.Get(ctx, &configModel)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	model.UpdateMethod = configModel.UpdateMethod
+	model.UpdatePath = configModel.UpdatePath
+	model.UpdateHeaders = configModel.UpdateHeaders
+	model.UpdateRequestBody = configModel.UpdateRequestBody
+
+	return diagnostics
+}
+
+// validateUpdateControls rejects the update arguments when no update method is configured, since
+// without one they would silently do nothing.
+func validateUpdateControls(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var method types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrUpdateMethod), &method)...)
+	if resp.Diagnostics.HasError() || method.IsUnknown() || isNonEmptyString(method) {
+		return
+	}
+
+	var body, updatePath types.String
+	var headers types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrUpdatePath), &updatePath)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrUpdateHeaders), &headers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrUpdateRequestBody), &body)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dependents := []struct {
+		attribute string
+		isSet     bool
+	}{
+		{attrUpdatePath, !updatePath.IsNull()},
+		{attrUpdateHeaders, !headers.IsNull()},
+		{attrUpdateRequestBody, !body.IsNull()},
+	}
+	for _, dependent := range dependents {
+		if dependent.isSet {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(dependent.attribute),
+				"Missing update_method",
+				"`"+dependent.attribute+"` only shapes the dedicated update request, which is not "+
+					"sent unless `update_method` is also set.",
+			)
+		}
+	}
+}
+
+// resolveUpdateTargetPath returns the path of the update request, defaulting to the planned `path`
+// and resolving any inline JSONPath tokens against the response captured before the update.
+func resolveUpdateTargetPath(
+	plan HTTPRequestResourceModel,
+	state HTTPRequestResourceModel,
+	diagnostics *diag.Diagnostics,
+) (string, bool) {
+	if !isNonEmptyString(plan.UpdatePath) {
+		return plan.Path.ValueString(), true
+	}
+
+	return resolvePathTokens(
+		plan.UpdatePath.ValueString(),
+		state.ResponseBody.ValueString(),
+		headersOf(context.Background(), state.ResponseHeaders, diagnostics),
+		diagnostics,
+	)
+}
+
+// makeUpdateModel derives the model describing the update request from the plan.
+func makeUpdateModel(plan HTTPRequestResourceModel, targetPath string) HTTPRequestResourceModel {
+	um := plan
+	um.Method = types.StringValue(strings.ToUpper(strings.TrimSpace(plan.UpdateMethod.ValueString())))
+	um.Path = types.StringValue(targetPath)
+
+	if !plan.UpdateRequestBody.IsNull() {
+		replaceRequestBody(&um, plan.UpdateRequestBody)
+	}
+
+	if !plan.UpdateHeaders.IsNull() {
+		um.Headers = plan.UpdateHeaders
+	}
+
+	return um
+}
+
+// updateInPlace sends the dedicated update request and records its response.
+//
+// The identifier is kept: the object the resource represents is the same one create produced, and
+// so is the delete target. An update answered without the object -- 204 No Content, or an
+// acknowledgement such as {"status":"ok"} -- keeps the body and headers captured before it, so the
+// JSONPath tokens of `delete_path`, `refresh_path` and `update_path` still resolve afterwards.
+func (it *HTTPRequestResource) updateInPlace(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+	plan HTTPRequestResourceModel,
+	state HTTPRequestResourceModel,
+) {
+	tflog.Info(ctx, "Starting HTTP update request...")
+
+	resp.Diagnostics.Append(copyWriteOnlyDeleteParams(ctx, req.Config, &plan)...)
+	resp.Diagnostics.Append(prepareRequestBody(ctx, req.Config, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targetPath, ok := resolveUpdateTargetPath(plan, state, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	updateModel := makeUpdateModel(plan, targetPath)
+
+	exchange, ok := it.performRequest(ctx, updateModel, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	if !it.acceptExchange(ctx, plan, exchange, &resp.Diagnostics) {
+		return
+	}
+
+	exchange = capturedUpdateExchange(ctx, plan, state, exchange, &resp.Diagnostics)
+
+	// As in Create, the update has been applied even when the operation it started fails, so the
+	// state is recorded before the polling errors are reported.
+	var waitDiagnostics diag.Diagnostics
+	captured, ok := it.awaitOperation(ctx, plan, exchange, &waitDiagnostics)
+	if !ok {
+		captured = exchange
+	}
+
+	plan.ID = state.ID
+	populateResponseState(ctx, &plan, captured, &resp.Diagnostics)
+	previous, privateDiagnostics := unmarshalDeleteParamsFromPrivate(ctx, req.Private)
+	resp.Diagnostics.Append(privateDiagnostics...)
+	keepDeleteTarget(&plan, state, previous)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, plan, resp.Identity)...)
+	resp.Diagnostics.Append(marshalDeleteParamsToPrivate(ctx, plan, resp.Private)...)
+	resp.Diagnostics.Append(waitDiagnostics...)
+
+	tflog.Info(ctx, "Completed HTTP update request...", map[string]any{"success": true})
+}
+
+// capturedUpdateExchange returns the update exchange to record: the exchange itself when its
+// response describes the object, or one carrying the body and headers captured before the update
+// when it is empty or cannot resolve the path tokens, which would otherwise fail the update after
+// the remote change was applied.
+func capturedUpdateExchange(
+	ctx context.Context,
+	plan HTTPRequestResourceModel,
+	state HTTPRequestResourceModel,
+	exchange *httpExchange,
+	diagnostics *diag.Diagnostics,
+) *httpExchange {
+	if len(exchange.body) > 0 && resolvesPathTokens(plan, exchange) {
+		return exchange
+	}
+
+	kept := *exchange
+	kept.body = []byte(state.ResponseBody.ValueString())
+	kept.headers = keepCapturedHeaders(ctx, state.ResponseHeaders, exchange.headers, diagnostics)
+
+	return &kept
+}
+
+// resolvesPathTokens reports whether every token of `delete_path`, `refresh_path` and `update_path`
+// resolves against the response of the exchange.
+func resolvesPathTokens(plan HTTPRequestResourceModel, exchange *httpExchange) bool {
+	for _, rawPath := range []types.String{plan.DeletePath, plan.RefreshPath, plan.UpdatePath} {
+		if !isNonEmptyString(rawPath) {
+			continue
+		}
+
+		var ignored diag.Diagnostics
+		if _, ok := resolvePathTokens(rawPath.ValueString(), string(exchange.body), exchange.headers, &ignored); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keepDeleteTarget restores the delete target resolved before the update while `delete_path` is
+// unchanged, as an update response naming another identifier must not move what destroy deletes.
+// The write-only `delete_path` applied before is the one kept in private state.
+func keepDeleteTarget(
+	plan *HTTPRequestResourceModel,
+	state HTTPRequestResourceModel,
+	previous *deleteParamsPrivate,
+) {
+	if previous == nil || !isNonEmptyString(state.DeleteResolvedPath) ||
+		plan.DeletePath.ValueString() != previous.DeletePath {
+		return
+	}
+	plan.DeleteResolvedPath = state.DeleteResolvedPath
+}
+
+// keepCapturedHeaders adds the previously captured headers an update response did not repeat, so a
+// `${header.Location}` token keeps resolving after an update answered without a representation.
+func keepCapturedHeaders(
+	ctx context.Context,
+	captured types.Map,
+	headers http.Header,
+	diagnostics *diag.Diagnostics,
+) http.Header {
+	merged := headers.Clone()
+	if merged == nil {
+		merged = make(http.Header)
+	}
+
+	for name, values := range headersOf(ctx, captured, diagnostics) {
+		if _, ok := merged[name]; !ok {
+			merged[name] = values
+		}
+	}
+
+	return merged
+}
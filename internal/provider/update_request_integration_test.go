@@ -0,0 +1,111 @@
+//go:build integration
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/rios0rios0/terraform-provider-http/test/infrastructure/builders"
+)
+
+// updateTracker records every request by method, so a test can tell an in-place update apart from
+// a second create.
+type updateTracker struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func newUpdateServer(t *testing.T) (*httptest.Server, *updateTracker) {
+	t.Helper()
+
+	tracker := &updateTracker{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+
+		tracker.requests = append(tracker.requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id":"%d"}`, len(tracker.requests))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, tracker
+}
+
+func (ut *updateTracker) all() []string {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+
+	return append([]string(nil), ut.requests...)
+}
+
+func TestHTTPRequestResource_UpdateRequest(t *testing.T) {
+	t.Run("should patch the created object instead of creating a second one", func(t *testing.T) {
+		// given
+		srv, tracker := newUpdateServer(t)
+		providerConfig := builders.NewProviderTFBuilder().WithURL(srv.URL).Build()
+		widget := func(name string) string {
+			return providerConfig + builders.NewResourceTFBuilder().
+				WithName("widget").
+				WithMethod("POST").
+				WithPath("/widgets").
+				WithRequestBody(fmt.Sprintf("jsonencode({ name = %q })", name)).
+				WithIsResponseBodyJSON(true).
+				WithResponseBodyIDFilter("$.id").
+				WithUpdateMethod("PATCH").
+				WithUpdatePath("/widgets/$.id").
+				Build()
+		}
+
+		var createdID string
+		captureID := func(s *terraform.State) error {
+			createdID = s.RootModule().Resources["http_request.widget"].Primary.ID
+			return nil
+		}
+		sameID := func(s *terraform.State) error {
+			if got := s.RootModule().Resources["http_request.widget"].Primary.ID; got != createdID {
+				return fmt.Errorf("expected the id %q to survive the update, got %q", createdID, got)
+			}
+			return nil
+		}
+
+		// when / then
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: widget("original"),
+					Check:  captureID,
+				},
+				{
+					Config: widget("renamed"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						sameID,
+						resource.TestCheckResourceAttr("http_request.widget", "response_code", "204"),
+						resource.TestCheckResourceAttr("http_request.widget", "response_body_id", "1"),
+					),
+				},
+			},
+		})
+
+		// then
+		requests := tracker.all()
+		if len(requests) != 2 || requests[0] != "POST /widgets" || requests[1] != "PATCH /widgets/1" {
+			t.Fatalf("expected one POST and one PATCH against the created object, got %v", requests)
+		}
+	})
+}
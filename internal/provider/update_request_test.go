@@ -0,0 +1,306 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resourceConfigWith builds a resource configuration where every attribute is null except the ones
+// supplied, which is all the update-request decisions look at.
+func resourceConfigWith(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	resourceSchema := GetHTTPRequestResourceSchema()
+	objectType, ok := resourceSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	require.True(t, ok, "the resource schema must describe an object")
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return tfsdk.Config{Raw: tftypes.NewValue(objectType, attributes), Schema: resourceSchema}
+}
+
+func TestRequiresReplacementWithUpdateRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should update the request body in place when an update method is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		config := resourceConfigWith(t, map[string]tftypes.Value{
+			attrUpdateMethod: tftypes.NewValue(tftypes.String, "PATCH"),
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		replace := requiresReplacement(context.Background(), attrRequestBody, config, nil, &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError())
+		assert.False(t, replace, "the update request carries a body change, so nothing is replaced")
+	})
+
+	t.Run("should still replace on a base_url change when an update method is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a different server has no object for the update request to change
+		config := resourceConfigWith(t, map[string]tftypes.Value{
+			attrUpdateMethod: tftypes.NewValue(tftypes.String, "PATCH"),
+		})
+		var diagnostics diag.Diagnostics
+
+		// when
+		replace := requiresReplacement(context.Background(), attrBaseURL, config, nil, &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError())
+		assert.True(t, replace)
+	})
+
+	t.Run("should replace on a request body change when no update method is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		config := resourceConfigWith(t, nil)
+		var diagnostics diag.Diagnostics
+
+		// when
+		replace := requiresReplacement(context.Background(), attrRequestBody, config, nil, &diagnostics)
+
+		// then
+		require.False(t, diagnostics.HasError())
+		assert.True(t, replace, "without an update request the historical replacement is kept")
+	})
+}
+
+func TestResolveUpdateTargetPath(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should default to the planned path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		plan := HTTPRequestResourceModel{Path: types.StringValue("/widgets"), UpdatePath: types.StringNull()}
+		state := HTTPRequestResourceModel{ResponseBody: types.StringValue(`{"id":42}`)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		resolved, ok := resolveUpdateTargetPath(plan, state, &diagnostics)
+
+		// then
+		require.True(t, ok)
+		assert.Equal(t, "/widgets", resolved)
+	})
+
+	t.Run("should resolve JSONPath tokens against the response captured before the update", func(t *testing.T) {
+		t.Parallel()
+
+		// given: the plan's own response is unknown until the update has run
+		plan := HTTPRequestResourceModel{
+			Path:         types.StringValue("/widgets"),
+			UpdatePath:   types.StringValue("/widgets/$.id"),
+			ResponseBody: types.StringUnknown(),
+		}
+		state := HTTPRequestResourceModel{ResponseBody: types.StringValue(`{"id":42}`)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		resolved, ok := resolveUpdateTargetPath(plan, state, &diagnostics)
+
+		// then
+		require.True(t, ok)
+		require.False(t, diagnostics.HasError())
+		assert.Equal(t, "/widgets/42", resolved)
+	})
+}
+
+func TestMakeUpdateModel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should fall back to the request headers and body", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		plan := requestModel(resourceHeaderMap(t, map[string]string{"X-Trace": "create"}))
+		plan.RequestBody = types.StringValue(`{"name":"renamed"}`)
+		plan.UpdateMethod = types.StringValue(" patch ")
+		plan.UpdateHeaders = types.MapNull(types.StringType)
+		plan.UpdateRequestBody = types.StringNull()
+
+		// when
+		model := makeUpdateModel(plan, "/widgets/42")
+
+		// then
+		assert.Equal(t, http.MethodPatch, model.Method.ValueString(), "the method is normalized like delete_method")
+		assert.Equal(t, "/widgets/42", model.Path.ValueString())
+		assert.Equal(t, plan.Headers, model.Headers)
+		assert.Equal(t, `{"name":"renamed"}`, model.RequestBody.ValueString())
+	})
+
+	t.Run("should prefer the update headers and body when they are set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		plan := requestModel(resourceHeaderMap(t, map[string]string{"X-Trace": "create"}))
+		plan.RequestBody = types.StringValue(`{"name":"renamed"}`)
+		plan.UpdateMethod = types.StringValue(http.MethodPut)
+		plan.UpdateHeaders = resourceHeaderMap(t, map[string]string{"X-Trace": "update"})
+		plan.UpdateRequestBody = types.StringValue(`{"op":"rename"}`)
+
+		// when
+		model := makeUpdateModel(plan, "/widgets/42")
+
+		// then
+		assert.Equal(t, plan.UpdateHeaders, model.Headers)
+		assert.Equal(t, `{"op":"rename"}`, model.RequestBody.ValueString())
+	})
+}
+
+func TestPerformUpdateRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send the update request to the object create produced", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var method, requestPath, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method, requestPath = r.Method, r.URL.Path
+			raw, _ := io.ReadAll(r.Body)
+			body = string(raw)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		it := resourceWithProviderHeaders(nil)
+		plan := requestModel(types.MapNull(types.StringType))
+		plan.BaseURL = types.StringValue(server.URL)
+		plan.IgnoreTLS = types.BoolNull()
+		plan.RequestTimeoutMs = types.Int64Null()
+		plan.Retry = types.ObjectNull(retryObjectAttrTypes())
+		plan.RequestBody = types.StringValue(`{"name":"renamed"}`)
+		plan.UpdateMethod = types.StringValue(http.MethodPatch)
+		plan.UpdateRequestBody = types.StringNull()
+		var diagnostics diag.Diagnostics
+
+		// when
+		exchange, ok := it.performRequest(context.Background(), makeUpdateModel(plan, "/widgets/42"), &diagnostics)
+
+		// then
+		require.True(t, ok, "the update request must succeed: %v", diagnostics)
+		assert.Equal(t, http.StatusNoContent, exchange.statusCode)
+		assert.Equal(t, http.MethodPatch, method)
+		assert.Equal(t, "/widgets/42", requestPath)
+		assert.Equal(t, `{"name":"renamed"}`, body)
+	})
+}
+
+// updatedWidget returns the plan and state of a widget created as `{"id":"42"}`, whose destroy
+// deletes `/widgets/$.id`.
+func updatedWidget() (HTTPRequestResourceModel, HTTPRequestResourceModel, *deleteParamsPrivate) {
+	plan := pollingModel("https://example.test", types.ObjectNull(waitForObjectAttrTypes()))
+	plan.DeletePath = types.StringValue("/widgets/$.id")
+	plan.UpdatePath = types.StringValue("/widgets/$.id")
+	state := plan
+	state.DeletePath = types.StringNull()
+	state.ResponseBody = types.StringValue(`{"id":"42"}`)
+	state.DeleteResolvedPath = types.StringValue("/widgets/42")
+
+	return plan, state, &deleteParamsPrivate{IsDeleteEnabled: true, DeletePath: "/widgets/$.id"}
+}
+
+func TestRecordUpdateResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep the response captured before an update answered without the id", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		plan, state, previous := updatedWidget()
+		exchange := &httpExchange{statusCode: http.StatusOK, body: []byte(`{"status":"ok"}`)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured := capturedUpdateExchange(context.Background(), plan, state, exchange, &diagnostics)
+		populateResponseState(context.Background(), &plan, captured, &diagnostics)
+		keepDeleteTarget(&plan, state, previous)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.JSONEq(t, `{"id":"42"}`, plan.ResponseBody.ValueString())
+		assert.Equal(t, "/widgets/42", plan.DeleteResolvedPath.ValueString())
+	})
+
+	t.Run("should record an update response that describes the object", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		plan, state, previous := updatedWidget()
+		exchange := &httpExchange{statusCode: http.StatusOK, body: []byte(`{"id":"42","name":"renamed"}`)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured := capturedUpdateExchange(context.Background(), plan, state, exchange, &diagnostics)
+		populateResponseState(context.Background(), &plan, captured, &diagnostics)
+		keepDeleteTarget(&plan, state, previous)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.JSONEq(t, `{"id":"42","name":"renamed"}`, plan.ResponseBody.ValueString())
+		assert.Equal(t, "/widgets/42", plan.DeleteResolvedPath.ValueString())
+	})
+
+	t.Run("should not move the delete target to another id the update response names", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		plan, state, previous := updatedWidget()
+		exchange := &httpExchange{statusCode: http.StatusOK, body: []byte(`{"id":"job-7"}`)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured := capturedUpdateExchange(context.Background(), plan, state, exchange, &diagnostics)
+		populateResponseState(context.Background(), &plan, captured, &diagnostics)
+		keepDeleteTarget(&plan, state, previous)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.Equal(t, "/widgets/42", plan.DeleteResolvedPath.ValueString())
+	})
+
+	t.Run("should resolve a changed delete path against the recorded response", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		plan, state, previous := updatedWidget()
+		plan.DeletePath = types.StringValue("/archive/$.id")
+		exchange := &httpExchange{statusCode: http.StatusOK, body: []byte(`{"id":"42"}`)}
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured := capturedUpdateExchange(context.Background(), plan, state, exchange, &diagnostics)
+		populateResponseState(context.Background(), &plan, captured, &diagnostics)
+		keepDeleteTarget(&plan, state, previous)
+
+		// then
+		require.False(t, diagnostics.HasError(), "diagnostics: %v", diagnostics)
+		assert.Equal(t, "/archive/42", plan.DeleteResolvedPath.ValueString())
+	})
+}
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ohler55/ojg/jp"
+
+	"github.com/rios0rios0/terraform-provider-http/internal/infrastructure/helpers"
+)
+
+// Default polling cadence of the `wait_for` and `delete_wait` blocks.
+const (
+	defaultWaitForIntervalMs int64 = 2000
+	defaultWaitForTimeoutMs  int64 = 600000
+)
+
+// pollCadenceOf reads the `interval_ms` and `timeout_ms` attributes of a polling block, falling
+// back to the defaults when either is unset, unknown or not positive.
+func pollCadenceOf(attrs map[string]attr.Value) (time.Duration, time.Duration) {
+	millisecondsOf := func(name string, fallback int64) time.Duration {
+		if v, ok := attrs[name].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() && v.ValueInt64() > 0 {
+			return time.Duration(v.ValueInt64()) * time.Millisecond
+		}
+
+		return time.Duration(fallback) * time.Millisecond
+	}
+
+	return millisecondsOf(attrIntervalMs, defaultWaitForIntervalMs),
+		millisecondsOf(attrTimeoutMs, defaultWaitForTimeoutMs)
+}
+
+// waitForObjectAttrTypes returns the attribute types of the `wait_for` nested object. Like
+// retryObjectAttrTypes, it MUST be used wherever a typed null `wait_for` value is produced.
+func waitForObjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		attrPath:            types.StringType,
+		attrMethod:          types.StringType,
+		attrSuccessJSONPath: types.StringType,
+		attrSuccessValue:    types.StringType,
+		attrFailureJSONPath: types.StringType,
+		attrFailureValue:    types.StringType,
+		attrIntervalMs:      types.Int64Type,
+		attrTimeoutMs:       types.Int64Type,
+		attrCaptureResponse: types.BoolType,
+	}
+}
+
+// resourceWaitForBlock returns the `wait_for` block. It is stored in state rather than write-only
+// because Delete polls too, and Delete receives no configuration.
+func resourceWaitForBlock() schema.SingleNestedBlock {
+	description := "Polls an asynchronous operation until it completes. When set, create, update " +
+		"and destroy only finish once a poll response satisfies `success_json_path`, which suits APIs " +
+		"that answer `202 Accepted` with an operation to follow. Changing this block never re-sends " +
+		"the request."
+
+	return schema.SingleNestedBlock{
+		Description:         description,
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			attrPath: helpers.StringAttributeNoReplace(false,
+				"Path to poll. Required when the block is set. Supports the same inline tokens as "+
+					"`delete_path` (e.g. \"/operations/$.operation_id\" or `$${header.Location}`), evaluated "+
+					"against the response of the request that started the operation."),
+			attrMethod: helpers.StringAttributeNoReplace(false,
+				"HTTP method of the poll request. Defaults to GET."),
+			attrSuccessJSONPath: helpers.StringAttributeNoReplace(false,
+				"JSONPath evaluated against each poll response (e.g. \"$.status\"). Required when the "+
+					"block is set. The operation has completed once it yields `success_value`."),
+			attrSuccessValue: helpers.StringAttributeNoReplace(false,
+				"Value `success_json_path` must yield for the operation to count as completed. Required "+
+					"when the block is set."),
+			attrFailureJSONPath: helpers.StringAttributeNoReplace(false,
+				"JSONPath evaluated against each poll response before the success condition. When it "+
+					"yields `failure_value` the operation is reported as failed and polling stops."),
+			attrFailureValue: helpers.StringAttributeNoReplace(false,
+				"Value `failure_json_path` must yield for the operation to count as failed."),
+			attrIntervalMs: helpers.Int64AttributeNoReplace(false,
+				"Delay between two polls, in milliseconds. Defaults to `2000`."),
+			attrTimeoutMs: helpers.Int64AttributeNoReplace(false,
+				"How long to keep polling before failing, in milliseconds. Defaults to `600000`."),
+			attrCaptureResponse: helpers.BoolAttributeNoReplace(false,
+				"When true, the final poll response of a create or update becomes the captured response "+
+					"(`response_code`, `response_body` and everything derived from it) instead of the "+
+					"response of the request that started the operation. Defaults to false."),
+		},
+	}
+}
+
+// waitForConfig is the resolved `wait_for` block.
+type waitForConfig struct {
+	method          string
+	path            string
+	successJSONPath string
+	successValue    string
+	failureJSONPath string
+	failureValue    string
+	interval        time.Duration
+	timeout         time.Duration
+	captureResponse bool
+}
+
+// waitForConfigFromObject converts a `wait_for` nested object into its resolved form, applying
+// defaults. It returns nil when the object is null or unknown, meaning "do not poll".
+func waitForConfigFromObject(obj types.Object) *waitForConfig {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	attrs := obj.Attributes()
+	stringOf := func(name string) string {
+		if v, ok := attrs[name].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			return strings.TrimSpace(v.ValueString())
+		}
+
+		return ""
+	}
+
+	interval, timeout := pollCadenceOf(attrs)
+	cfg := &waitForConfig{
+		method:          strings.ToUpper(stringOf(attrMethod)),
+		path:            stringOf(attrPath),
+		successJSONPath: stringOf(attrSuccessJSONPath),
+		successValue:    stringOf(attrSuccessValue),
+		failureJSONPath: stringOf(attrFailureJSONPath),
+		failureValue:    stringOf(attrFailureValue),
+		interval:        interval,
+		timeout:         timeout,
+	}
+	if cfg.method == "" {
+		cfg.method = http.MethodGet
+	}
+	if v, ok := attrs[attrCaptureResponse].(types.Bool); ok {
+		cfg.captureResponse = isBoolTrue(v)
+	}
+
+	return cfg
+}
+
+// validateWaitFor checks the `wait_for` block once it is set: the poll target and the success
+// condition are mandatory, and the JSONPath expressions must parse.
+func validateWaitFor(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var block types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attrWaitFor), &block)...)
+	if resp.Diagnostics.HasError() || block.IsNull() || block.IsUnknown() {
+		return
+	}
+
+	attrs := block.Attributes()
+	for _, required := range []string{attrPath, attrSuccessJSONPath, attrSuccessValue} {
+		if value, ok := attrs[required].(types.String); ok && value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attrWaitFor).AtName(required),
+				"Missing wait_for argument",
+				fmt.Sprintf("`%s` is required when the `wait_for` block is set.", required),
+			)
+		}
+	}
+
+	for _, expression := range []string{attrSuccessJSONPath, attrFailureJSONPath} {
+		value, ok := attrs[expression].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if _, err := jp.ParseString(value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attrWaitFor).AtName(expression),
+				"Invalid JSONPath expression",
+				fmt.Sprintf("%q could not be parsed: %v", value.ValueString(), err),
+			)
+		}
+	}
+
+	failurePath, _ := attrs[attrFailureJSONPath].(types.String)
+	failureValue, _ := attrs[attrFailureValue].(types.String)
+	if failurePath.IsNull() != failureValue.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attrWaitFor).AtName(attrFailureJSONPath),
+			"Incomplete wait_for failure condition",
+			"`failure_json_path` and `failure_value` must be set together.",
+		)
+	}
+}
+
+// awaitOperation polls the operation the trigger exchange started until it completes, and returns
+// the exchange to record: the final poll response when `capture_response` is set, otherwise the
+// trigger itself. Without a `wait_for` block it returns the trigger untouched.
+func (it *HTTPRequestResource) awaitOperation(
+	ctx context.Context,
+	model HTTPRequestResourceModel,
+	trigger *httpExchange,
+	diagnostics *diag.Diagnostics,
+) (*httpExchange, bool) {
+	cfg := waitForConfigFromObject(model.WaitFor)
+	if cfg == nil {
+		return trigger, true
+	}
+
+	pollPath, ok := resolvePathTokens(cfg.path, string(trigger.body), trigger.headers, diagnostics)
+	if !ok {
+		return nil, false
+	}
+
+	pollModel := model
+	pollModel.Method = types.StringValue(cfg.method)
+	pollModel.Path = types.StringValue(pollPath)
+	pollModel.QueryParameters = types.MapNull(types.StringType)
+	replaceRequestBody(&pollModel, types.StringNull())
+
+	deadline := time.Now().Add(cfg.timeout)
+	for attempt := 1; ; attempt++ {
+		exchange, performed := it.performRequest(ctx, pollModel, diagnostics)
+		if !performed || !it.acceptExchange(ctx, model, exchange, diagnostics) {
+			return nil, false
+		}
+
+		succeeded, failed := evaluateWaitFor(cfg, exchange.body, diagnostics)
+		tflog.Debug(ctx, "Polled asynchronous operation...", map[string]any{
+			"attempt": attempt, attrPath: pollPath, "status": exchange.status, "completed": succeeded,
+		})
+
+		if diagnostics.HasError() {
+			return nil, false
+		}
+
+		if failed {
+			diagnostics.AddError(
+				"Asynchronous operation failed",
+				fmt.Sprintf("Polling %s reported `%s` = %q. Response body: %s",
+					pollPath, cfg.failureJSONPath, cfg.failureValue, string(exchange.body)),
+			)
+
+			return nil, false
+		}
+
+		if succeeded {
+			if cfg.captureResponse {
+				return exchange, true
+			}
+
+			return trigger, true
+		}
+
+		if time.Now().Add(cfg.interval).After(deadline) {
+			diagnostics.AddError(
+				"Timed out waiting for the asynchronous operation",
+				fmt.Sprintf("Polling %s did not report `%s` = %q within %s. Last response body: %s",
+					pollPath, cfg.successJSONPath, cfg.successValue, cfg.timeout, string(exchange.body)),
+			)
+
+			return nil, false
+		}
+
+		select {
+		case <-ctx.Done():
+			diagnostics.AddError("Interrupted while waiting for the asynchronous operation", ctx.Err().Error())
+
+			return nil, false
+		case <-time.After(cfg.interval):
+		}
+	}
+}
+
+// evaluateWaitFor reports whether a poll response satisfies the success or the failure condition.
+// A body that is not JSON satisfies neither, so polling carries on until the timeout.
+func evaluateWaitFor(cfg *waitForConfig, body []byte, diagnostics *diag.Diagnostics) (bool, bool) {
+	var document any
+	if err := json.Unmarshal(body, &document); err != nil {
+		return false, false
+	}
+
+	if cfg.failureJSONPath != "" &&
+		jsonPathYields(document, cfg.failureJSONPath, cfg.failureValue, diagnostics) {
+		return false, true
+	}
+
+	return jsonPathYields(document, cfg.successJSONPath, cfg.successValue, diagnostics), false
+}
+
+// jsonPathYields reports whether any value the expression selects renders as the expected string.
+func jsonPathYields(document any, expression, expected string, diagnostics *diag.Diagnostics) bool {
+	expr, err := jp.ParseString(expression)
+	if err != nil {
+		diagnostics.AddError("Invalid JSONPath expression in wait_for", fmt.Sprintf("%q: %v", expression, err))
+
+		return false
+	}
+
+	for _, value := range expr.Get(document) {
+		if helpers.FormatJSONScalar(value) == expected {
+			return true
+		}
+	}
+
+	return false
+}
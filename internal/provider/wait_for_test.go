@@ -0,0 +1,252 @@
+//go:build unit || integration
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForObject builds a `wait_for` block polling `/operations/$.operation` until `$.status` is
+// "done", failing fast on "error".
+func waitForObject(intervalMs, timeoutMs int64, captureResponse bool) types.Object {
+	return types.ObjectValueMust(waitForObjectAttrTypes(), map[string]attr.Value{
+		attrPath:            types.StringValue("/operations/$.operation"),
+		attrMethod:          types.StringNull(),
+		attrSuccessJSONPath: types.StringValue("$.status"),
+		attrSuccessValue:    types.StringValue("done"),
+		attrFailureJSONPath: types.StringValue("$.status"),
+		attrFailureValue:    types.StringValue("error"),
+		attrIntervalMs:      types.Int64Value(intervalMs),
+		attrTimeoutMs:       types.Int64Value(timeoutMs),
+		attrCaptureResponse: types.BoolValue(captureResponse),
+	})
+}
+
+// operationServer answers every poll of /operations/7 with the next status in line, repeating the
+// last one once they run out.
+func operationServer(t *testing.T, statuses ...string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/operations/7" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		index := min(int(polls.Add(1)), len(statuses)) - 1
+		_, _ = w.Write([]byte(`{"status":"` + statuses[index] + `"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &polls
+}
+
+// pollingModel is the smallest model awaitOperation accepts, aimed at the given server.
+func pollingModel(baseURL string, waitFor types.Object) HTTPRequestResourceModel {
+	model := requestModel(types.MapNull(types.StringType))
+	model.BaseURL = types.StringValue(baseURL)
+	model.IgnoreTLS = types.BoolNull()
+	model.RequestTimeoutMs = types.Int64Null()
+	model.Retry = types.ObjectNull(retryObjectAttrTypes())
+	model.ToleratedStatusCodes = types.SetNull(types.Int32Type)
+	model.WaitFor = waitFor
+
+	return model
+}
+
+// acceptedExchange is the `202 Accepted` that starts the operation under test.
+func acceptedExchange() *httpExchange {
+	return &httpExchange{
+		statusCode: http.StatusAccepted,
+		status:     "202 Accepted",
+		body:       []byte(`{"operation":7}`),
+	}
+}
+
+func TestWaitForConfigFromObject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nil when the block is not set", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		cfg := waitForConfigFromObject(types.ObjectNull(waitForObjectAttrTypes()))
+
+		// then
+		assert.Nil(t, cfg, "a null block means the request is not polled")
+	})
+
+	t.Run("should apply the default method and cadence", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		obj := types.ObjectValueMust(waitForObjectAttrTypes(), map[string]attr.Value{
+			attrPath:            types.StringValue("/operations/1"),
+			attrMethod:          types.StringNull(),
+			attrSuccessJSONPath: types.StringValue("$.done"),
+			attrSuccessValue:    types.StringValue("true"),
+			attrFailureJSONPath: types.StringNull(),
+			attrFailureValue:    types.StringNull(),
+			attrIntervalMs:      types.Int64Null(),
+			attrTimeoutMs:       types.Int64Null(),
+			attrCaptureResponse: types.BoolNull(),
+		})
+
+		// when
+		cfg := waitForConfigFromObject(obj)
+
+		// then
+		require.NotNil(t, cfg)
+		assert.Equal(t, http.MethodGet, cfg.method)
+		assert.Equal(t, time.Duration(defaultWaitForIntervalMs)*time.Millisecond, cfg.interval)
+		assert.Equal(t, time.Duration(defaultWaitForTimeoutMs)*time.Millisecond, cfg.timeout)
+		assert.False(t, cfg.captureResponse)
+	})
+}
+
+func TestEvaluateWaitFor(t *testing.T) {
+	t.Parallel()
+
+	cfg := waitForConfigFromObject(waitForObject(1, 1000, false))
+
+	t.Run("should match non-string scalars by their JSON rendering", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		boolCfg := *cfg
+		boolCfg.successJSONPath, boolCfg.successValue = "$.done", "true"
+		var diagnostics diag.Diagnostics
+
+		// when
+		succeeded, failed := evaluateWaitFor(&boolCfg, []byte(`{"done":true}`), &diagnostics)
+
+		// then
+		assert.True(t, succeeded)
+		assert.False(t, failed)
+	})
+
+	t.Run("should keep polling while the body is not JSON", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var diagnostics diag.Diagnostics
+
+		// when
+		succeeded, failed := evaluateWaitFor(cfg, []byte("in progress"), &diagnostics)
+
+		// then
+		assert.False(t, succeeded)
+		assert.False(t, failed)
+		assert.False(t, diagnostics.HasError())
+	})
+}
+
+func TestAwaitOperation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should poll until the success condition holds and keep the trigger response", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, polls := operationServer(t, "running", "running", "done")
+		model := pollingModel(server.URL, waitForObject(1, 5000, false))
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured, ok := (&HTTPRequestResource{}).awaitOperation(
+			context.Background(), model, acceptedExchange(), &diagnostics,
+		)
+
+		// then
+		require.True(t, ok, "the operation completes: %v", diagnostics)
+		assert.Equal(t, int32(3), polls.Load())
+		assert.Equal(t, http.StatusAccepted, captured.statusCode,
+			"without capture_response the response that started the operation is recorded")
+	})
+
+	t.Run("should return the final poll response when capture_response is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, _ := operationServer(t, "running", "done")
+		model := pollingModel(server.URL, waitForObject(1, 5000, true))
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured, ok := (&HTTPRequestResource{}).awaitOperation(
+			context.Background(), model, acceptedExchange(), &diagnostics,
+		)
+
+		// then
+		require.True(t, ok, "the operation completes: %v", diagnostics)
+		assert.Equal(t, http.StatusOK, captured.statusCode)
+		assert.JSONEq(t, `{"status":"done"}`, string(captured.body))
+	})
+
+	t.Run("should stop at the failure condition", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, polls := operationServer(t, "running", "error", "done")
+		model := pollingModel(server.URL, waitForObject(1, 5000, false))
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := (&HTTPRequestResource{}).awaitOperation(
+			context.Background(), model, acceptedExchange(), &diagnostics,
+		)
+
+		// then
+		require.False(t, ok)
+		assert.Equal(t, int32(2), polls.Load(), "no poll follows the failure")
+		assert.Equal(t, "Asynchronous operation failed", diagnostics[0].Summary())
+	})
+
+	t.Run("should time out when the operation never completes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server, _ := operationServer(t, "running")
+		model := pollingModel(server.URL, waitForObject(10, 50, false))
+		var diagnostics diag.Diagnostics
+
+		// when
+		_, ok := (&HTTPRequestResource{}).awaitOperation(
+			context.Background(), model, acceptedExchange(), &diagnostics,
+		)
+
+		// then
+		require.False(t, ok)
+		assert.Equal(t, "Timed out waiting for the asynchronous operation", diagnostics[0].Summary())
+	})
+
+	t.Run("should return the trigger untouched when no block is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		model := pollingModel("http://unused.test", types.ObjectNull(waitForObjectAttrTypes()))
+		trigger := acceptedExchange()
+		var diagnostics diag.Diagnostics
+
+		// when
+		captured, ok := (&HTTPRequestResource{}).awaitOperation(
+			context.Background(), model, trigger, &diagnostics,
+		)
+
+		// then
+		require.True(t, ok)
+		assert.Same(t, trigger, captured)
+	})
+}
@@ -28,6 +28,9 @@ func main() {
 	}
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// Serve returns once Terraform is done with the provider, which is when sessions are logged out;
+	// a provider Terraform kills instead never gets here, so the logout is best-effort
+	provider.Shutdown(context.Background())
 	if err != nil {
 		log.Fatal(err.Error())
 	}
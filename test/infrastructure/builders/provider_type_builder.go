@@ -4,6 +4,13 @@ import "github.com/hashicorp/terraform-plugin-go/tftypes"
 
 const (
 	attrBasicAuth        = "basic_auth"
+	attrBearerAuth       = "bearer_auth"
+	attrToken            = "token"
+	attrAPIKey           = "api_key"
+	attrName             = "name"
+	attrValue            = "value"
+	attrIn               = "in"
+	attrOAuth2           = "oauth2"
 	attrHeaders          = "headers"
 	attrIgnoreTLS        = "ignore_tls"
 	attrUsername         = "username"
@@ -20,9 +27,14 @@ const (
 func retryObjectType() tftypes.Object {
 	return tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			attrAttempts:   tftypes.Number,
-			attrMinDelayMs: tftypes.Number,
-			attrMaxDelayMs: tftypes.Number,
+			attrAttempts:            tftypes.Number,
+			attrMinDelayMs:          tftypes.Number,
+			attrMaxDelayMs:          tftypes.Number,
+			"retry_on_status_codes": tftypes.Set{ElementType: tftypes.Number},
+			"respect_retry_after":   tftypes.Bool,
+			"jitter":                tftypes.Bool,
+			"retry_non_idempotent":  tftypes.Bool,
+			"retry_on_body_match":   tftypes.String,
 		},
 	}
 }
@@ -74,6 +86,151 @@ func (b *ProviderTypeBuilder) WithPassword() *ProviderTypeBuilder {
 	return b
 }
 
+func (b *ProviderTypeBuilder) WithBearerAuth() *ProviderTypeBuilder {
+	b.attributeTypes[attrBearerAuth] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			attrToken: tftypes.String,
+		},
+	}
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithDigestAuth() *ProviderTypeBuilder {
+	b.attributeTypes["digest_auth"] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			attrUsername: tftypes.String,
+			attrPassword: tftypes.String,
+		},
+	}
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithAPIKey() *ProviderTypeBuilder {
+	b.attributeTypes[attrAPIKey] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			attrName:  tftypes.String,
+			attrValue: tftypes.String,
+			attrIn:    tftypes.String,
+		},
+	}
+	return b
+}
+
+// WithTLS adds the TLS arguments, which are all plain strings.
+func (b *ProviderTypeBuilder) WithTLS() *ProviderTypeBuilder {
+	for _, name := range []string{
+		"ca_cert_pem", "ca_cert_file", "client_cert_pem", "client_key_pem",
+		"client_cert_file", "client_key_file", "tls_server_name", "min_tls_version",
+	} {
+		b.attributeTypes[name] = tftypes.String
+	}
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithProxy() *ProviderTypeBuilder {
+	b.attributeTypes["proxy_url"] = tftypes.String
+	b.attributeTypes["proxy_basic_auth"] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			attrUsername: tftypes.String,
+			attrPassword: tftypes.String,
+		},
+	}
+	b.attributeTypes["no_proxy"] = tftypes.List{ElementType: tftypes.String}
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithRedirects() *ProviderTypeBuilder {
+	b.attributeTypes["follow_redirects"] = tftypes.Bool
+	b.attributeTypes["max_redirects"] = tftypes.Number
+	b.attributeTypes["preserve_method_on_redirect"] = tftypes.Bool
+	b.attributeTypes["forward_auth_on_redirect"] = tftypes.Bool
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithConnectionPool() *ProviderTypeBuilder {
+	b.attributeTypes["max_idle_conns"] = tftypes.Number
+	b.attributeTypes["idle_conn_timeout_ms"] = tftypes.Number
+	b.attributeTypes["keep_alive"] = tftypes.Bool
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithThrottle() *ProviderTypeBuilder {
+	b.attributeTypes["rate_limit"] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"requests_per_second": tftypes.Number,
+			"burst":               tftypes.Number,
+		},
+	}
+	b.attributeTypes["max_concurrent_requests"] = tftypes.Number
+	b.attributeTypes["throttle_per_host"] = tftypes.Bool
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithSession() *ProviderTypeBuilder {
+	b.attributeTypes["session"] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"login_path":           tftypes.String,
+			"method":               tftypes.String,
+			"headers":              tftypes.Map{ElementType: tftypes.String},
+			"request_body":         tftypes.String,
+			"success_status_codes": tftypes.List{ElementType: tftypes.Number},
+			"success_cookie":       tftypes.String,
+			"logout_path":          tftypes.String,
+			"logout_method":        tftypes.String,
+		},
+	}
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithEndpoints() *ProviderTypeBuilder {
+	b.attributeTypes["endpoint"] = tftypes.List{
+		ElementType: tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"name": tftypes.String,
+				"url":  tftypes.String,
+				"basic_auth": tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"username": tftypes.String,
+						"password": tftypes.String,
+					},
+				},
+				"headers":            tftypes.Map{ElementType: tftypes.String},
+				"ignore_tls":         tftypes.Bool,
+				"request_timeout_ms": tftypes.Number,
+				attrRetry:            retryObjectType(),
+			},
+		},
+	}
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithOAuth2() *ProviderTypeBuilder {
+	b.attributeTypes[attrOAuth2] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"token_url":     tftypes.String,
+			"client_id":     tftypes.String,
+			"client_secret": tftypes.String,
+			"scopes":        tftypes.List{ElementType: tftypes.String},
+			"audience":      tftypes.String,
+			"extra_params":  tftypes.Map{ElementType: tftypes.String},
+		},
+	}
+	return b
+}
+
+func (b *ProviderTypeBuilder) WithAWSSigV4() *ProviderTypeBuilder {
+	b.attributeTypes["aws_sigv4"] = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"region":        tftypes.String,
+			"service":       tftypes.String,
+			"access_key":    tftypes.String,
+			"secret_key":    tftypes.String,
+			"session_token": tftypes.String,
+		},
+	}
+	return b
+}
+
 func (b *ProviderTypeBuilder) WithHeaders() *ProviderTypeBuilder {
 	b.attributeTypes[attrHeaders] = tftypes.Map{ElementType: tftypes.String}
 	return b
@@ -125,6 +125,30 @@ func (b *ResourceTFBuilder) WithRefreshPath(refreshPath string) *ResourceTFBuild
 	return b
 }
 
+func (b *ResourceTFBuilder) WithUpdateMethod(updateMethod string) *ResourceTFBuilder {
+	b.config += fmt.Sprintf("update_method = \"%s\"\n", updateMethod)
+	return b
+}
+
+func (b *ResourceTFBuilder) WithUpdatePath(updatePath string) *ResourceTFBuilder {
+	b.config += fmt.Sprintf("update_path = \"%s\"\n", updatePath)
+	return b
+}
+
+func (b *ResourceTFBuilder) WithUpdateHeaders(updateHeaders map[string]string) *ResourceTFBuilder {
+	b.config += "update_headers = {\n"
+	for key, value := range updateHeaders {
+		b.config += fmt.Sprintf("  \"%s\" = \"%s\"\n", key, value)
+	}
+	b.config += "}\n"
+	return b
+}
+
+func (b *ResourceTFBuilder) WithUpdateRequestBody(updateRequestBody string) *ResourceTFBuilder {
+	b.config += fmt.Sprintf("update_request_body = %s\n", updateRequestBody)
+	return b
+}
+
 func (b *ResourceTFBuilder) WithToleratedStatusCodes(codes []int) *ResourceTFBuilder {
 	if len(codes) == 0 {
 		return b